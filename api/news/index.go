@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"math"
 	"net/http"
@@ -11,31 +13,86 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hidatara-ds/evolipia-radar/pkg/api"
-	_ "github.com/lib/pq"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
+	"github.com/lib/pq"
 )
 
 const allConst = "all"
 
-var TopicAliases = map[string][]string{
-	"llm":         {"llm", "large language models", "gpt", "claude", "llama", "general_ai"},
-	"agents":      {"agents", "autonomous", "autogpt", "babyagi"},
-	"vision":      {"computer vision", "cv", "image generation", "midjourney", "dalle"},
-	"open-source": {"open source", "oss", "huggingface", "open_source"},
-	"infra":       {"infrastructure", "mlops", "deployment", "kubernetes"},
-	"robotics":    {"robotics", "embodied ai", "control"},
-	"security":    {"security", "safety", "alignment", "jailbreak"},
+// getAliases returns the lowercased tags a topic filter accepts: the topic's
+// slug, its aliases and its sub-topics.
+func getAliases(tax *taxonomy.Taxonomy, topic string) []string {
+	return tax.Expand(topic)
 }
 
-func getAliases(topic string) []string {
-	topic = strings.ToLower(topic)
-	aliases := []string{topic}
-	if mapped, ok := TopicAliases[topic]; ok {
-		aliases = append(aliases, mapped...)
+// taxonomyTTL is how long a warm instance reuses the taxonomy before it is
+// re-read, so topic edits reach the filter without a query per request.
+const taxonomyTTL = time.Minute
+
+var taxonomyCache struct {
+	sync.Mutex
+	tax      *taxonomy.Taxonomy
+	loadedAt time.Time
+}
+
+// loadTaxonomy returns the topic taxonomy, re-reading it from the database
+// once taxonomyTTL has passed. If reading fails the cached taxonomy is kept,
+// or the compiled-in defaults are used until a read succeeds.
+func loadTaxonomy(ctx context.Context, db *sql.DB) *taxonomy.Taxonomy {
+	taxonomyCache.Lock()
+	defer taxonomyCache.Unlock()
+	if taxonomyCache.tax != nil && time.Since(taxonomyCache.loadedAt) < taxonomyTTL {
+		return taxonomyCache.tax
+	}
+
+	tax, err := readTaxonomy(ctx, db)
+	if err != nil {
+		if taxonomyCache.tax != nil {
+			log.Printf("⚠️ %v. Keeping cached taxonomy", err)
+			return taxonomyCache.tax
+		}
+		log.Printf("⚠️ %v. Using default taxonomy", err)
+		return taxonomy.Default()
+	}
+	taxonomyCache.tax, taxonomyCache.loadedAt = tax, time.Now()
+	return tax
+}
+
+func readTaxonomy(ctx context.Context, db *sql.DB) (*taxonomy.Taxonomy, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT slug, COALESCE(parent, ''), name, aliases, keywords, patterns
+		FROM topics
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topics: %w", err)
+	}
+	defer rows.Close()
+
+	var topics []models.Topic
+	for rows.Next() {
+		var t models.Topic
+		if err := rows.Scan(&t.Slug, &t.Parent, &t.Name, pq.Array(&t.Aliases), pq.Array(&t.Keywords), pq.Array(&t.Patterns)); err != nil {
+			return nil, fmt.Errorf("failed to scan topic: %w", err)
+		}
+		topics = append(topics, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load topics: %w", err)
+	}
+	if len(topics) == 0 {
+		return nil, errors.New("topics table is empty")
+	}
+
+	tax, err := taxonomy.New(topics)
+	if err != nil {
+		return nil, fmt.Errorf("stored taxonomy is invalid: %w", err)
 	}
-	return aliases
+	return tax, nil
 }
 
 type NewsItem struct {
@@ -133,7 +190,20 @@ func connectToDB() (*sql.DB, bool) {
 	return db, false
 }
 
-func buildSQLQuery(topics, domains []string, sortMode string, timeThreshold time.Time, searchQuery string) (string, []interface{}) {
+// parseMinConfidence reads the optional min_confidence (0-1) a matching
+// topic tag must have been assigned with.
+func parseMinConfidence(v string) (float64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	minConfidence, err := strconv.ParseFloat(v, 64)
+	if err != nil || minConfidence < 0 || minConfidence > 1 {
+		return 0, errors.New("min_confidence must be a number between 0 and 1")
+	}
+	return minConfidence, nil
+}
+
+func buildSQLQuery(tax *taxonomy.Taxonomy, topics, domains []string, minConfidence float64, sortMode string, timeThreshold time.Time, searchQuery string) (string, []interface{}) {
 	sqlQuery := `
 		SELECT 
 			i.id, i.title, i.url, i.domain, i.published_at, i.category,
@@ -173,14 +243,20 @@ func buildSQLQuery(topics, domains []string, sortMode string, timeThreshold time
 		var topicChecks []string
 		for _, t := range topics {
 			if t != "" && !strings.EqualFold(t, allConst) {
-				aliases := getAliases(t)
+				aliases := getAliases(tax, t)
 				var placeholders []string
 				for _, alias := range aliases {
 					placeholders = append(placeholders, "$"+strconv.Itoa(argIdx))
 					args = append(args, strings.ToLower(alias))
 					argIdx++
 				}
-				topicChecks = append(topicChecks, `EXISTS (SELECT 1 FROM jsonb_array_elements_text(sm.tags) as tag WHERE LOWER(tag) IN (`+strings.Join(placeholders, ",")+`))`)
+				if minConfidence > 0 {
+					topicChecks = append(topicChecks, `EXISTS (SELECT 1 FROM jsonb_array_elements(sm.tag_scores) as ts WHERE LOWER(ts->>'tag') IN (`+strings.Join(placeholders, ",")+`) AND (ts->>'confidence')::float8 >= $`+strconv.Itoa(argIdx)+`)`)
+					args = append(args, minConfidence)
+					argIdx++
+				} else {
+					topicChecks = append(topicChecks, `EXISTS (SELECT 1 FROM jsonb_array_elements_text(sm.tags) as tag WHERE LOWER(tag) IN (`+strings.Join(placeholders, ",")+`))`)
+				}
 			}
 		}
 		if len(topicChecks) > 0 {
@@ -249,6 +325,14 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	sortMode := query.Get("sort")
 	searchQuery := strings.ToLower(query.Get("q"))
 	timeThreshold := getTimeThreshold(query.Get("time"))
+	minConfidence, err := parseMinConfidence(query.Get("min_confidence"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		if encErr := json.NewEncoder(w).Encode(Response{Success: false, Error: err.Error()}); encErr != nil {
+			log.Printf("Failed to encode error response: %v", encErr)
+		}
+		return
+	}
 
 	db, useFallback := connectToDB()
 	if db != nil {
@@ -260,7 +344,9 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sqlQuery, args := buildSQLQuery(topics, domains, sortMode, timeThreshold, searchQuery)
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	sqlQuery, args := buildSQLQuery(loadTaxonomy(ctx, db), topics, domains, minConfidence, sortMode, timeThreshold, searchQuery)
 	rows, err := db.Query(sqlQuery, args...)
 	if err != nil {
 		log.Printf("❌ Failed to query database: %v. Using JSON fallback", err)
@@ -277,7 +363,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	sendSuccessResponse(w, items, time.Now())
 }
 
-func isMatchTopic(rawTags []string, reqTopics []string) bool {
+func isMatchTopic(tax *taxonomy.Taxonomy, rawTags []string, reqTopics []string) bool {
 	if len(reqTopics) == 0 {
 		return true
 	}
@@ -285,7 +371,7 @@ func isMatchTopic(rawTags []string, reqTopics []string) bool {
 		if strings.EqualFold(reqTopic, allConst) || reqTopic == "" {
 			return true
 		}
-		aliases := getAliases(reqTopic)
+		aliases := getAliases(tax, reqTopic)
 		for _, tag := range rawTags {
 			tagLower := strings.ToLower(tag)
 			for _, alias := range aliases {
@@ -310,7 +396,7 @@ func isMatchDomain(itemDomain string, reqDomains []string) bool {
 	return false
 }
 
-func filterJSONItems(dataItems []api.NewsItem, topics, domains []string, timeThreshold time.Time, searchQuery string) []NewsItem {
+func filterJSONItems(tax *taxonomy.Taxonomy, dataItems []api.NewsItem, topics, domains []string, timeThreshold time.Time, searchQuery string) []NewsItem {
 	filtered := make([]NewsItem, 0, len(dataItems))
 	for _, rawItem := range dataItems {
 		if rawItem.PublishedAt.Before(timeThreshold) {
@@ -328,7 +414,7 @@ func filterJSONItems(dataItems []api.NewsItem, topics, domains []string, timeThr
 			}
 		}
 
-		if !isMatchTopic(rawItem.Tags, topics) {
+		if !isMatchTopic(tax, rawItem.Tags, topics) {
 			continue
 		}
 
@@ -384,7 +470,7 @@ func handleJSONFallback(w http.ResponseWriter, topics, domains []string, sortMod
 		return
 	}
 
-	filtered := filterJSONItems(data.Items, topics, domains, timeThreshold, searchQuery)
+	filtered := filterJSONItems(taxonomy.Default(), data.Items, topics, domains, timeThreshold, searchQuery)
 	sortJSONItems(filtered, sortMode)
 
 	if len(filtered) > 30 {
@@ -14,6 +14,10 @@ import (
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/crawler"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/hidatara-ds/evolipia-radar/pkg/reputation"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -53,6 +57,22 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		defer dbConn.Close()
 		database = dbConn
 		pool = dbConn.Pool
+
+		// Score and summarize jobs drained below rank under the active
+		// scoring config and taxonomy; without these they would use defaults.
+		scoringLoader := scoring.NewLoader(cfg.ScoringConfigPath, db.NewSettingRepository(database))
+		scoringLoader.KeepSnapshots(db.NewScoringConfigRepository(database))
+		if _, err := scoringLoader.Reload(r.Context()); err != nil {
+			log.Printf("[VERCEL TRIGGER] Invalid scoring config, using defaults: %v", err)
+		}
+		if cfg.ReputationEnabled {
+			if err := reputation.NewService(database, cfg).Load(r.Context()); err != nil {
+				log.Printf("[VERCEL TRIGGER] Failed to load reputations: %v", err)
+			}
+		}
+		if _, err := taxonomy.NewService(database).Load(r.Context()); err != nil {
+			log.Printf("[VERCEL TRIGGER] Failed to load topic taxonomy, using defaults: %v", err)
+		}
 	}
 
 	clusterService := ai.NewClusterService(aiService, pool)
@@ -66,6 +86,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 
 	summarizer := crawler.NewSummarizer(aiService, database)
 	botOrchestrator := crawler.NewOrchestrator(clusterService, inMemClusterSvc, aiService, metricsData, database, dryRunEnv, summarizer)
+	botOrchestrator.DisableBloomFilter() // one cycle per instance: not worth warming
 
 	// Executing the cycle synchronously for Vercel Serverless
 	// Add an 8-second timeout so Vercel doesn't kill it with 504 Gateway Timeout
@@ -75,6 +96,13 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	stats := botOrchestrator.RunCycle(ctx)
 	botOrchestrator.UpdateClusterMetrics(ctx)
 
+	// Spend whatever time is left on queued enrichment; leftovers wait for the next trigger.
+	if database != nil {
+		runner := jobs.NewRunner(database)
+		botOrchestrator.RegisterJobHandlers(runner)
+		stats["jobs_completed"] = runner.Drain(ctx)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(map[string]interface{}{
 		"status": "completed",
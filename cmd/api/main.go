@@ -18,6 +18,10 @@ import (
 	"github.com/hidatara-ds/evolipia-radar/pkg/crawler"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
 	"github.com/hidatara-ds/evolipia-radar/pkg/http/handlers"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/hidatara-ds/evolipia-radar/pkg/reputation"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 
 	"github.com/hidatara-ds/evolipia-radar/api/news"
 	"github.com/hidatara-ds/evolipia-radar/api/search"
@@ -78,6 +82,29 @@ func main() {
 	defer crawlCancel()
 	go botOrchestrator.Start(crawlCtx, 15*time.Minute)
 
+	// Scoring config: load now, then pick up file or settings edits without a restart
+	scoringLoader := scoring.NewLoader(cfg.ScoringConfigPath, db.NewSettingRepository(database))
+	scoringLoader.KeepSnapshots(db.NewScoringConfigRepository(database))
+	if _, err := scoringLoader.Reload(crawlCtx); err != nil {
+		log.Printf("Invalid scoring config, using defaults: %v", err)
+	}
+	go scoringLoader.Watch(crawlCtx, cfg.ScoringReloadInterval())
+
+	// Learned domain/source reputation feeding credibility
+	go reputation.NewService(database, cfg).Watch(crawlCtx, cfg.ReputationRefreshInterval())
+
+	// Topic taxonomy shared by tagging, topic filters and relevance scoring
+	taxonomyService := taxonomy.NewService(database)
+	if _, err := taxonomyService.Load(crawlCtx); err != nil {
+		log.Printf("Failed to load topic taxonomy, using defaults: %v", err)
+	}
+	go taxonomyService.Watch(crawlCtx, cfg.TaxonomyRefreshInterval())
+
+	// Enrichment jobs (summarize, embed, score, cluster) queued by the crawler
+	jobRunner := jobs.NewRunner(database)
+	botOrchestrator.RegisterJobHandlers(jobRunner)
+	go jobRunner.Start(crawlCtx, 30*time.Second)
+
 	// Health & Observability
 	router.GET("/healthz", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -118,15 +145,49 @@ func main() {
 		v1.GET("/feed", h.GetFeed)
 		v1.GET("/rising", h.GetRising)
 		v1.GET("/items/:id", h.GetItem)
+		v1.GET("/items/:id/score/explain", h.ExplainScore)
+		v1.GET("/items/:id/score/history", h.ScoreHistory)
+		v1.GET("/scores/compare", h.CompareScores)
+		v1.POST("/items/:id/feedback", h.RecordFeedback)
 		v1.GET("/search", h.Search)
 		v1.GET("/sources", h.ListSources)
+		v1.GET("/sources/reputation", h.ListReputations)
 		v1.POST("/sources", h.CreateSource)
 		v1.POST("/sources/test", h.TestSource)
 		v1.PATCH("/sources/:id/enable", h.EnableSource)
+		v1.PATCH("/sources/:id/schedule", h.SetSourceSchedule)
 
 		// Settings API
 		settingsHandler := ai_api.NewSettingsHandler(database)
 		settingsHandler.RegisterRoutes(v1)
+
+		// Discovery agent configuration
+		agentsHandler := ai_api.NewAgentsHandler(database)
+		agentsHandler.RegisterRoutes(v1)
+
+		// Job queue administration
+		jobsHandler := ai_api.NewJobsHandler(database)
+		admin := v1.Group("/admin")
+		jobsHandler.RegisterRoutes(admin)
+
+		// Historical backfill
+		backfillHandler := ai_api.NewBackfillHandler(database, cfg)
+		backfillHandler.RegisterRoutes(admin)
+
+		// Runtime scoring config
+		scoringHandler := ai_api.NewScoringHandler(scoringLoader)
+		scoringHandler.RegisterRoutes(admin)
+
+		// Topic taxonomy: public listing, admin edits
+		taxonomyHandler := ai_api.NewTaxonomyHandler(taxonomyService)
+		v1.GET("/topics", taxonomyHandler.List)
+		taxonomyHandler.RegisterRoutes(admin)
+
+		// Entities: public listing and timelines, admin curation
+		v1.GET("/entities", h.ListEntities)
+		v1.GET("/entities/:id", h.GetEntity)
+		entitiesHandler := ai_api.NewEntitiesHandler(database, cfg)
+		entitiesHandler.RegisterRoutes(admin)
 	}
 
 	srv := &http.Server{
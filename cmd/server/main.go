@@ -4,7 +4,6 @@ package main
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -18,6 +17,10 @@ import (
 	"github.com/hidatara-ds/evolipia-radar/internal/models"
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/reputation"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+	"github.com/hidatara-ds/evolipia-radar/pkg/services"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 )
 
 func main() {
@@ -45,97 +48,68 @@ func main() {
 	// Initialize SSE Progress Broadcaster
 	broadcaster := api.NewProgressBroadcaster()
 
-	// Initialize Validator & Retry Runner
-	validator := crawler.NewValidator(cfg.MinRelevanceScore, cfg.TopicKeywords)
-	retryRunner := crawler.NewRetryRunner(cfg.MaxCrawlRetries, 1*time.Second)
-
-	// Crawl Task definition with step logging & SSE events
-	crawlTaskFunc := func(ctx context.Context, onProgress func(models.CrawlProgressEvent)) (int, error) {
-		slog.Info("Starting crawl step 1: Initializing crawler...")
-		onProgress(models.CrawlProgressEvent{
-			Step:       1,
-			Message:    "Initializing crawler...",
-			Progress:   10,
-			Timestamp:  time.Now(),
-			IsComplete: false,
-		})
-
-		sources := []string{"HackerNews RSS", "ArXiv AI Papers", "Reddit MachineLearning", "TechCrunch AI"}
-		totalSources := len(sources)
-		processedItems := 0
-
-		for idx, src := range sources {
-			stepNum := 2
-			if idx > 0 {
-				stepNum = 3
-			}
-
-			slog.Info("Starting source crawl", "source", src, "step", stepNum, "index", idx+1, "total", totalSources)
-			onProgress(models.CrawlProgressEvent{
-				Step:                   stepNum,
-				Message:                fmt.Sprintf("Scanning source (%d/%d): %s...", idx+1, totalSources, src),
-				Progress:               20 + (idx * 50 / totalSources),
-				CurrentSource:          src,
-				TotalSources:           totalSources,
-				ProcessedItems:         processedItems,
-				EstimatedRemainingSecs: (totalSources - idx) * 3,
-				Timestamp:              time.Now(),
-			})
-
-			// Execute fetch with retry runner
-			err := retryRunner.ExecuteWithRetry(ctx, src, func(c context.Context) error {
-				// Mock content fetch & validation
-				time.Sleep(500 * time.Millisecond)
-
-				candidate := &models.Item{
-					Title:       fmt.Sprintf("Latest Advances in Autonomous Agents from %s", src),
-					URL:         fmt.Sprintf("https://example.com/%s/item-%d", src, time.Now().UnixNano()),
-					SourceName:  src,
-					PublishedAt: time.Now(),
-					RawExcerpt:  stringPtr("Detailed technical breakdown of open-source agent frameworks and scalable LLM inference pipelines."),
-				}
+	// Crawl task: one ingestion pass of the source worker, streamed over SSE
+	var worker *services.Worker
+	var settings scoring.SettingsSource
+	if database != nil {
+		worker = services.NewWorker(database, cfg)
+		settings = db.NewSettingRepository(database)
+	}
 
-				if valErr := validator.ValidateItem(candidate); valErr != nil {
-					return fmt.Errorf("item validation failed: %w", valErr)
-				}
+	// Scoring config: load now, then pick up file or settings edits without a restart
+	scoringLoader := scoring.NewLoader(cfg.ScoringConfigPath, settings)
+	if database != nil {
+		scoringLoader.KeepSnapshots(db.NewScoringConfigRepository(database))
+	}
+	if _, err := scoringLoader.Reload(context.Background()); err != nil {
+		slog.Warn("Invalid scoring config, using defaults", "err", err)
+	}
+	go scoringLoader.Watch(context.Background(), cfg.ScoringReloadInterval())
 
-				score := validator.ScoreRelevance(candidate.Title, *candidate.RawExcerpt)
-				candidate.RelevanceScore = score
-				if !validator.IsReleasesSufficientlyRelevant(score) {
-					slog.Info("Item skipped due to low relevance score", "source", src, "score", score)
-					return nil
-				}
+	// Learned domain/source reputation feeding credibility
+	if database != nil {
+		go reputation.NewService(database, cfg).Watch(context.Background(), cfg.ReputationRefreshInterval())
+	}
 
-				processedItems += 3
-				return nil
-			})
+	// Topic taxonomy used for tagging and relevance scoring
+	if database != nil {
+		taxonomyService := taxonomy.NewService(database)
+		if _, err := taxonomyService.Load(context.Background()); err != nil {
+			slog.Warn("Failed to load topic taxonomy, using defaults", "err", err)
+		}
+		go taxonomyService.Watch(context.Background(), cfg.TaxonomyRefreshInterval())
+	}
 
-			if err != nil {
-				slog.Error("Source crawl failed", "source", src, "err", err)
-			}
+	crawlTaskFunc := func(ctx context.Context, onProgress func(models.CrawlProgressEvent)) (int, error) {
+		if worker == nil {
+			return 0, errors.New("crawling requires a database connection")
 		}
 
-		slog.Info("Crawl step 4: Validating data...")
-		onProgress(models.CrawlProgressEvent{
-			Step:       4,
-			Message:    "Validating data...",
-			Progress:   80,
-			Timestamp:  time.Now(),
-			IsComplete: false,
-		})
-		time.Sleep(200 * time.Millisecond)
-
-		slog.Info("Crawl step 5: Saving to database...")
-		onProgress(models.CrawlProgressEvent{
-			Step:       5,
-			Message:    "Saving to database...",
-			Progress:   90,
-			Timestamp:  time.Now(),
-			IsComplete: false,
+		report := crawler.ReportFrom(ctx)
+		var finishSource func(crawler.SourceCounts, error)
+
+		return worker.RunIngestionWithOptions(ctx, services.IngestionOptions{
+			CrawlRunID: report.RunID(),
+			Progress: func(p services.IngestionProgress) {
+				switch p.Stage {
+				case services.StageSourceStarted:
+					id := p.SourceID
+					finishSource = report.BeginSource(p.Source, &id)
+				case services.StageSourceFinished:
+					if finishSource != nil {
+						finishSource(crawler.SourceCounts{
+							Discovered: p.ItemsFetched,
+							Accepted:   p.ItemsFetched - p.ItemsRejected,
+							Rejected:   p.ItemsRejected,
+							Duplicates: p.ItemsDuplicate,
+							Inserted:   p.ItemsInserted,
+						}, p.Err)
+						finishSource = nil
+					}
+				}
+				onProgress(progressEvent(p))
+			},
 		})
-		time.Sleep(200 * time.Millisecond)
-
-		return processedItems, nil
 	}
 
 	// Initialize & Start Auto-Scheduler
@@ -144,6 +118,10 @@ func main() {
 		slog.Error("Failed to initialize scheduler", "err", err)
 		os.Exit(1)
 	}
+	if database != nil {
+		scheduler.SetLocker(database, db.LockCrawlScheduler)
+		scheduler.SetRunStore(db.NewCrawlRunRepository(database), db.InstanceID())
+	}
 	scheduler.Start()
 
 	// Initialize Gin HTTP Router
@@ -158,6 +136,7 @@ func main() {
 
 	// Handlers
 	itemsHandler := api.NewItemsHandler(database)
+	runsHandler := api.NewCrawlRunsHandler(database)
 
 	// API Routes
 	router.GET("/healthz", func(c *gin.Context) {
@@ -169,6 +148,17 @@ func main() {
 
 	router.GET("/api/crawl/status", func(c *gin.Context) {
 		lastTime, status, count, errStr, isRunning := scheduler.GetStatus()
+		if status == "never_run" && runsHandler.Repository() != nil {
+			// Nothing ran since this process started; fall back to persisted history.
+			if last, err := runsHandler.Repository().Latest(c.Request.Context()); err != nil {
+				slog.Warn("Failed to load last crawl run", "err", err)
+			} else if last != nil {
+				lastTime, status, count = *last.FinishedAt, last.Status, last.Inserted
+				if last.Error != nil {
+					errStr = *last.Error
+				}
+			}
+		}
 		c.JSON(http.StatusOK, gin.H{
 			"last_run_time":    lastTime,
 			"last_run_status":  status,
@@ -178,6 +168,9 @@ func main() {
 		})
 	})
 
+	router.GET("/api/crawl/runs", runsHandler.HandleListRuns)
+	router.GET("/api/crawl/runs/:id", runsHandler.HandleGetRun)
+
 	router.POST("/api/crawl", func(c *gin.Context) {
 		go func() {
 			_, _ = scheduler.RunCrawl(context.Background(), "manual")
@@ -222,7 +215,3 @@ func main() {
 
 	slog.Info("Server stopped cleanly")
 }
-
-func stringPtr(s string) *string {
-	return &s
-}
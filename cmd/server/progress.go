@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/internal/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/services"
+)
+
+// progressEvent maps worker progress onto the SSE event the web UI renders.
+// The scheduler owns the first (10%) and final (100%) events, so worker
+// progress is scaled into the range between them.
+func progressEvent(p services.IngestionProgress) models.CrawlProgressEvent {
+	ev := models.CrawlProgressEvent{
+		Progress:               10 + p.Percent*85/100,
+		CurrentSource:          p.Source,
+		TotalSources:           p.TotalSources,
+		ProcessedItems:         p.TotalInserted,
+		EstimatedRemainingSecs: int(p.ETA.Round(time.Second).Seconds()),
+		Timestamp:              time.Now(),
+	}
+
+	switch p.Stage {
+	case services.StageStarted:
+		ev.Step = 1
+		ev.Message = fmt.Sprintf("Found %d due sources", p.TotalSources)
+	case services.StageSourceStarted:
+		ev.Step = 3
+		if p.SourceIndex == 1 {
+			ev.Step = 2
+		}
+		ev.Message = fmt.Sprintf("Scanning source (%d/%d): %s...", p.SourceIndex, p.TotalSources, p.Source)
+	case services.StageSourceFetched:
+		ev.Step = 3
+		ev.Message = fmt.Sprintf("Fetched %d items from %s", p.ItemsFetched, p.Source)
+	case services.StageEnriching:
+		ev.Step = 5
+		ev.Message = fmt.Sprintf("Scoring items (%d/%d) after %s", p.Enriched, p.ToEnrich, p.Source)
+	case services.StageSourceFinished:
+		ev.Step = 4
+		ev.Message = fmt.Sprintf("Saved %d new items from %s in %s", p.ItemsInserted, p.Source, p.Duration.Round(100*time.Millisecond))
+		if p.Err != nil {
+			ev.Message = fmt.Sprintf("Source %s failed: %v", p.Source, p.Err)
+			ev.HasError = true
+			ev.Error = p.Err.Error()
+		}
+	case services.StageFinished:
+		ev.Step = 5
+		ev.Message = fmt.Sprintf("Ingestion finished: %d new items", p.TotalInserted)
+	}
+	return ev
+}
@@ -10,7 +10,10 @@ import (
 
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/reputation"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
 	"github.com/hidatara-ds/evolipia-radar/pkg/services"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 )
 
 type NewsItem struct {
@@ -58,6 +61,20 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
+	scoringLoader := scoring.NewLoader(cfg.ScoringConfigPath, db.NewSettingRepository(database))
+	scoringLoader.KeepSnapshots(db.NewScoringConfigRepository(database))
+	if _, err := scoringLoader.Reload(ctx); err != nil {
+		log.Printf("Invalid scoring config, using defaults: %v", err)
+	}
+	if cfg.ReputationEnabled {
+		if err := reputation.NewService(database, cfg).Load(ctx); err != nil {
+			log.Printf("Failed to load reputations: %v", err)
+		}
+	}
+	if _, err := taxonomy.NewService(database).Load(ctx); err != nil {
+		log.Printf("Failed to load topic taxonomy, using defaults: %v", err)
+	}
+
 	log.Println("Starting ingestion...")
 	if err := w.RunIngestion(ctx); err != nil {
 		log.Printf("Ingestion error: %v", err)
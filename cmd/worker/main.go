@@ -8,9 +8,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/crawler"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/hidatara-ds/evolipia-radar/pkg/reputation"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
 	"github.com/hidatara-ds/evolipia-radar/pkg/services"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 	"github.com/robfig/cron/v3"
 )
 
@@ -25,6 +31,33 @@ func main() {
 
 	w := services.NewWorker(database, cfg)
 
+	// Scoring config: load now, then pick up file or settings edits without a restart
+	scoringLoader := scoring.NewLoader(cfg.ScoringConfigPath, db.NewSettingRepository(database))
+	scoringLoader.KeepSnapshots(db.NewScoringConfigRepository(database))
+	if _, err := scoringLoader.Reload(context.Background()); err != nil {
+		log.Printf("Invalid scoring config, using defaults: %v", err)
+	}
+	go scoringLoader.Watch(context.Background(), cfg.ScoringReloadInterval())
+
+	// Learned domain/source reputation feeding credibility
+	go reputation.NewService(database, cfg).Watch(context.Background(), cfg.ReputationRefreshInterval())
+
+	// Topic taxonomy used for tagging and relevance scoring
+	taxonomyService := taxonomy.NewService(database)
+	if _, err := taxonomyService.Load(context.Background()); err != nil {
+		log.Printf("Failed to load topic taxonomy, using defaults: %v", err)
+	}
+	go taxonomyService.Watch(context.Background(), cfg.TaxonomyRefreshInterval())
+
+	// Enrichment jobs the worker queues itself (score, entities, backfill
+	// summaries) run here too, so it does not depend on cmd/api running.
+	// Runners in several processes share the queue safely.
+	jobCtx, jobCancel := context.WithCancel(context.Background())
+	defer jobCancel()
+	jobRunner := jobs.NewRunner(database)
+	newEnrichment(database).RegisterJobHandlers(jobRunner)
+	go jobRunner.Start(jobCtx, 30*time.Second)
+
 	c := cron.New()
 	_, err = c.AddFunc(cfg.WorkerCron, func() {
 		log.Println("Starting scheduled ingestion...")
@@ -58,5 +91,21 @@ func main() {
 
 	log.Println("Shutting down worker...")
 	c.Stop()
+	jobCancel()
 	log.Println("Worker exited")
 }
+
+// newEnrichment builds the orchestrator whose handlers process enrichment
+// jobs. Discovery is never started from the worker.
+func newEnrichment(database *db.DB) *crawler.Orchestrator {
+	aiCfg := config.LoadAIConfig()
+	provider := ai.NewOpenRouterProvider(ai.OpenRouterProviderConfig{
+		APIKey:       aiCfg.APIKey,
+		DefaultModel: aiCfg.DefaultModel,
+	})
+	aiService := ai.NewService(ai.NewTrackerMiddleware(provider, 10000, 300000))
+
+	clusterService := ai.NewClusterService(aiService, database.Pool)
+	summarizer := crawler.NewSummarizer(aiService, database)
+	return crawler.NewOrchestrator(clusterService, nil, aiService, crawler.NewMetrics(database.Pool), database, false, summarizer)
+}
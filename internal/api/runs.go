@@ -0,0 +1,121 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	radar "github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// CrawlRunsHandler serves the persisted crawl run history.
+type CrawlRunsHandler struct {
+	runs      *db.CrawlRunRepository
+	fetchRuns *db.FetchRunRepository
+}
+
+// NewCrawlRunsHandler constructs a CrawlRunsHandler. database may be nil, in
+// which case the endpoints report that history is unavailable.
+func NewCrawlRunsHandler(database *db.DB) *CrawlRunsHandler {
+	if database == nil {
+		return &CrawlRunsHandler{}
+	}
+	return &CrawlRunsHandler{
+		runs:      db.NewCrawlRunRepository(database),
+		fetchRuns: db.NewFetchRunRepository(database),
+	}
+}
+
+// Repository returns the underlying run repository, or nil without a database.
+func (h *CrawlRunsHandler) Repository() *db.CrawlRunRepository {
+	return h.runs
+}
+
+func (h *CrawlRunsHandler) available(c *gin.Context) bool {
+	if h.runs == nil {
+		RespondWithError(c, http.StatusServiceUnavailable, ErrCodeInternal, "crawl run history requires a database")
+		return false
+	}
+	return true
+}
+
+// HandleListRuns godoc
+// @Summary List crawl runs, newest first
+// @Tags Crawl
+// @Produce json
+// @Param trigger query string false "Trigger type filter (auto, manual, worker)"
+// @Param limit query int false "Page limit (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Router /api/crawl/runs [get]
+func (h *CrawlRunsHandler) HandleListRuns(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	runs, err := h.runs.List(c.Request.Context(), strings.TrimSpace(c.Query("trigger")), limit, offset)
+	if err != nil {
+		slog.Error("Failed to list crawl runs", "err", err)
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to list crawl runs")
+		return
+	}
+	if runs == nil {
+		runs = []radar.CrawlRun{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": runs, "limit": limit, "offset": offset})
+}
+
+// HandleGetRun godoc
+// @Summary Get one crawl run with its fetch_runs
+// @Tags Crawl
+// @Produce json
+// @Param id path string true "Crawl run ID"
+// @Router /api/crawl/runs/{id} [get]
+func (h *CrawlRunsHandler) HandleGetRun(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeValidation, "invalid crawl run id")
+		return
+	}
+
+	ctx := c.Request.Context()
+	run, err := h.runs.GetByID(ctx, id)
+	if err != nil {
+		slog.Error("Failed to load crawl run", "run_id", id, "err", err)
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to load crawl run")
+		return
+	}
+	if run == nil {
+		RespondWithError(c, http.StatusNotFound, ErrCodeValidation, "crawl run not found")
+		return
+	}
+
+	fetchRuns, err := h.fetchRuns.ListByCrawlRun(ctx, id)
+	if err != nil {
+		slog.Error("Failed to load fetch runs for crawl run", "run_id", id, "err", err)
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to load fetch runs")
+		return
+	}
+	if fetchRuns == nil {
+		fetchRuns = []radar.FetchRun{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": run, "fetch_runs": fetchRuns})
+}
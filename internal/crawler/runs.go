@@ -0,0 +1,101 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	radar "github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// RunStore persists crawl run records (see db.CrawlRunRepository).
+type RunStore interface {
+	Create(ctx context.Context, run *radar.CrawlRun) error
+	Finish(ctx context.Context, run *radar.CrawlRun) error
+}
+
+// SourceCounts are the per-source results a crawl task reports.
+type SourceCounts struct {
+	Discovered int
+	Accepted   int
+	Rejected   int // includes Duplicates
+	Duplicates int
+	Inserted   int
+}
+
+// RunReport collects per-source timings and counts while a crawl task runs.
+// A nil *RunReport is valid and records nothing, so tasks can report
+// unconditionally.
+type RunReport struct {
+	runID *uuid.UUID
+
+	mu      sync.Mutex
+	sources []radar.CrawlRunSource
+}
+
+type runReportKey struct{}
+
+func withRunReport(ctx context.Context, r *RunReport) context.Context {
+	return context.WithValue(ctx, runReportKey{}, r)
+}
+
+// ReportFrom returns the RunReport of the crawl run ctx belongs to, or nil.
+func ReportFrom(ctx context.Context) *RunReport {
+	r, _ := ctx.Value(runReportKey{}).(*RunReport)
+	return r
+}
+
+// RunID is the persisted run's ID, for tagging fetch_runs rows. It is nil
+// when runs are not persisted.
+func (r *RunReport) RunID() *uuid.UUID {
+	if r == nil {
+		return nil
+	}
+	return r.runID
+}
+
+// BeginSource starts timing source name. Call the returned func once the
+// source is done with its counts and error, if any.
+func (r *RunReport) BeginSource(name string, sourceID *uuid.UUID) func(SourceCounts, error) {
+	start := time.Now()
+	return func(c SourceCounts, err error) {
+		if r == nil {
+			return
+		}
+		entry := radar.CrawlRunSource{
+			Name:       name,
+			SourceID:   sourceID,
+			StartedAt:  start,
+			DurationMs: time.Since(start).Milliseconds(),
+			Discovered: c.Discovered,
+			Accepted:   c.Accepted,
+			Rejected:   c.Rejected,
+			Duplicates: c.Duplicates,
+			Inserted:   c.Inserted,
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		r.mu.Lock()
+		r.sources = append(r.sources, entry)
+		r.mu.Unlock()
+	}
+}
+
+// apply copies the collected sources and their totals onto run.
+func (r *RunReport) apply(run *radar.CrawlRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.Sources = append([]radar.CrawlRunSource(nil), r.sources...)
+	run.Discovered, run.Accepted, run.Rejected, run.Duplicates, run.Inserted = 0, 0, 0, 0, 0
+	for _, s := range r.sources {
+		run.Discovered += s.Discovered
+		run.Accepted += s.Accepted
+		run.Rejected += s.Rejected
+		run.Duplicates += s.Duplicates
+		run.Inserted += s.Inserted
+	}
+}
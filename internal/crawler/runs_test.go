@@ -0,0 +1,76 @@
+package crawler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/internal/crawler"
+	"github.com/hidatara-ds/evolipia-radar/internal/models"
+	radar "github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memRunStore struct {
+	created  []radar.CrawlRun
+	finished []radar.CrawlRun
+}
+
+func (s *memRunStore) Create(_ context.Context, run *radar.CrawlRun) error {
+	run.ID = uuid.New()
+	run.Status = "running"
+	s.created = append(s.created, *run)
+	return nil
+}
+
+func (s *memRunStore) Finish(_ context.Context, run *radar.CrawlRun) error {
+	s.finished = append(s.finished, *run)
+	return nil
+}
+
+func TestScheduler_RunCrawlRecordsRun(t *testing.T) {
+	var taskRunID *uuid.UUID
+	task := func(ctx context.Context, _ func(models.CrawlProgressEvent)) (int, error) {
+		report := crawler.ReportFrom(ctx)
+		taskRunID = report.RunID()
+
+		report.BeginSource("ok", nil)(crawler.SourceCounts{Discovered: 4, Accepted: 3, Rejected: 1, Duplicates: 1, Inserted: 2}, nil)
+		report.BeginSource("broken", nil)(crawler.SourceCounts{Discovered: 1, Rejected: 1}, errors.New("timeout"))
+		return 2, errors.New("partial failure")
+	}
+
+	s, err := crawler.NewScheduler("@every 1h", task, nil)
+	require.NoError(t, err)
+	store := &memRunStore{}
+	s.SetRunStore(store, "test-instance")
+
+	_, err = s.RunCrawl(context.Background(), "manual")
+	assert.Error(t, err)
+
+	require.Len(t, store.created, 1)
+	require.Len(t, store.finished, 1)
+	run := store.finished[0]
+
+	require.NotNil(t, taskRunID)
+	assert.Equal(t, run.ID, *taskRunID)
+	assert.Equal(t, "manual", run.TriggerType)
+	assert.Equal(t, "test-instance", *run.InstanceID)
+	assert.Equal(t, "failed", run.Status)
+	assert.Equal(t, "partial failure", *run.Error)
+	assert.Equal(t, 5, run.Discovered)
+	assert.Equal(t, 3, run.Accepted)
+	assert.Equal(t, 2, run.Rejected)
+	assert.Equal(t, 1, run.Duplicates)
+	assert.Equal(t, 2, run.Inserted)
+	require.Len(t, run.Sources, 2)
+	assert.Equal(t, "timeout", run.Sources[1].Error)
+}
+
+func TestRunReport_NilIsNoop(t *testing.T) {
+	report := crawler.ReportFrom(context.Background())
+	assert.Nil(t, report)
+	assert.Nil(t, report.RunID())
+	report.BeginSource("any", nil)(crawler.SourceCounts{Discovered: 1}, nil)
+}
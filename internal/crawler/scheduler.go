@@ -9,12 +9,18 @@ import (
 	"time"
 
 	"github.com/hidatara-ds/evolipia-radar/internal/models"
+	radar "github.com/hidatara-ds/evolipia-radar/pkg/models"
 	"github.com/robfig/cron/v3"
 )
 
 // CrawlTaskFunc is the signature for triggering a crawl run with progress reporting.
 type CrawlTaskFunc func(ctx context.Context, onProgress func(models.CrawlProgressEvent)) (int, error)
 
+// Locker coordinates crawl runs across replicas (see db.DB.TryLock).
+type Locker interface {
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+}
+
 // Scheduler manages automated background crawl jobs using cron.
 type Scheduler struct {
 	cron             *cron.Cron
@@ -28,6 +34,10 @@ type Scheduler struct {
 	lastRunError     string
 	mu               sync.RWMutex
 	progressReporter func(models.CrawlProgressEvent)
+	locker           Locker
+	lockName         string
+	runStore         RunStore
+	instanceID       string
 }
 
 // NewScheduler creates a Scheduler instance with interval schedule.
@@ -60,6 +70,20 @@ func NewScheduler(interval string, task CrawlTaskFunc, progressReporter func(mod
 	return s, nil
 }
 
+// SetLocker makes RunCrawl take the named distributed lock, so only one
+// replica crawls at a time. Without it only the in-process guard applies.
+func (s *Scheduler) SetLocker(l Locker, name string) {
+	s.locker = l
+	s.lockName = name
+}
+
+// SetRunStore makes RunCrawl persist every cycle it runs, tagged with
+// instanceID, so run history survives restarts.
+func (s *Scheduler) SetRunStore(store RunStore, instanceID string) {
+	s.runStore = store
+	s.instanceID = instanceID
+}
+
 // Start launches the cron scheduler loop in the background.
 func (s *Scheduler) Start() {
 	slog.Info("Starting Auto-Scheduler...", "interval", s.crawlInterval)
@@ -88,6 +112,19 @@ func (s *Scheduler) RunCrawl(ctx context.Context, triggerType string) (int, erro
 		s.wg.Done()
 	}()
 
+	if s.locker != nil {
+		unlock, acquired, err := s.locker.TryLock(ctx, s.lockName)
+		switch {
+		case err != nil:
+			slog.Warn("Distributed crawl lock unavailable, continuing with local guard only", "err", err)
+		case !acquired:
+			slog.Warn("Crawl cycle skipped: another instance holds the crawl lock", "lock", s.lockName)
+			return 0, fmt.Errorf("crawl already in progress on another instance")
+		default:
+			defer unlock()
+		}
+	}
+
 	startTime := time.Now()
 	slog.Info("Starting crawl cycle", "trigger", triggerType, "time", startTime.Format(time.RFC3339))
 
@@ -105,7 +142,16 @@ func (s *Scheduler) RunCrawl(ctx context.Context, triggerType string) (int, erro
 		IsComplete: false,
 	})
 
-	itemsProcessed, err := s.crawlTask(ctx, reporter)
+	report := &RunReport{}
+	run := s.beginRun(ctx, triggerType)
+	if run != nil {
+		report.runID = &run.ID
+	}
+
+	itemsProcessed, err := s.crawlTask(withRunReport(ctx, report), reporter)
+	if run != nil {
+		s.finishRun(ctx, run, report, itemsProcessed, err)
+	}
 
 	s.mu.Lock()
 	s.lastRunTime = time.Now()
@@ -142,6 +188,43 @@ func (s *Scheduler) RunCrawl(ctx context.Context, triggerType string) (int, erro
 	return itemsProcessed, err
 }
 
+// beginRun records the start of a cycle. It returns nil when runs are not
+// persisted or the record could not be created; the crawl still proceeds.
+func (s *Scheduler) beginRun(ctx context.Context, triggerType string) *radar.CrawlRun {
+	if s.runStore == nil {
+		return nil
+	}
+
+	run := &radar.CrawlRun{TriggerType: triggerType}
+	if s.instanceID != "" {
+		run.InstanceID = &s.instanceID
+	}
+	if err := s.runStore.Create(ctx, run); err != nil {
+		slog.Warn("Failed to record crawl run start", "trigger", triggerType, "err", err)
+		return nil
+	}
+	return run
+}
+
+func (s *Scheduler) finishRun(ctx context.Context, run *radar.CrawlRun, report *RunReport, itemsProcessed int, crawlErr error) {
+	report.apply(run)
+	if run.Inserted == 0 {
+		// Tasks that do not report per source still return a total.
+		run.Inserted = itemsProcessed
+	}
+
+	run.Status = "success"
+	if crawlErr != nil {
+		run.Status = "failed"
+		msg := crawlErr.Error()
+		run.Error = &msg
+	}
+
+	if err := s.runStore.Finish(context.WithoutCancel(ctx), run); err != nil {
+		slog.Warn("Failed to record crawl run result", "run_id", run.ID, "err", err)
+	}
+}
+
 // GetStatus returns the last crawl execution metrics.
 func (s *Scheduler) GetStatus() (time.Time, string, int, string, bool) {
 	s.mu.RLock()
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS crawl_budget_windows;
+DROP INDEX IF EXISTS idx_crawl_seen_expires_at;
+DROP TABLE IF EXISTS crawl_seen;
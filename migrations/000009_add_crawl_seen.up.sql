@@ -0,0 +1,14 @@
+-- Persistent URL dedup set for CrawlBudget (shared across instances and cold starts)
+CREATE TABLE IF NOT EXISTS crawl_seen (
+    url TEXT PRIMARY KEY,
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_crawl_seen_expires_at ON crawl_seen(expires_at);
+
+-- Hourly ingestion counters for CrawlBudget
+CREATE TABLE IF NOT EXISTS crawl_budget_windows (
+    window_start TIMESTAMPTZ PRIMARY KEY,
+    ingested INT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_agent_configs_name;
+DROP TABLE IF EXISTS agent_configs;
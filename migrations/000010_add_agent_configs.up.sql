@@ -0,0 +1,24 @@
+-- Discovery agent definitions consumed by the crawler orchestrator
+CREATE TABLE IF NOT EXISTS agent_configs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    max_items INT NOT NULL DEFAULT 10,
+    params JSONB NOT NULL DEFAULT '{}'::jsonb,
+    schedule TEXT NOT NULL DEFAULT '',
+    last_run_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agent_configs_name ON agent_configs(name);
+
+-- Seed the agents that used to be hard-coded in NewOrchestrator
+INSERT INTO agent_configs (name, type, max_items, params) VALUES
+    ('RSSAgent', 'rss', 10, '{"feeds": ["https://news.ycombinator.com/rss", "https://techcrunch.com/category/artificial-intelligence/feed/"]}'),
+    ('TrendingAgent', 'trending', 10, '{}'),
+    ('RedditAgent', 'reddit', 10, '{"subreddits": ["technology", "artificialintelligence", "programming"]}'),
+    ('SocialAgent-X', 'social', 10, '{"platform": "X", "query": "(AI OR ML OR Robotics OR LLM) -is:retweet lang:en"}'),
+    ('SocialAgent-Threads', 'social', 10, '{"platform": "Threads", "query": "AI Robotics Innovation"}')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_sources_circuit_state;
+
+ALTER TABLE sources
+DROP COLUMN IF EXISTS last_success_at,
+DROP COLUMN IF EXISTS failing_since,
+DROP COLUMN IF EXISTS next_attempt_at,
+DROP COLUMN IF EXISTS consecutive_failures,
+DROP COLUMN IF EXISTS circuit_state;
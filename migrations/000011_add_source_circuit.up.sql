@@ -0,0 +1,9 @@
+-- Per-source circuit breaker state driven by fetch_runs outcomes
+ALTER TABLE sources
+ADD COLUMN IF NOT EXISTS circuit_state TEXT NOT NULL DEFAULT 'closed',
+ADD COLUMN IF NOT EXISTS consecutive_failures INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NULL,
+ADD COLUMN IF NOT EXISTS failing_since TIMESTAMPTZ NULL,
+ADD COLUMN IF NOT EXISTS last_success_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sources_circuit_state ON sources(circuit_state);
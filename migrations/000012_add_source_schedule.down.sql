@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_sources_next_poll_at;
+
+ALTER TABLE sources
+DROP COLUMN IF EXISTS next_poll_at,
+DROP COLUMN IF EXISTS last_polled_at,
+DROP COLUMN IF EXISTS poll_interval_seconds,
+DROP COLUMN IF EXISTS adaptive,
+DROP COLUMN IF EXISTS schedule;
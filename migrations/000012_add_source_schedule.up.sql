@@ -0,0 +1,9 @@
+-- Per-source polling schedule (cron expression or "@every <duration>") with optional adaptive interval
+ALTER TABLE sources
+ADD COLUMN IF NOT EXISTS schedule TEXT NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS adaptive BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS poll_interval_seconds INT NULL,
+ADD COLUMN IF NOT EXISTS last_polled_at TIMESTAMPTZ NULL,
+ADD COLUMN IF NOT EXISTS next_poll_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sources_next_poll_at ON sources(next_poll_at);
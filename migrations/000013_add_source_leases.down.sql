@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_sources_lease_expires_at;
+
+ALTER TABLE sources
+DROP COLUMN IF EXISTS lease_expires_at,
+DROP COLUMN IF EXISTS lease_owner;
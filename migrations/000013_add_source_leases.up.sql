@@ -0,0 +1,6 @@
+-- Source leases so worker replicas split due sources without double-fetching
+ALTER TABLE sources
+ADD COLUMN IF NOT EXISTS lease_owner TEXT NULL,
+ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sources_lease_expires_at ON sources(lease_expires_at);
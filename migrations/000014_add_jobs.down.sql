@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_jobs_live_dedup;
+DROP INDEX IF EXISTS idx_jobs_type_status;
+DROP INDEX IF EXISTS idx_jobs_claim;
+DROP TABLE IF EXISTS jobs;
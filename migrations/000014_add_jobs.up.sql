@@ -0,0 +1,23 @@
+-- Durable enrichment job queue (summarize, embed, score, cluster)
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type TEXT NOT NULL,
+    dedup_key TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL DEFAULT 'pending', -- pending, running, done, dead
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5,
+    next_run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NULL,
+    locked_by TEXT NULL,
+    locked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_claim ON jobs(status, next_run_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_type_status ON jobs(type, status);
+
+-- At most one live job per (type, dedup_key)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_live_dedup ON jobs(type, dedup_key)
+WHERE status IN ('pending', 'running');
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_fetch_runs_crawl_run;
+ALTER TABLE fetch_runs DROP COLUMN IF EXISTS crawl_run_id;
+DROP INDEX IF EXISTS idx_crawl_runs_started;
+DROP TABLE IF EXISTS crawl_runs;
@@ -0,0 +1,22 @@
+-- Persisted crawl cycle history
+CREATE TABLE IF NOT EXISTS crawl_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trigger_type TEXT NOT NULL, -- auto, manual, worker
+    status TEXT NOT NULL DEFAULT 'running', -- running, success, failed
+    instance_id TEXT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ NULL,
+    discovered INT NOT NULL DEFAULT 0,
+    accepted INT NOT NULL DEFAULT 0,
+    rejected INT NOT NULL DEFAULT 0,
+    inserted INT NOT NULL DEFAULT 0,
+    error TEXT NULL,
+    sources JSONB NOT NULL DEFAULT '[]'::jsonb -- per-source timings, counts and errors
+);
+
+CREATE INDEX IF NOT EXISTS idx_crawl_runs_started ON crawl_runs(started_at DESC);
+
+ALTER TABLE fetch_runs
+    ADD COLUMN IF NOT EXISTS crawl_run_id UUID NULL REFERENCES crawl_runs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_fetch_runs_crawl_run ON fetch_runs(crawl_run_id) WHERE crawl_run_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_items_canonical_url;
+DROP INDEX IF EXISTS idx_items_url;
+ALTER TABLE items DROP COLUMN IF EXISTS canonical_url;
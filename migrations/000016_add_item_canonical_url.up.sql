@@ -0,0 +1,7 @@
+-- Canonical URL (from redirects, <link rel="canonical"> or og:url) used as the dedup key.
+-- items.url keeps the URL the source reported.
+ALTER TABLE items
+ADD COLUMN IF NOT EXISTS canonical_url TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_url ON items(url);
+CREATE INDEX IF NOT EXISTS idx_items_canonical_url ON items(canonical_url);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_items_duplicate_of;
+DROP INDEX IF EXISTS idx_items_minhash_bands;
+ALTER TABLE items
+DROP COLUMN IF EXISTS duplicate_of,
+DROP COLUMN IF EXISTS minhash_bands,
+DROP COLUMN IF EXISTS minhash;
@@ -0,0 +1,9 @@
+-- Near-duplicate detection: MinHash signature of title + excerpt words, its
+-- LSH band keys, and the canonical item a duplicate story is linked to.
+ALTER TABLE items
+ADD COLUMN IF NOT EXISTS minhash BIGINT[] NULL,
+ADD COLUMN IF NOT EXISTS minhash_bands BIGINT[] NULL,
+ADD COLUMN IF NOT EXISTS duplicate_of UUID NULL REFERENCES items(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_minhash_bands ON items USING GIN (minhash_bands);
+CREATE INDEX IF NOT EXISTS idx_items_duplicate_of ON items(duplicate_of) WHERE duplicate_of IS NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE summaries
+DROP COLUMN IF EXISTS translated_why_it_matters,
+DROP COLUMN IF EXISTS translated_tldr,
+DROP COLUMN IF EXISTS translated_lang;
+
+DROP INDEX IF EXISTS idx_items_lang;
+ALTER TABLE items DROP COLUMN IF EXISTS lang;
@@ -0,0 +1,11 @@
+-- Detected language of each item (ISO 639-1) and an optional translation of
+-- its summary into the team's preferred language.
+ALTER TABLE items
+ADD COLUMN IF NOT EXISTS lang TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_lang ON items(lang);
+
+ALTER TABLE summaries
+ADD COLUMN IF NOT EXISTS translated_lang TEXT NULL,
+ADD COLUMN IF NOT EXISTS translated_tldr TEXT NULL,
+ADD COLUMN IF NOT EXISTS translated_why_it_matters TEXT NULL;
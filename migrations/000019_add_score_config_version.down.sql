@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_scores_config_version;
+ALTER TABLE scores DROP COLUMN IF EXISTS config_version;
@@ -0,0 +1,6 @@
+-- Version of the scoring config each score was computed with, so rankings
+-- stay explainable after the config changes.
+ALTER TABLE scores
+ADD COLUMN IF NOT EXISTS config_version TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_scores_config_version ON scores(config_version);
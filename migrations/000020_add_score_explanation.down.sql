@@ -0,0 +1 @@
+ALTER TABLE scores DROP COLUMN IF EXISTS explanation;
@@ -0,0 +1,4 @@
+-- Structured explanation of how each score was built (components, weights,
+-- matched keywords, credibility tier, decay and provenance).
+ALTER TABLE scores
+ADD COLUMN IF NOT EXISTS explanation JSONB NULL;
@@ -0,0 +1,13 @@
+-- Engagement velocity per item, recomputed from its signal time series
+-- whenever a new signal arrives. /v1/rising ranks items by z-score of this
+-- velocity against their source's recent baseline.
+CREATE TABLE IF NOT EXISTS item_velocity (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    engagement DOUBLE PRECISION NOT NULL DEFAULT 0,
+    velocity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    acceleration DOUBLE PRECISION NOT NULL DEFAULT 0,
+    samples INT NOT NULL DEFAULT 0,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_velocity_computed_at ON item_velocity(computed_at DESC);
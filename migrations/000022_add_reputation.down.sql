@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reputations;
+DROP TABLE IF EXISTS item_feedback;
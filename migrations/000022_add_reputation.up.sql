@@ -0,0 +1,28 @@
+-- Team feedback on items, one of the inputs to learned reputation.
+CREATE TABLE IF NOT EXISTS item_feedback (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    vote SMALLINT NOT NULL CHECK (vote IN (-1, 1)),
+    user_ref TEXT NULL,
+    note TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_feedback_item ON item_feedback(item_id);
+
+-- Reputation learned per domain and per source from item history.
+CREATE TABLE IF NOT EXISTS reputations (
+    kind TEXT NOT NULL CHECK (kind IN ('domain', 'source')),
+    key TEXT NOT NULL,
+    items INT NOT NULL DEFAULT 0,
+    llm_quality DOUBLE PRECISION NOT NULL,
+    duplicate_rate DOUBLE PRECISION NOT NULL,
+    top_rate DOUBLE PRECISION NOT NULL,
+    feedback_score DOUBLE PRECISION NOT NULL,
+    upvotes INT NOT NULL DEFAULT 0,
+    downvotes INT NOT NULL DEFAULT 0,
+    score DOUBLE PRECISION NOT NULL,
+    credibility DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (kind, key)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS score_history;
+ALTER TABLE scores DROP COLUMN IF EXISTS algorithm_version;
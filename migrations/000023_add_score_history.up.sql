@@ -0,0 +1,23 @@
+-- Every score recomputation is appended here, tagged with the scoring
+-- algorithm and config version, so an item's score can be traced over time
+-- and rankings under two versions compared.
+ALTER TABLE scores
+ADD COLUMN IF NOT EXISTS algorithm_version TEXT NULL;
+
+CREATE TABLE IF NOT EXISTS score_history (
+    id BIGSERIAL PRIMARY KEY,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    hot DOUBLE PRECISION NOT NULL,
+    relevance DOUBLE PRECISION NOT NULL,
+    credibility DOUBLE PRECISION NOT NULL,
+    novelty DOUBLE PRECISION NOT NULL,
+    impact DOUBLE PRECISION NOT NULL,
+    engineering_value DOUBLE PRECISION NOT NULL,
+    final DOUBLE PRECISION NOT NULL,
+    algorithm_version TEXT NOT NULL,
+    config_version TEXT NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_score_history_item ON score_history(item_id, computed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_score_history_version ON score_history(config_version, computed_at);
@@ -0,0 +1,13 @@
+-- Topic taxonomy shared by tagging, feed topic filters and relevance
+-- scoring. Seeded with the compiled-in defaults when empty.
+CREATE TABLE IF NOT EXISTS topics (
+    slug TEXT PRIMARY KEY,
+    parent TEXT NULL REFERENCES topics(slug) ON UPDATE CASCADE,
+    name TEXT NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    keywords TEXT[] NOT NULL DEFAULT '{}',
+    patterns TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_topics_parent ON topics(parent);
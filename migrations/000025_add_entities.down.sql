@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS item_entities;
+DROP TABLE IF EXISTS entities;
@@ -0,0 +1,27 @@
+-- Canonical entities (models, organizations, datasets, people, products)
+-- and the items that mention them. Seeded with built-in entities when
+-- empty; the LLM pass adds names the gazetteer does not know.
+CREATE TABLE IF NOT EXISTS entities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('model', 'organization', 'dataset', 'person', 'product')),
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    case_sensitive BOOLEAN NOT NULL DEFAULT false,
+    origin TEXT NOT NULL DEFAULT 'seed' CHECK (origin IN ('seed', 'llm', 'manual')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_entities_kind ON entities(kind);
+
+CREATE TABLE IF NOT EXISTS item_entities (
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    entity_id UUID NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    mention TEXT NOT NULL,
+    method TEXT NOT NULL CHECK (method IN ('gazetteer', 'llm')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (item_id, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_entities_entity ON item_entities(entity_id);
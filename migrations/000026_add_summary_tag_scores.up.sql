@@ -0,0 +1,13 @@
+-- Each summary tag with its confidence (0-1) and how it was assigned
+-- (keyword or llm): [{"tag": "llm", "confidence": 0.9, "method": "llm"}].
+ALTER TABLE summaries
+ADD COLUMN IF NOT EXISTS tag_scores JSONB NOT NULL DEFAULT '[]';
+
+-- Existing tags came from keyword matching; give them the confidence of a
+-- single keyword hit.
+UPDATE summaries su
+SET tag_scores = (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('tag', lower(t), 'confidence', 0.5, 'method', 'keyword')), '[]')
+    FROM jsonb_array_elements_text(su.tags) t
+)
+WHERE jsonb_typeof(su.tags) = 'array';
@@ -0,0 +1,4 @@
+ALTER TABLE scores
+DROP COLUMN IF EXISTS llm_engineering_value,
+DROP COLUMN IF EXISTS llm_impact,
+DROP COLUMN IF EXISTS llm_novelty;
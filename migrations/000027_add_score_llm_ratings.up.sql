@@ -0,0 +1,21 @@
+-- Raw 1-10 ratings from the LLM article analysis. They used to be written
+-- into novelty/impact/engineering_value and read back from there, so each
+-- rescore normalized them again.
+ALTER TABLE scores
+ADD COLUMN IF NOT EXISTS llm_novelty DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS llm_impact DOUBLE PRECISION NULL,
+ADD COLUMN IF NOT EXISTS llm_engineering_value DOUBLE PRECISION NULL;
+
+-- Rows the summarizer wrote that have not been scored since still hold the
+-- raw ratings.
+UPDATE scores
+SET llm_novelty = novelty, llm_impact = impact, llm_engineering_value = engineering_value
+WHERE impact > 1;
+
+-- Scored rows hold normalized ratings; undo one normalization. Rows that
+-- were rescored several times cannot be recovered and stay low until the
+-- item is summarized again.
+UPDATE scores
+SET llm_novelty = novelty * 10, llm_impact = impact * 10, llm_engineering_value = engineering_value * 10
+WHERE llm_impact IS NULL AND impact > 0
+AND COALESCE((explanation->'provenance'->>'llm_scores')::boolean, false);
@@ -0,0 +1,2 @@
+ALTER TABLE crawl_runs
+DROP COLUMN IF EXISTS duplicates;
@@ -0,0 +1,4 @@
+-- Items that were already stored. Earlier runs counted them as inserted and
+-- cannot be split out after the fact.
+ALTER TABLE crawl_runs
+ADD COLUMN IF NOT EXISTS duplicates INT NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+-- Every scoring config that was active, by version, so one day's items can
+-- be ranked under two configs side by side.
+CREATE TABLE IF NOT EXISTS scoring_configs (
+    version TEXT PRIMARY KEY,
+    algorithm_version TEXT NOT NULL,
+    config TEXT NOT NULL, -- YAML, as accepted by SCORING_CONFIG_PATH
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
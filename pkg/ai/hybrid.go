@@ -107,7 +107,7 @@ func (h *HybridSearcher) Search(ctx context.Context, query string, mode string,
 // textSearch uses the existing ILIKE-based search in ItemRepository.
 func (h *HybridSearcher) textSearch(ctx context.Context, query string, limit int) ([]HybridResult, error) {
 	itemRepo := db.NewItemRepository(h.database)
-	items, _, err := itemRepo.Search(ctx, query, nil, limit, 0)
+	items, _, err := itemRepo.Search(ctx, query, nil, 0, nil, limit, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 const (
@@ -34,7 +36,7 @@ type openRouterProvider struct {
 func NewOpenRouterProvider(cfg OpenRouterProviderConfig) LLMProvider {
 	client := cfg.Client
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = fetch.NewClient(30 * time.Second)
 	}
 
 	defaultModel := cfg.DefaultModel
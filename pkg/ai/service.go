@@ -2,9 +2,17 @@ package ai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/utils"
 )
 
+// MaxArticleContent bounds the article text sent to the LLM with a prompt,
+// in bytes.
+const MaxArticleContent = 2000
+
 // Service is the primary business logic layer for AI operations.
 // It wraps an underlying LLMProvider, allowing for centralized business rules
 // (e.g., logging, default fallback, structured formatting) to be applied
@@ -33,6 +41,20 @@ func (s *Service) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, err
 	return s.provider.ChatCompletion(ctx, req)
 }
 
+// ArticleContent cuts content to MaxArticleContent on a character boundary.
+func ArticleContent(content string) string {
+	return utils.Truncate(content, MaxArticleContent)
+}
+
+// DecodeJSONReply unmarshals the JSON object in a Chat reply into v,
+// ignoring text or a markdown fence around it.
+func DecodeJSONReply(reply string, v any) error {
+	if start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}"); start >= 0 && end > start {
+		reply = reply[start : end+1]
+	}
+	return json.Unmarshal([]byte(reply), v)
+}
+
 // Summarize orchestrates a summarization task.
 func (s *Service) Summarize(ctx context.Context, req SummarizeRequest) (*SummarizeResponse, error) {
 	if req.Text == "" {
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/crawler"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// AgentsHandler exposes CRUD endpoints for stored discovery agent configs.
+// Changes take effect on the orchestrator's next cycle.
+type AgentsHandler struct {
+	repo *db.AgentConfigRepository
+}
+
+func NewAgentsHandler(database *db.DB) *AgentsHandler {
+	return &AgentsHandler{
+		repo: db.NewAgentConfigRepository(database),
+	}
+}
+
+func (h *AgentsHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/agents", h.List)
+	rg.GET("/agents/types", h.Types)
+	rg.GET("/agents/:id", h.Get)
+	rg.POST("/agents", h.Create)
+	rg.PUT("/agents/:id", h.Update)
+	rg.DELETE("/agents/:id", h.Delete)
+}
+
+type agentRequest struct {
+	Name     string             `json:"name" binding:"required"`
+	Type     string             `json:"type" binding:"required"`
+	Enabled  *bool              `json:"enabled"`
+	MaxItems int                `json:"max_items"`
+	Params   models.AgentParams `json:"params"`
+	Schedule string             `json:"schedule"`
+}
+
+func (req agentRequest) apply(a *models.AgentConfig) {
+	a.Name = req.Name
+	a.Type = req.Type
+	a.Enabled = true
+	if req.Enabled != nil {
+		a.Enabled = *req.Enabled
+	}
+	a.MaxItems = req.MaxItems
+	if a.MaxItems == 0 {
+		a.MaxItems = 10
+	}
+	a.Params = req.Params
+	a.Schedule = req.Schedule
+}
+
+func (h *AgentsHandler) List(c *gin.Context) {
+	agents, err := h.repo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if agents == nil {
+		agents = []models.AgentConfig{}
+	}
+	c.JSON(http.StatusOK, gin.H{"agents": agents})
+}
+
+func (h *AgentsHandler) Types(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"types": crawler.AgentTypes()})
+}
+
+func (h *AgentsHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid agent id"})
+		return
+	}
+
+	agent, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if agent == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "agent not found"})
+		return
+	}
+	c.JSON(http.StatusOK, agent)
+}
+
+func (h *AgentsHandler) Create(c *gin.Context) {
+	var req agentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	agent := &models.AgentConfig{}
+	req.apply(agent)
+	if err := crawler.ValidateAgentConfig(*agent); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), agent); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, agent)
+}
+
+func (h *AgentsHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid agent id"})
+		return
+	}
+
+	var req agentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	agent, err := h.repo.GetByID(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if agent == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "agent not found"})
+		return
+	}
+
+	req.apply(agent)
+	if err := crawler.ValidateAgentConfig(*agent); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.repo.Update(ctx, agent); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, agent)
+}
+
+func (h *AgentsHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid agent id"})
+		return
+	}
+
+	deleted, err := h.repo.Delete(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "agent not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/services"
+)
+
+// backfillTimeout bounds a backfill started over the API.
+const backfillTimeout = 2 * time.Hour
+
+// BackfillHandler starts historical backfills and reports on them. Each
+// backfill runs in the background and is tracked as a "backfill" crawl run.
+type BackfillHandler struct {
+	backfiller *services.Backfiller
+	runs       *db.CrawlRunRepository
+}
+
+func NewBackfillHandler(database *db.DB, cfg *config.Config) *BackfillHandler {
+	return &BackfillHandler{
+		backfiller: services.NewBackfiller(database, cfg),
+		runs:       db.NewCrawlRunRepository(database),
+	}
+}
+
+func (h *BackfillHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/backfill", h.Start)
+	rg.GET("/backfill", h.List)
+	rg.GET("/backfill/:id", h.Get)
+}
+
+type backfillRequest struct {
+	SourceID string `json:"source_id" binding:"required"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Weeks    int    `json:"weeks"`
+	Query    string `json:"query"`
+	MaxPages int    `json:"max_pages"`
+}
+
+func (h *BackfillHandler) Start(c *gin.Context) {
+	var body backfillRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sourceID, err := uuid.Parse(body.SourceID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid source_id"})
+		return
+	}
+
+	req := services.BackfillRequest{SourceID: sourceID, Query: body.Query, MaxPages: body.MaxPages, To: time.Now()}
+	if body.To != "" {
+		if req.To, err = services.ParseBackfillTime(body.To); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	switch {
+	case body.From != "":
+		if req.From, err = services.ParseBackfillTime(body.From); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	case body.Weeks > 0:
+		req.From = req.To.AddDate(0, 0, -7*body.Weeks)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from or weeks is required"})
+		return
+	}
+
+	bf, err := h.backfiller.Prepare(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrBackfillUnsupported) {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), backfillTimeout)
+		defer cancel()
+		if _, err := bf.Run(ctx); err != nil {
+			log.Printf("Backfill %s failed: %v", bf.RunID(), err)
+		}
+	}()
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "started", "id": bf.RunID()})
+}
+
+func (h *BackfillHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	runs, err := h.runs.List(c.Request.Context(), "backfill", limit, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if runs == nil {
+		runs = []models.CrawlRun{}
+	}
+	c.JSON(http.StatusOK, gin.H{"backfills": runs})
+}
+
+func (h *BackfillHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid backfill id"})
+		return
+	}
+
+	run, err := h.runs.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if run == nil || run.TriggerType != "backfill" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "backfill not found"})
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// EntitiesHandler exposes admin endpoints to curate the entity gazetteer.
+type EntitiesHandler struct {
+	extractor *entities.Extractor
+}
+
+func NewEntitiesHandler(database *db.DB, cfg *config.Config) *EntitiesHandler {
+	return &EntitiesHandler{extractor: entities.NewExtractor(database, cfg, nil)}
+}
+
+func (h *EntitiesHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.PUT("/entities/:slug", h.Put)
+}
+
+// Put creates or replaces the entity named in the path, e.g. to add aliases
+// or fix the kind of an entity the LLM pass created. Newly ingested items
+// are matched against it from then on; existing items are not relinked.
+func (h *EntitiesHandler) Put(c *gin.Context) {
+	var entity models.Entity
+	if err := c.ShouldBindJSON(&entity); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	entity.Slug = c.Param("slug")
+
+	if err := h.extractor.Put(c.Request.Context(), &entity); err != nil {
+		if errors.Is(err, entities.ErrInvalid) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"entity": entity})
+}
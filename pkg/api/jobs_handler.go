@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// JobsHandler exposes admin endpoints for inspecting and requeueing
+// enrichment jobs.
+type JobsHandler struct {
+	repo *db.JobRepository
+}
+
+func NewJobsHandler(database *db.DB) *JobsHandler {
+	return &JobsHandler{
+		repo: db.NewJobRepository(database),
+	}
+}
+
+func (h *JobsHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/jobs", h.List)
+	rg.GET("/jobs/stats", h.Stats)
+	rg.POST("/jobs/requeue-dead", h.RequeueDead)
+	rg.POST("/jobs/:id/requeue", h.Requeue)
+}
+
+var jobStatuses = map[string]bool{
+	jobs.StatusPending: true,
+	jobs.StatusRunning: true,
+	jobs.StatusDone:    true,
+	jobs.StatusDead:    true,
+}
+
+func (h *JobsHandler) List(c *gin.Context) {
+	status := c.Query("status")
+	if status != "" && !jobStatuses[status] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of pending, running, done, dead"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.repo.List(c.Request.Context(), status, c.Query("type"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = []models.Job{}
+	}
+	c.JSON(http.StatusOK, gin.H{"jobs": list, "limit": limit, "offset": offset})
+}
+
+func (h *JobsHandler) Stats(c *gin.Context) {
+	counts, err := h.repo.CountByStatus(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"stats": counts})
+}
+
+func (h *JobsHandler) Requeue(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job id"})
+		return
+	}
+
+	ok, err := h.repo.Requeue(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "job not found, still queued, or already has a live duplicate"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "requeued"})
+}
+
+func (h *JobsHandler) RequeueDead(c *gin.Context) {
+	n, err := h.repo.RequeueDead(c.Request.Context(), c.Query("type"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"requeued": n})
+}
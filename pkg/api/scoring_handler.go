@@ -0,0 +1,61 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+)
+
+// ScoringHandler exposes admin endpoints for the runtime scoring config.
+type ScoringHandler struct {
+	loader *scoring.Loader
+}
+
+func NewScoringHandler(loader *scoring.Loader) *ScoringHandler {
+	return &ScoringHandler{loader: loader}
+}
+
+func (h *ScoringHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/scoring", h.Get)
+	rg.POST("/scoring/validate", h.Validate)
+	rg.POST("/scoring/reload", h.Reload)
+}
+
+// Get returns the active scoring config and where it was loaded from.
+func (h *ScoringHandler) Get(c *gin.Context) {
+	cfg := scoring.Current()
+	c.JSON(http.StatusOK, gin.H{
+		"version": cfg.Version,
+		"source":  h.loader.Source(),
+		"config":  cfg,
+	})
+}
+
+// Validate checks a YAML or JSON scoring config without applying it.
+func (h *ScoringHandler) Validate(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cfg, err := scoring.Parse(body)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"valid": false, "error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"valid": true, "version": cfg.Version, "config": cfg})
+}
+
+// Reload re-reads the scoring config now instead of waiting for the next
+// poll. An invalid config is rejected and the active one kept.
+func (h *ScoringHandler) Reload(c *gin.Context) {
+	cfg, err := h.loader.Reload(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "version": cfg.Version})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"version": cfg.Version, "source": h.loader.Source()})
+}
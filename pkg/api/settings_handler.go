@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
 )
 
 type SettingsHandler struct {
@@ -38,6 +39,14 @@ func (h *SettingsHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// Reject a broken scoring config here rather than on the next reload.
+	if raw, ok := req[scoring.SettingsKey]; ok && raw != "" {
+		if _, err := scoring.Parse([]byte(raw)); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	ctx := c.Request.Context()
 	for k, v := range req {
 		if err := h.repo.Set(ctx, k, v); err != nil {
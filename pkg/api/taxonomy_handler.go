@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
+)
+
+// TaxonomyHandler exposes the topic taxonomy and admin endpoints to edit it.
+type TaxonomyHandler struct {
+	svc *taxonomy.Service
+}
+
+func NewTaxonomyHandler(svc *taxonomy.Service) *TaxonomyHandler {
+	return &TaxonomyHandler{svc: svc}
+}
+
+func (h *TaxonomyHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/topics", h.List)
+	rg.PUT("/topics/:slug", h.Put)
+	rg.DELETE("/topics/:slug", h.Delete)
+}
+
+// List returns the active taxonomy with each topic's children.
+func (h *TaxonomyHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, taxonomyResponse(taxonomy.Current()))
+}
+
+// Put creates or replaces the topic named in the path. Edits that would make
+// the taxonomy invalid (unknown parent, cycles, alias clashes, bad patterns)
+// are rejected.
+func (h *TaxonomyHandler) Put(c *gin.Context) {
+	var topic models.Topic
+	if err := c.ShouldBindJSON(&topic); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	topic.Slug = c.Param("slug")
+
+	tax, err := h.svc.Put(c.Request.Context(), &topic)
+	if err != nil {
+		if errors.Is(err, taxonomy.ErrInvalid) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"topic": topic, "version": tax.Version()})
+}
+
+// Delete removes a topic without children.
+func (h *TaxonomyHandler) Delete(c *gin.Context) {
+	tax, err := h.svc.Delete(c.Request.Context(), c.Param("slug"))
+	switch {
+	case errors.Is(err, taxonomy.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, taxonomy.ErrHasChildren):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, gin.H{"deleted": c.Param("slug"), "version": tax.Version()})
+	}
+}
+
+type topicNode struct {
+	models.Topic
+	Children []string `json:"children"`
+}
+
+func taxonomyResponse(tax *taxonomy.Taxonomy) gin.H {
+	topics := tax.Topics()
+	nodes := make([]topicNode, len(topics))
+	for i, t := range topics {
+		children := tax.Children(t.Slug)
+		if children == nil {
+			children = []string{}
+		}
+		nodes[i] = topicNode{Topic: t, Children: children}
+	}
+	return gin.H{"version": tax.Version(), "topics": nodes}
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 // EmbeddingProvider defines the specific interface for generating vector maps in Phase 5.
@@ -24,7 +26,7 @@ type OpenRouterEmbedder struct {
 func NewOpenRouterEmbedder(apiKey string) *OpenRouterEmbedder {
 	return &OpenRouterEmbedder{
 		apiKey: apiKey,
-		client: &http.Client{Timeout: 15 * time.Second},
+		client: fetch.NewClient(15 * time.Second),
 	}
 }
 
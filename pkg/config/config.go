@@ -18,6 +18,30 @@ const (
 	defaultMaxRetries        = 3
 	defaultFetchTimeout      = 8
 	defaultMaxFetchBytes     = 2000000
+	defaultFetchHostRPS      = 1.0
+	defaultFetchHostBurst    = 2
+	defaultFetchConcurrency  = 8
+	defaultFetchHostLimits   = "hacker-news.firebaseio.com=5:10,hn.algolia.com=1:2,export.arxiv.org=0.33:1,reddit.com=0.5:1,openrouter.ai=2:4"
+	defaultSourceFailures    = 3
+	defaultSourceCooldown    = 10 // minutes
+	defaultSourceMaxCooldown = 24 // hours
+	defaultSourceDisableDays = 7
+	defaultBackfillMaxPages  = 50
+	defaultBackfillTokens    = 3000
+	defaultBackfillEnrichMax = 100
+	defaultNearDupThreshold  = 0.6
+	defaultNearDupWindow     = 72 // hours
+	defaultRisingWindow      = 24 // hours
+	defaultRisingBaseline    = 7  // days
+	defaultRisingZThreshold  = 2.0
+	defaultRisingMinBaseline = 10
+	defaultReputationDays    = 90
+	defaultReputationPrior   = 20.0 // pseudo-items
+	defaultReputationRefresh = 60   // minutes
+	defaultScoringConfig     = "configs/scoring.yaml"
+	defaultScoringReload     = 60 // seconds
+	defaultTaxonomyRefresh   = 60 // seconds
+	defaultCanonicalSkip     = "arxiv.org,news.ycombinator.com,github.com,huggingface.co,paperswithcode.com"
 	defaultTopicKeywords     = "llm,agents,vision,open source,infra,robotics,security,ai,machine learning"
 	defaultFallbackLLMModels = "anthropic/claude-3.5-sonnet,meta-llama/llama-3.1-70b-instruct"
 )
@@ -35,6 +59,55 @@ type Config struct {
 	MaxFetchBytes       int64
 	FetchTimeoutSeconds int
 
+	// Outbound politeness (see pkg/fetch)
+	FetchHostRPS       float64
+	FetchHostBurst     int
+	FetchMaxConcurrent int
+	FetchHostLimits    []string // "host=rps[:burst]" overrides
+
+	// Canonical URL resolution for new items
+	ResolveCanonical   bool
+	CanonicalSkipHosts []string // hosts whose links are already canonical
+
+	// Near-duplicate detection (see pkg/dedup)
+	NearDupEnabled     bool
+	NearDupThreshold   float64 // estimated Jaccard similarity of title/excerpt words
+	NearDupWindowHours int     // how far apart duplicates may be published
+
+	// Per-source circuit breaker
+	SourceFailureThreshold int
+	SourceCooldownMinutes  int
+	SourceMaxCooldownHours int
+	SourceDisableAfterDays int // 0 disables auto-disable
+
+	// Historical backfill
+	BackfillMaxPages    int // upstream pages fetched per backfill
+	BackfillDailyTokens int // LLM tokens per day backfill enrichment may use; 0 disables it
+	BackfillEnrichMax   int // items queued for LLM enrichment per backfill
+
+	RisingVelocityWindowHours int     // signal history used to compute an item's velocity
+	RisingBaselineDays        int     // per-source velocity baseline for z-scores
+	RisingZThreshold          float64 // z-score at which a riser is flagged anomalous
+	RisingMinBaseline         int     // items a source needs in its baseline before z-scores are used
+
+	ReputationEnabled        bool    // learn domain/source credibility from history
+	ReputationLookbackDays   int     // history used to learn reputations
+	ReputationPriorWeight    float64 // items of evidence the population prior counts as
+	ReputationRefreshMinutes int     // how often reputations are recomputed
+
+	ScoringConfigPath    string // YAML scoring config; a scoring_config setting overrides it
+	ScoringReloadSeconds int    // how often the scoring config is re-read; 0 disables reloading
+
+	TaxonomyRefreshSeconds int // how often the topic taxonomy is re-read from the database
+
+	EntityLLMEnabled bool // ask the LLM for entity names the gazetteer does not know; runs through the AI service and its token budget
+
+	TagLLMEnabled bool // classify summarized items against the taxonomy with the LLM instead of by keyword
+
+	// SummaryLanguage is the ISO 639-1 code LLM summaries are translated
+	// into, e.g. "id"; empty disables translation.
+	SummaryLanguage string
+
 	// LLM Configuration
 	LLMProvider       string
 	LLMModel          string
@@ -65,6 +138,48 @@ func Load() *Config {
 		MaxFetchBytes:       int64(getEnvInt("MAX_FETCH_BYTES", defaultMaxFetchBytes)),
 		FetchTimeoutSeconds: getEnvInt("FETCH_TIMEOUT_SECONDS", defaultFetchTimeout),
 
+		FetchHostRPS:       getEnvFloat("FETCH_HOST_RPS", defaultFetchHostRPS),
+		FetchHostBurst:     getEnvInt("FETCH_HOST_BURST", defaultFetchHostBurst),
+		FetchMaxConcurrent: getEnvInt("FETCH_MAX_CONCURRENT", defaultFetchConcurrency),
+		FetchHostLimits:    splitString(getEnv("FETCH_HOST_LIMITS", defaultFetchHostLimits), ","),
+
+		ResolveCanonical:   getEnvBool("RESOLVE_CANONICAL_URLS", true),
+		CanonicalSkipHosts: splitString(getEnv("CANONICAL_SKIP_HOSTS", defaultCanonicalSkip), ","),
+
+		NearDupEnabled:     getEnvBool("NEAR_DUP_ENABLED", true),
+		NearDupThreshold:   getEnvFloat("NEAR_DUP_THRESHOLD", defaultNearDupThreshold),
+		NearDupWindowHours: getEnvInt("NEAR_DUP_WINDOW_HOURS", defaultNearDupWindow),
+
+		SourceFailureThreshold: getEnvInt("SOURCE_FAILURE_THRESHOLD", defaultSourceFailures),
+		SourceCooldownMinutes:  getEnvInt("SOURCE_COOLDOWN_MINUTES", defaultSourceCooldown),
+		SourceMaxCooldownHours: getEnvInt("SOURCE_MAX_COOLDOWN_HOURS", defaultSourceMaxCooldown),
+		SourceDisableAfterDays: getEnvInt("SOURCE_DISABLE_AFTER_DAYS", defaultSourceDisableDays),
+
+		BackfillMaxPages:    getEnvInt("BACKFILL_MAX_PAGES", defaultBackfillMaxPages),
+		BackfillDailyTokens: getEnvInt("BACKFILL_DAILY_TOKENS", defaultBackfillTokens),
+		BackfillEnrichMax:   getEnvInt("BACKFILL_ENRICH_MAX", defaultBackfillEnrichMax),
+
+		RisingVelocityWindowHours: getEnvInt("RISING_VELOCITY_WINDOW_HOURS", defaultRisingWindow),
+		RisingBaselineDays:        getEnvInt("RISING_BASELINE_DAYS", defaultRisingBaseline),
+		RisingZThreshold:          getEnvFloat("RISING_ZSCORE_THRESHOLD", defaultRisingZThreshold),
+		RisingMinBaseline:         getEnvInt("RISING_MIN_BASELINE", defaultRisingMinBaseline),
+
+		ReputationEnabled:        getEnvBool("REPUTATION_ENABLED", true),
+		ReputationLookbackDays:   getEnvInt("REPUTATION_LOOKBACK_DAYS", defaultReputationDays),
+		ReputationPriorWeight:    getEnvFloat("REPUTATION_PRIOR_WEIGHT", defaultReputationPrior),
+		ReputationRefreshMinutes: getEnvInt("REPUTATION_REFRESH_MINUTES", defaultReputationRefresh),
+
+		ScoringConfigPath:    getEnv("SCORING_CONFIG_PATH", defaultScoringConfig),
+		ScoringReloadSeconds: getEnvInt("SCORING_RELOAD_SECONDS", defaultScoringReload),
+
+		TaxonomyRefreshSeconds: getEnvInt("TAXONOMY_REFRESH_SECONDS", defaultTaxonomyRefresh),
+
+		EntityLLMEnabled: getEnvBool("ENTITY_LLM_ENABLED", false),
+
+		TagLLMEnabled: getEnvBool("TAG_LLM_ENABLED", true),
+
+		SummaryLanguage: strings.ToLower(strings.TrimSpace(getEnv("SUMMARY_LANGUAGE", ""))),
+
 		// LLM Configuration
 		LLMProvider:       getEnv("LLM_PROVIDER", "openrouter"),
 		LLMModel:          getEnv("LLM_MODEL", "google/gemini-flash-1.5"),
@@ -89,6 +204,58 @@ func (c *Config) Validate() {
 		slog.Warn("MAX_CRAWL_RETRIES must be positive, defaulting to 3", "val", c.MaxCrawlRetries)
 		c.MaxCrawlRetries = defaultMaxRetries
 	}
+	if c.FetchHostRPS <= 0 {
+		slog.Warn("FETCH_HOST_RPS must be positive, defaulting to 1", "val", c.FetchHostRPS)
+		c.FetchHostRPS = defaultFetchHostRPS
+	}
+	if c.FetchMaxConcurrent < 0 {
+		slog.Warn("FETCH_MAX_CONCURRENT must not be negative, defaulting to 8", "val", c.FetchMaxConcurrent)
+		c.FetchMaxConcurrent = defaultFetchConcurrency
+	}
+	if c.SourceFailureThreshold <= 0 {
+		slog.Warn("SOURCE_FAILURE_THRESHOLD must be positive, defaulting to 3", "val", c.SourceFailureThreshold)
+		c.SourceFailureThreshold = defaultSourceFailures
+	}
+	if c.SourceCooldownMinutes <= 0 {
+		slog.Warn("SOURCE_COOLDOWN_MINUTES must be positive, defaulting to 10", "val", c.SourceCooldownMinutes)
+		c.SourceCooldownMinutes = defaultSourceCooldown
+	}
+	if c.BackfillMaxPages <= 0 {
+		slog.Warn("BACKFILL_MAX_PAGES must be positive, defaulting to 50", "val", c.BackfillMaxPages)
+		c.BackfillMaxPages = defaultBackfillMaxPages
+	}
+	if c.NearDupThreshold <= 0 || c.NearDupThreshold > 1 {
+		slog.Warn("NEAR_DUP_THRESHOLD must be in (0, 1], defaulting to 0.6", "val", c.NearDupThreshold)
+		c.NearDupThreshold = defaultNearDupThreshold
+	}
+	if c.NearDupWindowHours <= 0 {
+		slog.Warn("NEAR_DUP_WINDOW_HOURS must be positive, defaulting to 72", "val", c.NearDupWindowHours)
+		c.NearDupWindowHours = defaultNearDupWindow
+	}
+	if c.RisingVelocityWindowHours <= 0 {
+		slog.Warn("RISING_VELOCITY_WINDOW_HOURS must be positive, defaulting to 24", "val", c.RisingVelocityWindowHours)
+		c.RisingVelocityWindowHours = defaultRisingWindow
+	}
+	if c.RisingBaselineDays <= 0 {
+		slog.Warn("RISING_BASELINE_DAYS must be positive, defaulting to 7", "val", c.RisingBaselineDays)
+		c.RisingBaselineDays = defaultRisingBaseline
+	}
+	if c.ReputationLookbackDays <= 0 {
+		slog.Warn("REPUTATION_LOOKBACK_DAYS must be positive, defaulting to 90", "val", c.ReputationLookbackDays)
+		c.ReputationLookbackDays = defaultReputationDays
+	}
+	if c.ReputationPriorWeight <= 0 {
+		slog.Warn("REPUTATION_PRIOR_WEIGHT must be positive, defaulting to 20", "val", c.ReputationPriorWeight)
+		c.ReputationPriorWeight = defaultReputationPrior
+	}
+	if c.RisingMinBaseline < 2 {
+		slog.Warn("RISING_MIN_BASELINE must be at least 2, defaulting to 10", "val", c.RisingMinBaseline)
+		c.RisingMinBaseline = defaultRisingMinBaseline
+	}
+	if c.TaxonomyRefreshSeconds <= 0 {
+		slog.Warn("TAXONOMY_REFRESH_SECONDS must be positive, defaulting to 60", "val", c.TaxonomyRefreshSeconds)
+		c.TaxonomyRefreshSeconds = defaultTaxonomyRefresh
+	}
 }
 
 // CacheTTL returns duration for cache expiry.
@@ -96,6 +263,31 @@ func (c *Config) CacheTTL() time.Duration {
 	return time.Duration(c.CacheTTLSeconds) * time.Second
 }
 
+// RisingVelocityWindow returns the signal history used for item velocity.
+func (c *Config) RisingVelocityWindow() time.Duration {
+	return time.Duration(c.RisingVelocityWindowHours) * time.Hour
+}
+
+// RisingBaseline returns the per-source velocity baseline window.
+func (c *Config) RisingBaseline() time.Duration {
+	return time.Duration(c.RisingBaselineDays) * 24 * time.Hour
+}
+
+// ReputationRefreshInterval returns how often reputations are recomputed.
+func (c *Config) ReputationRefreshInterval() time.Duration {
+	return time.Duration(c.ReputationRefreshMinutes) * time.Minute
+}
+
+// ScoringReloadInterval returns how often the scoring config is re-read.
+func (c *Config) ScoringReloadInterval() time.Duration {
+	return time.Duration(c.ScoringReloadSeconds) * time.Second
+}
+
+// TaxonomyRefreshInterval returns how often the topic taxonomy is re-read.
+func (c *Config) TaxonomyRefreshInterval() time.Duration {
+	return time.Duration(c.TaxonomyRefreshSeconds) * time.Second
+}
+
 // FetchTimeout returns HTTP client timeout duration.
 func (c *Config) FetchTimeout() time.Duration {
 	return time.Duration(c.FetchTimeoutSeconds) * time.Second
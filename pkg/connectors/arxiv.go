@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
@@ -12,14 +13,20 @@ import (
 	"github.com/hidatara-ds/evolipia-radar/pkg/normalizer"
 )
 
-const arxivAPIBase = "http://export.arxiv.org/api/query"
+const arxivAPIBase = "https://export.arxiv.org/api/query"
 
 func FetchArxiv(ctx context.Context, query string, cfg *config.Config) ([]dto.ContentItem, error) {
+	return FetchArxivPage(ctx, query, 0, 100, cfg)
+}
+
+// FetchArxivPage returns up to max entries matching query, newest first,
+// starting at offset start.
+func FetchArxivPage(ctx context.Context, query string, start, max int, cfg *config.Config) ([]dto.ContentItem, error) {
 	// Build query URL
 	params := url.Values{}
 	params.Set("search_query", query)
-	params.Set("start", "0")
-	params.Set("max_results", "100")
+	params.Set("start", strconv.Itoa(start))
+	params.Set("max_results", strconv.Itoa(max))
 	params.Set("sortBy", "submittedDate")
 	params.Set("sortOrder", "descending")
 
@@ -57,6 +64,12 @@ func FetchArxiv(ctx context.Context, query string, cfg *config.Config) ([]dto.Co
 	return items, nil
 }
 
+// ArxivDateRange restricts query to papers submitted in [from, to).
+func ArxivDateRange(query string, from, to time.Time) string {
+	const layout = "200601021504"
+	return fmt.Sprintf("(%s) AND submittedDate:[%s TO %s]", query, from.UTC().Format(layout), to.UTC().Format(layout))
+}
+
 type ArxivFeed struct {
 	XMLName xml.Name     `xml:"feed"`
 	Entries []ArxivEntry `xml:"entry"`
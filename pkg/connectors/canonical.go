@@ -0,0 +1,164 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/normalizer"
+)
+
+// canonicalReadBytes bounds how much of a page is read looking for
+// <link rel="canonical"> / og:url; both live in <head>.
+const canonicalReadBytes = 256 << 10
+
+var (
+	headTagPattern  = regexp.MustCompile(`(?is)<(?:link|meta)\b[^>]*>`)
+	tagAttrPattern  = regexp.MustCompile(`(?is)([a-z][a-z0-9:_-]*)\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	endOfHeadMarker = regexp.MustCompile(`(?i)</head>`)
+)
+
+// ResolveCanonicalURL returns the canonical form of rawURL: the
+// <link rel="canonical"> or og:url declared by the page, otherwise the final
+// URL after redirects. Every redirect hop is re-validated like the original
+// request. rawURL is returned unchanged when resolution is disabled or its
+// host is listed in CanonicalSkipHosts.
+func ResolveCanonicalURL(ctx context.Context, rawURL string, cfg *config.Config) (string, error) {
+	if !cfg.ResolveCanonical {
+		return rawURL, nil
+	}
+
+	u, err := validateOutboundURL(ctx, rawURL, allowedFetchHostsFromEnv())
+	if err != nil {
+		return "", err
+	}
+	if hostAllowed(strings.ToLower(u.Hostname()), cfg.CanonicalSkipHosts) {
+		return rawURL, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "evolipia-radar/1.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.5")
+
+	resp, err := newSafeHTTPClient(cfg).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	final := resp.Request.URL
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(strings.ToLower(ct), "html") {
+		return final.String(), nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, canonicalReadBytes))
+	if err != nil {
+		return final.String(), nil
+	}
+	if canonical := canonicalFromHTML(body, final); canonical != "" {
+		return canonical, nil
+	}
+	return final.String(), nil
+}
+
+// CanonicalURL resolves normalizedURL to its normalized canonical form,
+// falling back to normalizedURL when the page can't be resolved.
+func CanonicalURL(ctx context.Context, normalizedURL string, cfg *config.Config) string {
+	resolved, err := ResolveCanonicalURL(ctx, normalizedURL, cfg)
+	if err != nil {
+		log.Printf("Canonical URL lookup failed for %s: %v", normalizedURL, err)
+		return normalizedURL
+	}
+	canonical, err := normalizer.NormalizeURL(resolved)
+	if err != nil {
+		return normalizedURL
+	}
+	return canonical
+}
+
+// canonicalFromHTML extracts the canonical URL declared in page, resolved
+// against base. <link rel="canonical"> wins over og:url. Canonicals that
+// collapse an article onto the site root are ignored, as some CMSs emit
+// them on every page.
+func canonicalFromHTML(page []byte, base *url.URL) string {
+	if loc := endOfHeadMarker.FindIndex(page); loc != nil {
+		page = page[:loc[0]]
+	}
+
+	var link, og string
+	for _, tag := range headTagPattern.FindAll(page, -1) {
+		attrs := tagAttrs(tag)
+		switch {
+		case link == "" && hasToken(attrs["rel"], "canonical"):
+			link = attrs["href"]
+		case og == "" && strings.EqualFold(attrs["property"], "og:url"):
+			og = attrs["content"]
+		}
+	}
+
+	for _, candidate := range []string{link, og} {
+		if resolved := resolveCanonical(candidate, base); resolved != "" {
+			return resolved
+		}
+	}
+	return ""
+}
+
+func resolveCanonical(candidate string, base *url.URL) string {
+	candidate = strings.TrimSpace(candidate)
+	if candidate == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(candidate)
+	if err != nil {
+		return ""
+	}
+	u := base.ResolveReference(ref)
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return ""
+	}
+	if isRootPath(u.Path) && !isRootPath(base.Path) {
+		return ""
+	}
+	return u.String()
+}
+
+func tagAttrs(tag []byte) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range tagAttrPattern.FindAllSubmatch(tag, -1) {
+		name := strings.ToLower(string(m[1]))
+		value := strings.Trim(string(m[2]), `"'`)
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = html.UnescapeString(value)
+		}
+	}
+	return attrs
+}
+
+func hasToken(list, token string) bool {
+	for _, f := range strings.Fields(list) {
+		if strings.EqualFold(f, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRootPath(p string) bool {
+	return p == "" || p == "/"
+}
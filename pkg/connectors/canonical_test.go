@@ -0,0 +1,77 @@
+package connectors
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+)
+
+func TestCanonicalFromHTML(t *testing.T) {
+	base, _ := url.Parse("https://example.com/2026/07/story?utm_source=feed")
+
+	tests := []struct {
+		name string
+		page string
+		want string
+	}{
+		{
+			name: "link rel canonical",
+			page: `<html><head><link rel="canonical" href="https://example.com/2026/07/story"></head></html>`,
+			want: "https://example.com/2026/07/story",
+		},
+		{
+			name: "relative canonical resolved against final URL",
+			page: `<head><LINK REL='canonical' HREF='/articles/story'></head>`,
+			want: "https://example.com/articles/story",
+		},
+		{
+			name: "link preferred over og:url",
+			page: `<head><meta property="og:url" content="https://example.com/og"><link href="https://example.com/link" rel="alternate canonical"></head>`,
+			want: "https://example.com/link",
+		},
+		{
+			name: "og:url fallback",
+			page: `<head><meta property="og:url" content="https://example.com/story?a=1&amp;b=2"></head>`,
+			want: "https://example.com/story?a=1&b=2",
+		},
+		{
+			name: "site root canonical ignored",
+			page: `<head><link rel="canonical" href="https://example.com/"></head>`,
+			want: "",
+		},
+		{
+			name: "non-http scheme ignored",
+			page: `<head><link rel="canonical" href="javascript:alert(1)"></head>`,
+			want: "",
+		},
+		{
+			name: "tags after head ignored",
+			page: `<head></head><body><link rel="canonical" href="https://example.com/body"></body>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canonicalFromHTML([]byte(tt.page), base); got != tt.want {
+				t.Errorf("canonicalFromHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalURL_FallsBack(t *testing.T) {
+	ctx := context.Background()
+	const link = "https://example.com/post"
+
+	if got := CanonicalURL(ctx, link, &config.Config{ResolveCanonical: false}); got != link {
+		t.Errorf("disabled: CanonicalURL = %q, want %q", got, link)
+	}
+	// Private addresses are refused, so resolution fails and the link stands.
+	private := "https://127.0.0.1/post"
+	if got := CanonicalURL(ctx, private, &config.Config{ResolveCanonical: true}); got != private {
+		t.Errorf("unresolvable: CanonicalURL = %q, want %q", got, private)
+	}
+}
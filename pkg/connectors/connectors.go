@@ -10,11 +10,13 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/dto"
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 	"github.com/hidatara-ds/evolipia-radar/pkg/normalizer"
 )
 
@@ -92,7 +94,12 @@ func fetchWithLimits(ctx context.Context, rawURL string, cfg *config.Config) ([]
 	return body, nil
 }
 
-// Disable redirects so attacker can't redirect from public URL -> internal URL.
+// maxRedirects bounds the redirect chain followed for one request.
+const maxRedirects = 5
+
+// Redirects are followed, but every hop is re-validated with
+// validateOutboundURL so a public URL can't bounce a request to an internal
+// one. Requests are paced per host by the shared fetch limiter.
 func newSafeHTTPClient(cfg *config.Config) *http.Client {
 	base, ok := http.DefaultTransport.(*http.Transport)
 	if !ok || base == nil {
@@ -106,9 +113,13 @@ func newSafeHTTPClient(cfg *config.Config) *http.Client {
 
 	return &http.Client{
 		Timeout:   cfg.FetchTimeout(),
-		Transport: transport,
+		Transport: fetch.NewTransport(transport, fetch.Shared()),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			_, err := validateOutboundURL(req.Context(), req.URL.String(), allowedFetchHostsFromEnv())
+			return err
 		},
 	}
 }
@@ -455,6 +466,48 @@ func FetchJSONAPI(ctx context.Context, apiURL string, mapping map[string]interfa
 	return items, nil
 }
 
+// FetchJSONAPIPage fetches one page of a paginated JSON API. The mapping's
+// page_param names the query parameter carrying the page number; page_size_param
+// and page_size optionally set the page size.
+func FetchJSONAPIPage(ctx context.Context, apiURL string, mapping map[string]interface{}, page int, cfg *config.Config) ([]dto.ContentItem, error) {
+	pageParam := getString(mapping, "page_param", "")
+	if pageParam == "" {
+		return nil, fmt.Errorf("mapping has no page_param; source is not paginated")
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse failed", ErrInvalidURL)
+	}
+	q := u.Query()
+	q.Set(pageParam, strconv.Itoa(page))
+	if sizeParam := getString(mapping, "page_size_param", ""); sizeParam != "" {
+		if size := getInt(mapping, "page_size", 0); size > 0 {
+			q.Set(sizeParam, strconv.Itoa(size))
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	return FetchJSONAPI(ctx, u.String(), mapping, cfg)
+}
+
+// JSONAPIFirstPage returns the number of the first page (page_start, default 1).
+func JSONAPIFirstPage(mapping map[string]interface{}) int {
+	return getInt(mapping, "page_start", 1)
+}
+
+func getInt(m map[string]interface{}, key string, defaultValue int) int {
+	switch v := m[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
 func getNestedValue(data map[string]interface{}, path string) interface{} {
 	parts := strings.Split(path, ".")
 	current := interface{}(data)
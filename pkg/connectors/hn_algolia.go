@@ -0,0 +1,96 @@
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/dto"
+	"github.com/hidatara-ds/evolipia-radar/pkg/normalizer"
+)
+
+const hnAlgoliaBase = "https://hn.algolia.com/api/v1/search_by_date"
+
+// HNAlgoliaPage is one page of stories from the HN Algolia search API.
+type HNAlgoliaPage struct {
+	Items   []dto.ContentItem
+	Page    int
+	NbPages int
+	// Oldest is the creation time of the oldest hit on the page. Algolia
+	// stops paginating after 1000 hits, so callers walk further back by
+	// moving the window end to Oldest.
+	Oldest time.Time
+}
+
+// FetchHNAlgoliaPage returns stories created in [from, to), newest first.
+// query is optional full-text search.
+func FetchHNAlgoliaPage(ctx context.Context, query string, from, to time.Time, page int, cfg *config.Config) (*HNAlgoliaPage, error) {
+	params := url.Values{}
+	params.Set("tags", "story")
+	params.Set("numericFilters", fmt.Sprintf("created_at_i>=%d,created_at_i<%d", from.Unix(), to.Unix()))
+	params.Set("hitsPerPage", "100")
+	params.Set("page", strconv.Itoa(page))
+	if query != "" {
+		params.Set("query", query)
+	}
+
+	body, err := fetchWithLimits(ctx, hnAlgoliaBase+"?"+params.Encode(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Hits []struct {
+			ObjectID    string `json:"objectID"`
+			Title       string `json:"title"`
+			URL         string `json:"url"`
+			Points      *int   `json:"points"`
+			NumComments *int   `json:"num_comments"`
+			CreatedAtI  int64  `json:"created_at_i"`
+			StoryText   string `json:"story_text"`
+		} `json:"hits"`
+		Page    int `json:"page"`
+		NbPages int `json:"nbPages"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse HN Algolia response: %w", err)
+	}
+
+	out := &HNAlgoliaPage{Page: resp.Page, NbPages: resp.NbPages}
+	for _, hit := range resp.Hits {
+		published := time.Unix(hit.CreatedAtI, 0)
+		if out.Oldest.IsZero() || published.Before(out.Oldest) {
+			out.Oldest = published
+		}
+		if hit.Title == "" {
+			continue
+		}
+
+		// Stories without URL are "Ask HN" posts - use discussion URL
+		link := hit.URL
+		if link == "" {
+			link = "https://news.ycombinator.com/item?id=" + hit.ObjectID
+		}
+		parsedURL, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+
+		out.Items = append(out.Items, dto.ContentItem{
+			Title:       hit.Title,
+			URL:         link,
+			PublishedAt: published,
+			Excerpt:     hit.StoryText,
+			Domain:      normalizer.NormalizeDomain(parsedURL.Hostname()),
+			Category:    "news",
+			Points:      hit.Points,
+			Comments:    hit.NumComments,
+			Tags:        []string{},
+		})
+	}
+	return out, nil
+}
@@ -10,18 +10,28 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// defaultSeenTTL is how long a crawled URL stays deduplicated.
+const defaultSeenTTL = 24 * time.Hour
+
 // CrawlBudget enforces strict limits on ingestion rates and avoids duplicate crawling.
 type CrawlBudget struct {
 	mu sync.Mutex
 
-	seenCache map[string]time.Time // Basic deduplication by URL. (Use Redis for scale).
+	seenCache    map[string]time.Time // In-memory fallback when no database is configured.
+	seenTTL      time.Duration
+	bloom        *bloomFilter // Optional fast path in front of crawl_seen.
+	bloomLoaded  bool         // warmed since the last reset
+	bloomLoading bool         // a warm-up is in flight
+
+	bloomExpected int
+	bloomFPRate   float64
 
 	hourlyIngested   int
 	maxHourlyIngests int
 	lastReset        time.Time
 
 	metrics *Metrics
-	db      *pgxpool.Pool
+	store   seenStore // nil without a database
 }
 
 // Metrics monitors health and scale of the ingestion engine
@@ -89,55 +99,201 @@ func (m *Metrics) AddFiltered(ctx context.Context) {
 	}
 }
 
-// NewCrawlBudget initializes the budget restrictions for crawlers.
+// NewCrawlBudget initializes the budget restrictions for crawlers. When db is
+// set, the seen-set and hourly counters live in Postgres (crawl_seen and
+// crawl_budget_windows) so every instance shares the same budget; otherwise
+// they are kept in memory.
 func NewCrawlBudget(maxHourly int, m *Metrics, db *pgxpool.Pool) *CrawlBudget {
-	return &CrawlBudget{
+	b := &CrawlBudget{
 		seenCache:        make(map[string]time.Time),
+		seenTTL:          defaultSeenTTL,
 		maxHourlyIngests: maxHourly,
 		lastReset:        time.Now(),
 		metrics:          m,
-		db:               db,
 	}
+	if db != nil {
+		b.store = &pgSeenStore{db: db}
+	}
+	return b
 }
 
-// Consume check if we can ingest a URL. Returns false if deduplicated or budget exhausted.
-func (b *CrawlBudget) Consume(ctx context.Context, url string) bool {
+// EnableBloomFilter puts an in-memory Bloom filter in front of the crawl_seen
+// table. A hit only means the URL may have been seen: it is confirmed with a
+// cheap lookup instead of the claim transaction, so false positives and URLs
+// that expired since the filter was built are still crawled.
+func (b *CrawlBudget) EnableBloomFilter(expected int, fpRate float64) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.bloomExpected, b.bloomFPRate = expected, fpRate
+	b.bloom = newBloomFilter(expected, fpRate)
+	b.bloomLoaded = false
+}
 
+// DisableBloomFilter drops the Bloom filter, e.g. for a short-lived
+// instance that would spend longer warming it than it saves.
+func (b *CrawlBudget) DisableBloomFilter() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bloom = nil
+	b.bloomLoaded = false
+}
+
+// Consume check if we can ingest a URL. Returns false if deduplicated or budget exhausted.
+func (b *CrawlBudget) Consume(ctx context.Context, url string) bool {
 	now := time.Now()
-	// Reset hourly budget
-	if now.Sub(b.lastReset) >= time.Hour {
+
+	b.mu.Lock()
+	expired := now.Sub(b.lastReset) >= time.Hour
+	if expired {
+		// Reset hourly budget
 		b.hourlyIngested = 0
 		b.lastReset = now
-		// Simple cache cleanup
-		for k, v := range b.seenCache {
-			if now.Sub(v) > 24*time.Hour {
-				delete(b.seenCache, k)
-			}
+		b.expireSeenCache(now)
+		b.bloomLoaded = false // rebuilt below, without the expired URLs
+	}
+	if b.store == nil {
+		allowed, seen := b.consumeInMemory(url, now)
+		b.mu.Unlock()
+		return b.count(ctx, allowed, seen)
+	}
+	rebuild := b.bloom != nil && !b.bloomLoaded && !b.bloomLoading
+	if rebuild {
+		b.bloomLoading = true
+	}
+	b.mu.Unlock()
+
+	// Database work happens outside b.mu so one slow query does not stall
+	// every other agent.
+	if expired {
+		b.store.Cleanup(ctx)
+	}
+	if rebuild {
+		b.loadBloom(ctx)
+	}
+
+	if b.maxHourlyIngests <= 0 {
+		return false
+	}
+
+	if b.bloomTest(url) {
+		seen, err := b.store.Seen(ctx, url)
+		if err != nil {
+			log.Printf("[BUDGET] Seen lookup failed for %s: %v", url, err)
+		}
+		if seen {
+			return b.count(ctx, false, true)
 		}
 	}
 
+	allowed, seen, ingested, err := b.store.Claim(ctx, url, b.seenTTL, b.maxHourlyIngests)
+	if err != nil {
+		log.Printf("[BUDGET] Persistent dedup failed for %s, falling back to memory: %v", url, err)
+		b.mu.Lock()
+		allowed, seen = b.consumeInMemory(url, now)
+		b.mu.Unlock()
+		return b.count(ctx, allowed, seen)
+	}
+
+	b.mu.Lock()
+	if b.bloom != nil && (allowed || seen) {
+		b.bloom.Add(url)
+	}
+	if !seen {
+		b.hourlyIngested = ingested
+	}
+	b.mu.Unlock()
+	return b.count(ctx, allowed, seen)
+}
+
+// count records the outcome of Consume in the metrics and returns allowed.
+func (b *CrawlBudget) count(ctx context.Context, allowed, seen bool) bool {
+	switch {
+	case seen:
+		b.metrics.AddFiltered(ctx) // Deduplicated
+	case allowed:
+		b.metrics.AddProcessed(ctx)
+	}
+	return allowed // false without seen: rate limit hit
+}
+
+// consumeInMemory is the process-local fallback used without a database.
+// The caller must hold b.mu.
+func (b *CrawlBudget) consumeInMemory(url string, now time.Time) (allowed, seen bool) {
 	if b.hourlyIngested >= b.maxHourlyIngests {
-		return false // Rate limit hit
+		return false, false // Rate limit hit
 	}
 
-	if _, exists := b.seenCache[url]; exists {
-		b.metrics.AddFiltered(ctx)
-		return false // Deduplicated
+	if seenAt, exists := b.seenCache[url]; exists && now.Sub(seenAt) < b.seenTTL {
+		return false, true // Deduplicated
 	}
 
 	// Allowed
 	b.seenCache[url] = now
 	b.hourlyIngested++
-	b.metrics.AddProcessed(ctx)
-	return true
+	return true, false
+}
+
+// bloomTest reports whether url may have been seen. Without a Bloom filter
+// every URL goes straight to the claim.
+func (b *CrawlBudget) bloomTest(url string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.bloom != nil && b.bloom.Test(url)
+}
+
+// expireSeenCache drops expired in-memory entries. The caller must hold b.mu.
+func (b *CrawlBudget) expireSeenCache(now time.Time) {
+	for k, v := range b.seenCache {
+		if now.Sub(v) > b.seenTTL {
+			delete(b.seenCache, k)
+		}
+	}
+}
+
+// loadBloom builds a fresh Bloom filter from the most recently seen
+// unexpired URLs, as many as the filter is sized for, and syncs the local
+// hourly counter, so a cold start picks up where other instances left off.
+// The old filter stays in use until the new one is complete; if building
+// fails the next Consume tries again.
+func (b *CrawlBudget) loadBloom(ctx context.Context) {
+	b.mu.Lock()
+	expected, fpRate := b.bloomExpected, b.bloomFPRate
+	b.mu.Unlock()
+
+	f := newBloomFilter(expected, fpRate)
+	err := b.store.EachSeen(ctx, expected, f.Add)
+	var ingested int
+	var countErr error
+	if err == nil {
+		ingested, countErr = b.store.HourlyIngested(ctx)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bloomLoading = false
+	if err != nil {
+		log.Printf("[BUDGET] Failed to warm Bloom filter: %v", err)
+		return
+	}
+	if b.bloom == nil {
+		return // disabled meanwhile
+	}
+	b.bloom = f
+	b.bloomLoaded = true
+	if countErr == nil {
+		b.hourlyIngested = ingested
+	}
 }
 
 func (b *CrawlBudget) LogStatus() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	log.Printf("[BUDGET] Crawl Status: %d / %d allowed this hour. Cache size: %d", b.hourlyIngested, b.maxHourlyIngests, len(b.seenCache))
+
+	cacheSize := len(b.seenCache)
+	if b.bloom != nil {
+		cacheSize = b.bloom.count
+	}
+	log.Printf("[BUDGET] Crawl Status: %d / %d allowed this hour. Cache size: %d (persistent: %v)", b.hourlyIngested, b.maxHourlyIngests, cacheSize, b.store != nil)
 }
 
 // UpdateClusterStats persists clustering stats to DB
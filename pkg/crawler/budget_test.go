@@ -0,0 +1,267 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memSeenStore is a seenStore kept in memory, with the expiry clock under
+// the test's control.
+type memSeenStore struct {
+	mu       sync.Mutex
+	now      time.Time
+	expires  map[string]time.Time
+	ingested int
+	claims   int
+	lookups  int
+	claimErr error
+	eachErr  error
+	eachRuns int
+}
+
+func newMemSeenStore() *memSeenStore {
+	return &memSeenStore{now: time.Now(), expires: make(map[string]time.Time)}
+}
+
+func (s *memSeenStore) Seen(_ context.Context, url string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups++
+	exp, ok := s.expires[url]
+	return ok && exp.After(s.now), nil
+}
+
+func (s *memSeenStore) Claim(_ context.Context, url string, ttl time.Duration, maxHourly int) (bool, bool, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.claims++
+	if s.claimErr != nil {
+		return false, false, 0, s.claimErr
+	}
+	if exp, ok := s.expires[url]; ok && exp.After(s.now) {
+		return false, true, 0, nil
+	}
+	if s.ingested >= maxHourly {
+		return false, false, maxHourly, nil
+	}
+	s.expires[url] = s.now.Add(ttl)
+	s.ingested++
+	return true, false, s.ingested, nil
+}
+
+func (s *memSeenStore) EachSeen(_ context.Context, limit int, fn func(url string)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eachRuns++
+	if s.eachErr != nil {
+		return s.eachErr
+	}
+	for url, exp := range s.expires {
+		if limit <= 0 {
+			break
+		}
+		if exp.After(s.now) {
+			fn(url)
+			limit--
+		}
+	}
+	return nil
+}
+
+func (s *memSeenStore) HourlyIngested(context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ingested, nil
+}
+
+func (s *memSeenStore) Cleanup(context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for url, exp := range s.expires {
+		if !exp.After(s.now) {
+			delete(s.expires, url)
+		}
+	}
+	s.ingested = 0 // a new hourly window
+}
+
+func newStoreBudget(maxHourly int, store *memSeenStore) *CrawlBudget {
+	b := NewCrawlBudget(maxHourly, NewMetrics(nil), nil)
+	b.store = store
+	return b
+}
+
+func TestCrawlBudget_PersistentDedup(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	b := newStoreBudget(10, store)
+
+	if !b.Consume(ctx, "https://a.example/1") {
+		t.Fatal("first sighting was rejected")
+	}
+	if b.Consume(ctx, "https://a.example/1") {
+		t.Error("repeat URL was accepted")
+	}
+	// Another instance sharing the store has already claimed this one.
+	store.expires["https://a.example/2"] = store.now.Add(time.Hour)
+	if b.Consume(ctx, "https://a.example/2") {
+		t.Error("URL claimed by another instance was accepted")
+	}
+	if b.hourlyIngested != 1 {
+		t.Errorf("hourlyIngested = %d, want 1", b.hourlyIngested)
+	}
+}
+
+func TestCrawlBudget_PersistentHourlyLimit(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	b := newStoreBudget(2, store)
+
+	for i, want := range []bool{true, true, false} {
+		if got := b.Consume(ctx, fmt.Sprintf("https://a.example/%d", i)); got != want {
+			t.Errorf("Consume #%d = %v, want %v", i, got, want)
+		}
+	}
+	if _, ok := store.expires["https://a.example/2"]; ok {
+		t.Error("URL refused by the hourly limit was marked seen")
+	}
+}
+
+func TestCrawlBudget_ClaimErrorFallsBackToMemory(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	store.claimErr = errors.New("connection refused")
+	b := newStoreBudget(10, store)
+
+	if !b.Consume(ctx, "https://a.example/1") {
+		t.Fatal("first sighting was rejected")
+	}
+	if b.Consume(ctx, "https://a.example/1") {
+		t.Error("repeat URL was accepted by the in-memory fallback")
+	}
+}
+
+func TestCrawlBudget_BloomHitIsConfirmed(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	b := newStoreBudget(10, store)
+	b.EnableBloomFilter(100, 0.01)
+
+	if !b.Consume(ctx, "https://a.example/1") {
+		t.Fatal("first sighting was rejected")
+	}
+	claims := store.claims
+	if b.Consume(ctx, "https://a.example/1") {
+		t.Error("repeat URL was accepted")
+	}
+	if store.claims != claims || store.lookups != 1 {
+		t.Errorf("claims %d lookups %d, want a lookup instead of a claim", store.claims-claims, store.lookups)
+	}
+
+	// The filter says maybe, the database says no: a false positive, or an
+	// entry another instance expired. The URL must still be crawled.
+	b.bloom.Add("https://a.example/false-positive")
+	if !b.Consume(ctx, "https://a.example/false-positive") {
+		t.Error("Bloom filter hit rejected a URL the database has not seen")
+	}
+}
+
+func TestCrawlBudget_CleanupRebuildsBloom(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	b := newStoreBudget(10, store)
+	b.EnableBloomFilter(100, 0.01)
+
+	store.expires["https://a.example/old"] = store.now.Add(time.Minute)
+	store.expires["https://a.example/live"] = store.now.Add(48 * time.Hour)
+	if b.Consume(ctx, "https://a.example/live") {
+		t.Fatal("seen URL was accepted")
+	}
+	if !b.bloom.Test("https://a.example/old") {
+		t.Fatal("Bloom filter was not warmed from the store")
+	}
+
+	store.now = store.now.Add(2 * time.Hour)
+	b.lastReset = b.lastReset.Add(-2 * time.Hour)
+	if !b.Consume(ctx, "https://a.example/old") {
+		t.Error("expired URL was rejected after cleanup")
+	}
+	if !b.bloom.Test("https://a.example/live") {
+		t.Error("rebuilt Bloom filter lost an unexpired URL")
+	}
+	if _, ok := store.expires["https://a.example/old"]; !ok {
+		t.Error("expired URL was not claimed again")
+	}
+}
+
+func TestCrawlBudget_FailedWarmupIsRetried(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	store.eachErr = errors.New("connection reset")
+	b := newStoreBudget(10, store)
+	b.EnableBloomFilter(100, 0.01)
+
+	store.expires["https://a.example/old"] = store.now.Add(time.Hour)
+	if b.Consume(ctx, "https://a.example/old") {
+		t.Error("seen URL was accepted")
+	}
+	if b.bloomLoaded {
+		t.Fatal("Bloom filter marked loaded after a failed warm-up")
+	}
+
+	store.eachErr = nil
+	b.Consume(ctx, "https://a.example/new")
+	if store.eachRuns != 2 || !b.bloomLoaded || !b.bloom.Test("https://a.example/old") {
+		t.Errorf("warm-up ran %d times, loaded %v; want a successful retry", store.eachRuns, b.bloomLoaded)
+	}
+	b.Consume(ctx, "https://a.example/newer")
+	if store.eachRuns != 2 {
+		t.Errorf("warm-up ran %d times, want no rebuild once loaded", store.eachRuns)
+	}
+}
+
+func TestCrawlBudget_DisableBloomFilter(t *testing.T) {
+	ctx := context.Background()
+	store := newMemSeenStore()
+	b := newStoreBudget(10, store)
+	b.EnableBloomFilter(100, 0.01)
+	b.DisableBloomFilter()
+
+	if !b.Consume(ctx, "https://a.example/1") || b.Consume(ctx, "https://a.example/1") {
+		t.Error("dedup without the Bloom filter is wrong")
+	}
+	if store.eachRuns != 0 {
+		t.Errorf("warm-up ran %d times with the filter disabled", store.eachRuns)
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	f := newBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Add(fmt.Sprintf("https://a.example/%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.Test(fmt.Sprintf("https://a.example/%d", i)) {
+			t.Fatalf("false negative for item %d", i)
+		}
+	}
+
+	fp := 0
+	for i := 0; i < 10000; i++ {
+		if f.Test(fmt.Sprintf("https://b.example/%d", i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / 10000; rate > 0.03 {
+		t.Errorf("false positive rate %.3f, want about 0.01", rate)
+	}
+
+	f.Reset()
+	if f.Test("https://a.example/1") || f.count != 0 {
+		t.Error("Reset left items behind")
+	}
+}
@@ -0,0 +1,168 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+)
+
+// enqueueEnrichment schedules the enrichment jobs for a freshly stored item.
+func (o *Orchestrator) enqueueEnrichment(ctx context.Context, item *models.Item, art Article) error {
+	payload := jobs.ItemPayload{
+		ItemID:  item.ID,
+		Title:   art.Title,
+		Content: art.Content,
+		URL:     item.URL,
+	}
+
+	var types []string
+	if o.clusterService != nil {
+		types = append(types, jobs.TypeCluster)
+	}
+	if o.summarizer != nil {
+		types = append(types, jobs.TypeSummarize)
+	}
+	if o.aiService != nil {
+		types = append(types, jobs.TypeEmbed)
+	}
+	if entities.LLMConfigured(o.cfg) {
+		types = append(types, jobs.TypeEntities)
+	}
+
+	for _, t := range types {
+		if err := o.queue.EnqueueItem(ctx, t, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterJobHandlers wires the orchestrator's AI services into runner as
+// enrichment job handlers. Types whose service is not configured are left
+// unregistered so their jobs wait for a runner that can process them.
+func (o *Orchestrator) RegisterJobHandlers(runner *jobs.Runner) {
+	if o.database == nil {
+		return
+	}
+
+	if o.summarizer != nil {
+		runner.Register(jobs.TypeSummarize, o.handleSummarize)
+		if o.cfg.SummaryLanguage != "" {
+			runner.Register(jobs.TypeTranslate, o.handleTranslate)
+		}
+	}
+	if o.aiService != nil {
+		runner.Register(jobs.TypeEmbed, o.handleEmbed)
+	}
+	if o.clusterService != nil {
+		runner.Register(jobs.TypeCluster, o.handleCluster)
+	}
+	if o.entities != nil && o.entities.LLMEnabled() {
+		runner.Register(jobs.TypeEntities, o.handleEntities)
+	}
+	runner.Register(jobs.TypeScore, o.handleScore)
+}
+
+func (o *Orchestrator) handleSummarize(ctx context.Context, job models.Job) error {
+	p, err := jobs.DecodeItemPayload(job)
+	if err != nil {
+		return err
+	}
+	if err := o.summarizer.Process(ctx, p.ItemID, p.Title, p.Content); err != nil {
+		return err
+	}
+	if o.cfg.SummaryLanguage != "" {
+		if err := o.queue.EnqueueItem(ctx, jobs.TypeTranslate, jobs.ItemPayload{ItemID: p.ItemID}); err != nil {
+			return err
+		}
+	}
+	// Fold the fresh LLM components into the final score.
+	return o.queue.EnqueueItem(ctx, jobs.TypeScore, jobs.ItemPayload{ItemID: p.ItemID, Title: p.Title})
+}
+
+func (o *Orchestrator) handleTranslate(ctx context.Context, job models.Job) error {
+	p, err := jobs.DecodeItemPayload(job)
+	if err != nil {
+		return err
+	}
+	return o.summarizer.Translate(ctx, p.ItemID, o.cfg.SummaryLanguage)
+}
+
+func (o *Orchestrator) handleEmbed(ctx context.Context, job models.Job) error {
+	p, err := jobs.DecodeItemPayload(job)
+	if err != nil {
+		return err
+	}
+
+	// Idempotency check: see if we already have an embedding for this article
+	hasEmbed, err := o.itemRepo.HasEmbedding(ctx, p.ItemID)
+	if err != nil {
+		return fmt.Errorf("embedding idempotency check failed: %w", err)
+	}
+	if hasEmbed {
+		return nil
+	}
+
+	// Build text to embed: Title + 512 chars of Content
+	embedText := p.Title + ". "
+	contentSnip := p.Content
+	if len(contentSnip) > 512 {
+		contentSnip = contentSnip[:512]
+	}
+	embedText += contentSnip
+
+	embedResp, err := o.aiService.Embed(ctx, ai.EmbeddingRequest{Input: embedText})
+	if err != nil {
+		return fmt.Errorf("embedding generation failed: %w", err)
+	}
+	return o.itemRepo.UpsertEmbedding(ctx, p.ItemID, embedResp.Embedding, embedResp.Model)
+}
+
+func (o *Orchestrator) handleCluster(ctx context.Context, job models.Job) error {
+	p, err := jobs.DecodeItemPayload(job)
+	if err != nil {
+		return err
+	}
+	return o.clusterService.ProcessArticle(ctx, p.ItemID, p.Title, p.Content, p.URL)
+}
+
+func (o *Orchestrator) handleEntities(ctx context.Context, job models.Job) error {
+	p, err := jobs.DecodeItemPayload(job)
+	if err != nil {
+		return err
+	}
+	return o.entities.Process(ctx, p.ItemID, p.Title, p.Content)
+}
+
+func (o *Orchestrator) handleScore(ctx context.Context, job models.Job) error {
+	p, err := jobs.DecodeItemPayload(job)
+	if err != nil {
+		return err
+	}
+	return ScoreItem(ctx, o.database, p.ItemID)
+}
+
+// ScoreItem recomputes and stores the score of a single item.
+func ScoreItem(ctx context.Context, database *db.DB, itemID uuid.UUID) error {
+	itemRepo := db.NewItemRepository(database)
+	scoreRepo := db.NewScoreRepository(database)
+
+	item, err := itemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to load item: %w", err)
+	}
+	signal, _ := db.NewSignalRepository(database).GetLatestByItemID(ctx, itemID)
+	summary, _ := db.NewSummaryRepository(database).GetByItemID(ctx, itemID)
+	existingScore, _ := scoreRepo.GetByItemID(ctx, itemID)
+	coverage, _ := itemRepo.GetCoverage(ctx, itemID)
+
+	score := scoring.ComputeScoreWithConfig(item, signal, summary, existingScore, coverage, scoring.Current())
+	return scoreRepo.Record(ctx, score)
+}
@@ -0,0 +1,73 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/ingest"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// agentSourceType marks sources that are fed by discovery agents rather than
+// by the connector worker.
+const agentSourceType = "agent"
+
+// agentSourceURL is the synthetic, unique URL under which an agent's source row
+// is registered.
+func agentSourceURL(agentName string) string {
+	return "agent://" + strings.ToLower(agentName)
+}
+
+// sourceForAgent returns the source row that owns items discovered by agent,
+// creating it on first use. Agent sources are stored disabled so the
+// connector worker does not try to fetch them.
+func (o *Orchestrator) sourceForAgent(ctx context.Context, agentName string) (uuid.UUID, error) {
+	o.sourceMu.Lock()
+	defer o.sourceMu.Unlock()
+
+	if id, ok := o.agentSources[agentName]; ok {
+		return id, nil
+	}
+
+	srcURL := agentSourceURL(agentName)
+	source, err := o.sourceRepo.GetByURL(ctx, srcURL)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up agent source: %w", err)
+	}
+	if source == nil {
+		source = &models.Source{
+			Name:     agentName,
+			Type:     agentSourceType,
+			Category: "news",
+			URL:      srcURL,
+			Enabled:  false,
+			Status:   "active",
+		}
+		if err := o.sourceRepo.Create(ctx, source); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to create agent source: %w", err)
+		}
+	}
+
+	o.agentSources[agentName] = source.ID
+	return source.ID, nil
+}
+
+// persistArticle stores art as an item of the agent's source through the
+// ingester the connector worker uses too. created is false when the item
+// already exists; the existing item is returned in that case.
+func (o *Orchestrator) persistArticle(ctx context.Context, agentName string, art Article) (item *models.Item, created bool, err error) {
+	sourceID, err := o.sourceForAgent(ctx, agentName)
+	if err != nil {
+		return nil, false, err
+	}
+	return o.ingester.Store(ctx, ingest.Candidate{
+		SourceID:    sourceID,
+		URL:         art.Link,
+		Title:       art.Title,
+		Excerpt:     art.Content,
+		Category:    "news",
+		PublishedAt: art.PublishedAt,
+	})
+}
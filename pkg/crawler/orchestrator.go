@@ -5,12 +5,26 @@ import (
 	"crypto/rand"
 	"log"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
 	"github.com/hidatara-ds/evolipia-radar/pkg/cluster"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
+	"github.com/hidatara-ds/evolipia-radar/pkg/ingest"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultAgentTimeout = 20 * time.Second
+	defaultMaxJitter    = 10 * time.Second
+
+	pipelineWorkers = 3  // concurrent article processors
+	pipelineBuffer  = 32 // discovered articles waiting for a processor
 )
 
 // Orchestrator manages the crawling lifecycle and agents.
@@ -22,16 +36,36 @@ type Orchestrator struct {
 	aiService       *ai.Service // Added for embeddings
 	database        *db.DB      // Kept strictly for passing to agents & ItemRepository
 	DryRun          bool
+	AgentTimeout    time.Duration // per-agent crawl deadline
+	MaxJitter       time.Duration // upper bound on the random delay before each agent
 	metrics         *Metrics
 	summarizer      *Summarizer
+	cfg             *config.Config // canonical URL, near-dup and translation settings
+
+	itemRepo   *db.ItemRepository
+	sourceRepo *db.SourceRepository
+	agentRepo  *db.AgentConfigRepository
+	queue      *jobs.Queue
+	ingester   *ingest.Ingester
+	entities   *entities.Extractor
+
+	sourceMu     sync.Mutex
+	agentSources map[string]uuid.UUID // agent name -> owning source ID
 }
 
 // NewOrchestrator wires together all agents and binds them to the AI clustering brain.
 func NewOrchestrator(clusterSvc *ai.ClusterService, inMemSvc *cluster.Service, aiSvc *ai.Service, metrics *Metrics, database *db.DB, dryRun bool, summarizer *Summarizer) *Orchestrator {
 	// Initialize with strict zero-cost budget: 50 requests per hour max
-	budget := NewCrawlBudget(50, metrics, database.Pool)
+	var pool *pgxpool.Pool
+	if database != nil {
+		pool = database.Pool
+	}
+	budget := NewCrawlBudget(50, metrics, pool)
+	if pool != nil {
+		budget.EnableBloomFilter(50000, 0.001)
+	}
 
-	return &Orchestrator{
+	o := &Orchestrator{
 		agents: []DiscoveryAgent{
 			NewRSSAgent(),
 			NewTrendingAgent(),
@@ -45,9 +79,29 @@ func NewOrchestrator(clusterSvc *ai.ClusterService, inMemSvc *cluster.Service, a
 		aiService:       aiSvc,
 		database:        database,
 		DryRun:          dryRun,
+		AgentTimeout:    defaultAgentTimeout,
+		MaxJitter:       defaultMaxJitter,
 		metrics:         metrics,
 		summarizer:      summarizer,
+		cfg:             config.Load(),
+		agentSources:    make(map[string]uuid.UUID),
 	}
+	if database != nil {
+		o.itemRepo = db.NewItemRepository(database)
+		o.sourceRepo = db.NewSourceRepository(database)
+		o.agentRepo = db.NewAgentConfigRepository(database)
+		o.queue = jobs.NewQueue(database)
+		o.entities = entities.NewExtractor(database, o.cfg, aiSvc)
+		o.ingester = ingest.NewIngester(database, o.cfg, o.entities)
+	}
+	return o
+}
+
+// DisableBloomFilter turns off the crawl budget's Bloom filter, whose
+// warm-up reads crawl_seen. Short-lived instances such as the serverless
+// trigger call it; their few lookups are cheaper than the warm-up.
+func (o *Orchestrator) DisableBloomFilter() {
+	o.budget.DisableBloomFilter()
 }
 
 // Start begins a blocking loop that triggers Discovery on an interval.
@@ -72,104 +126,229 @@ func (o *Orchestrator) Start(ctx context.Context, interval time.Duration) {
 	}
 }
 
-// RunCycle executes one pass of all Discovery agents.
+// RunCycle executes one pass of all Discovery agents. Agents crawl
+// concurrently, each under its own deadline, and feed a bounded pool of
+// processors. If ctx is cancelled mid-cycle the stats gathered so far are
+// returned with "cancelled" set to 1.
 func (o *Orchestrator) RunCycle(ctx context.Context) map[string]int {
 	log.Printf("[ORCHESTRATOR] Beginning Discovery Cycle (DryRun: %v)", o.DryRun)
-	o.budget.LogStatus()
 
-	stats := map[string]int{
-		"discovered": 0,
-		"accepted":   0,
-		"rejected":   0,
+	// Only one process (API loop, Vercel trigger, ...) may run discovery at a time.
+	if o.database != nil {
+		unlock, acquired, err := o.database.TryLock(ctx, db.LockDiscoveryCycle)
+		if err != nil {
+			log.Printf("[ORCHESTRATOR] Could not take discovery lock, running unguarded: %v", err)
+		} else if !acquired {
+			log.Println("[ORCHESTRATOR] Discovery cycle already running on another instance, skipping")
+			return map[string]int{"skipped": 1}
+		} else {
+			defer unlock()
+		}
 	}
 
-	for _, agent := range o.agents {
-		// Phase 3.5: Agent Jitter (0-10 seconds)
-		n, _ := rand.Int(rand.Reader, big.NewInt(10))
-		jitter := time.Duration(n.Int64()) * time.Second
-		log.Printf("[ORCHESTRATOR] Applying jitter %v before dispatching %s...", jitter, agent.Name())
-		time.Sleep(jitter)
+	o.budget.LogStatus()
 
-		// Limit each agent to fetching up to 10 candidates to inspect
-		articles, err := agent.Crawl(ctx, 10)
-		if err != nil {
-			log.Printf("[ORCHESTRATOR] Agent %s failed: %v", agent.Name(), err)
-			continue
-		}
+	stats := newCycleStats("discovered", "accepted", "rejected", "persisted", "duplicates", "failed")
 
-		stats["discovered"] += len(articles)
+	type discovered struct {
+		agent string
+		art   Article
+	}
+	queue := make(chan discovered, pipelineBuffer)
 
-		for _, art := range articles {
-			// 1. Budget & Deduplication Check (Fast rejection)
-			if !o.budget.Consume(ctx, art.Link) {
-				stats["rejected"]++
-				continue // Skip if already seen or over hourly limit
-			}
+	// Stored agent configs win; the built-in set only runs when none exist.
+	agents := o.loadAgents(ctx)
+	if agents == nil {
+		agents = o.agents
+	}
 
-			stats["accepted"]++
+	var producers sync.WaitGroup
+	for _, agent := range agents {
+		producers.Add(1)
+		go func(agent DiscoveryAgent) {
+			defer producers.Done()
 
-			// Phase 3.5: DRY RUN Mode
-			if o.DryRun {
-				log.Printf("[DRY-RUN] Discovered: %s | Source: %s", art.Title, art.Source)
-				continue // Bypass cluster ingestion
+			articles, err := o.crawlAgent(ctx, agent)
+			if err != nil {
+				log.Printf("[ORCHESTRATOR] Agent %s failed: %v", agent.Name(), err)
+				stats.addAgent(agent.Name(), "errors", 1)
+				return
 			}
 
-			// Phase 5: Fast In-Memory Clustering Routing
-			if o.inMemClusterSvc != nil {
-				err := o.inMemClusterSvc.ProcessArticle(ctx, art.Title, art.Content, art.Link)
-				if err != nil {
-					log.Printf("[ORCHESTRATOR] In-Memory Clustering failed for %s: %v", art.Link, err)
+			stats.add("discovered", len(articles))
+			stats.addAgent(agent.Name(), "discovered", len(articles))
+
+			for _, art := range articles {
+				select {
+				case queue <- discovered{agent: agent.Name(), art: art}:
+				case <-ctx.Done():
+					return
 				}
 			}
+		}(agent)
+	}
 
-			// Generate a fake article ID for ingestion since URLs are our real primary keys here
-			artID := uuid.New()
-
-			// 2. Feed into the Persistence AI Cluster Engine (If not dry run)
-			if o.clusterService != nil && !o.DryRun {
-				err := o.clusterService.ProcessArticle(ctx, artID, art.Title, art.Content, art.Link)
-				if err != nil {
-					log.Printf("[ORCHESTRATOR] Cluster pipeline failed for article %s: %v", art.Link, err)
-				}
+	go func() {
+		producers.Wait()
+		close(queue)
+	}()
 
-				// Phase 4: Dynamic AI Summarization
-				if o.summarizer != nil {
-					_ = o.summarizer.Process(ctx, artID, art.Title, art.Content)
+	var consumers sync.WaitGroup
+	for i := 0; i < pipelineWorkers; i++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for d := range queue {
+				if ctx.Err() != nil {
+					continue // Drain so producers never block on a dead pipeline
 				}
+				o.processArticle(ctx, d.agent, d.art, stats)
 			}
+		}()
+	}
+	consumers.Wait()
 
-			// 3. Generate and store semantic embedding (If not dry run)
-			if o.aiService != nil && o.database != nil && !o.DryRun {
-				itemRepo := db.NewItemRepository(o.database)
-
-				// Idempotency check: see if we already have an embedding for this article
-				hasEmbed, checkErr := itemRepo.HasEmbedding(ctx, artID)
-				if checkErr != nil {
-					log.Printf("[ORCHESTRATOR] Embedding idempotency check failed for %s: %v", art.Link, checkErr)
-				} else if !hasEmbed {
-					// Build text to embed: Title + 512 chars of Content
-					embedText := art.Title + ". "
-					contentSnip := art.Content
-					if len(contentSnip) > 512 {
-						contentSnip = contentSnip[:512]
-					}
-					embedText += contentSnip
-
-					embedResp, embedErr := o.aiService.Embed(ctx, ai.EmbeddingRequest{Input: embedText})
-					if embedErr != nil {
-						log.Printf("[ORCHESTRATOR] Embedding generation skipped/failed for %s: %v", art.Link, embedErr)
-					} else {
-						upsertErr := itemRepo.UpsertEmbedding(ctx, artID, embedResp.Embedding, embedResp.Model)
-						if upsertErr != nil {
-							log.Printf("[ORCHESTRATOR] Embedding save failed for %s: %v", art.Link, upsertErr)
-						}
-					}
-				}
+	result := stats.snapshot()
+	if ctx.Err() != nil {
+		result["cancelled"] = 1
+		log.Printf("[ORCHESTRATOR] Discovery cycle cancelled (%v), returning partial stats", ctx.Err())
+	}
+	return result
+}
+
+// crawlAgent waits a context-aware jitter and runs agent under its own
+// deadline so one slow upstream cannot stall the whole cycle.
+func (o *Orchestrator) crawlAgent(ctx context.Context, agent DiscoveryAgent) ([]Article, error) {
+	timeout := o.AgentTimeout
+	if timeout <= 0 {
+		timeout = defaultAgentTimeout
+	}
+
+	// Phase 3.5: Agent Jitter, capped to a fraction of the remaining budget
+	maxJitter := o.MaxJitter
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) / 4; remaining < maxJitter {
+			maxJitter = remaining
+		}
+	}
+	if maxJitter > 0 {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(maxJitter)))
+		jitter := time.Duration(n.Int64())
+		log.Printf("[ORCHESTRATOR] Applying jitter %v before dispatching %s...", jitter.Round(time.Millisecond), agent.Name())
+
+		timer := time.NewTimer(jitter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	agentCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Limit each agent to fetching up to 10 candidates to inspect unless configured otherwise
+	maxItems := 10
+	if ca, ok := agent.(*configuredAgent); ok {
+		maxItems = ca.maxItems()
+		if o.agentRepo != nil {
+			if err := o.agentRepo.MarkRun(ctx, ca.cfg.ID, time.Now()); err != nil {
+				log.Printf("[ORCHESTRATOR] Failed to record run for %s: %v", agent.Name(), err)
 			}
 		}
 	}
+	return agent.Crawl(agentCtx, maxItems)
+}
+
+// processArticle runs budget checks, persistence and enrichment for one article.
+func (o *Orchestrator) processArticle(ctx context.Context, agentName string, art Article, stats *cycleStats) {
+	// 1. Budget & Deduplication Check (Fast rejection)
+	if !o.budget.Consume(ctx, art.Link) {
+		stats.inc(agentName, "rejected")
+		return // Skip if already seen or over hourly limit
+	}
+
+	stats.inc(agentName, "accepted")
+
+	// Phase 3.5: DRY RUN Mode
+	if o.DryRun {
+		log.Printf("[DRY-RUN] Discovered: %s | Source: %s", art.Title, art.Source)
+		return // Bypass cluster ingestion
+	}
+
+	// Phase 5: Fast In-Memory Clustering Routing
+	if o.inMemClusterSvc != nil {
+		err := o.inMemClusterSvc.ProcessArticle(ctx, art.Title, art.Content, art.Link)
+		if err != nil {
+			log.Printf("[ORCHESTRATOR] In-Memory Clustering failed for %s: %v", art.Link, err)
+		}
+	}
+
+	if o.database == nil {
+		return // Nothing to persist or enrich against
+	}
 
-	return stats
+	// 2. Normalize, dedup and store as a real item so enrichment has a valid FK target
+	item, created, err := o.persistArticle(ctx, agentName, art)
+	if err != nil {
+		log.Printf("[ORCHESTRATOR] Failed to persist article %s: %v", art.Link, err)
+		stats.inc(agentName, "failed")
+		return
+	}
+	if !created {
+		stats.inc(agentName, "duplicates")
+		return // Already ingested (and enriched) by an earlier run or the worker
+	}
+	stats.inc(agentName, "persisted")
+
+	// 3. Hand clustering, summarization and embedding to the durable job
+	// queue so slow or failing AI calls are retried instead of lost.
+	if err := o.enqueueEnrichment(ctx, item, art); err != nil {
+		log.Printf("[ORCHESTRATOR] Failed to enqueue enrichment for %s: %v", art.Link, err)
+		stats.inc(agentName, "failed")
+	}
+}
+
+// cycleStats collects counters from concurrent agents and processors.
+type cycleStats struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newCycleStats(keys ...string) *cycleStats {
+	counts := make(map[string]int, len(keys))
+	for _, k := range keys {
+		counts[k] = 0
+	}
+	return &cycleStats{counts: counts}
+}
+
+func (s *cycleStats) add(key string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[key] += n
+}
+
+// addAgent records a per-agent counter under "<agent>.<key>".
+func (s *cycleStats) addAgent(agent, key string, n int) {
+	s.add(agent+"."+key, n)
+}
+
+// inc bumps both the cycle total and the agent's own counter.
+func (s *cycleStats) inc(agent, key string) {
+	s.add(key, 1)
+	s.addAgent(agent, key, 1)
+}
+
+func (s *cycleStats) snapshot() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]int, len(s.counts)+1)
+	for k, v := range s.counts {
+		out[k] = v
+	}
+	return out
 }
 
 // UpdateClusterMetrics fetches DB stats for the /metrics endpoint
@@ -0,0 +1,93 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeAgent struct {
+	name  string
+	delay time.Duration
+	count int
+	err   error
+}
+
+func (a *fakeAgent) Name() string { return a.name }
+
+func (a *fakeAgent) Crawl(ctx context.Context, maxItems int) ([]Article, error) {
+	select {
+	case <-time.After(a.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if a.err != nil {
+		return nil, a.err
+	}
+	var out []Article
+	for i := 0; i < a.count; i++ {
+		out = append(out, Article{Title: fmt.Sprintf("%s %d", a.name, i), Link: fmt.Sprintf("https://%s.example/%d", a.name, i)})
+	}
+	return out, nil
+}
+
+func newTestOrchestrator(agents ...DiscoveryAgent) *Orchestrator {
+	metrics := NewMetrics(nil)
+	return &Orchestrator{
+		agents:       agents,
+		budget:       NewCrawlBudget(50, metrics, nil),
+		metrics:      metrics,
+		DryRun:       true,
+		AgentTimeout: 200 * time.Millisecond,
+	}
+}
+
+func TestRunCycle_PerAgentStats(t *testing.T) {
+	o := newTestOrchestrator(
+		&fakeAgent{name: "a", count: 3},
+		&fakeAgent{name: "b", count: 2},
+		&fakeAgent{name: "broken", err: errors.New("boom")},
+		&fakeAgent{name: "slow", delay: time.Second, count: 5},
+	)
+
+	stats := o.RunCycle(context.Background())
+
+	if stats["discovered"] != 5 || stats["accepted"] != 5 {
+		t.Errorf("totals = %v", stats)
+	}
+	if stats["a.accepted"] != 3 || stats["b.discovered"] != 2 {
+		t.Errorf("per-agent stats = %v", stats)
+	}
+	if stats["broken.errors"] != 1 || stats["slow.errors"] != 1 {
+		t.Errorf("expected failing and timed-out agents to be reported, got %v", stats)
+	}
+	if _, ok := stats["cancelled"]; ok {
+		t.Errorf("cycle should not be marked cancelled: %v", stats)
+	}
+}
+
+func TestRunCycle_CancelledReturnsPartialStats(t *testing.T) {
+	o := newTestOrchestrator(
+		&fakeAgent{name: "fast", count: 2},
+		&fakeAgent{name: "slow", delay: time.Second, count: 5},
+	)
+	o.AgentTimeout = 5 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	stats := o.RunCycle(ctx)
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("RunCycle did not honour cancellation, took %v", elapsed)
+	}
+	if stats["cancelled"] != 1 {
+		t.Errorf("expected cancelled flag, got %v", stats)
+	}
+	if stats["fast.discovered"] != 2 {
+		t.Errorf("expected partial stats from fast agent, got %v", stats)
+	}
+}
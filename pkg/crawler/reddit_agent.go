@@ -6,15 +6,24 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 type RedditAgent struct {
 	Subreddits []string
+	client     *http.Client
 }
 
-func NewRedditAgent() *RedditAgent {
+// NewRedditAgent creates an agent for subreddits, defaulting to a small set of
+// tech communities when none are given.
+func NewRedditAgent(subreddits ...string) *RedditAgent {
+	if len(subreddits) == 0 {
+		subreddits = []string{"technology", "artificialintelligence", "programming"}
+	}
 	return &RedditAgent{
-		Subreddits: []string{"technology", "artificialintelligence", "programming"},
+		Subreddits: subreddits,
+		client:     fetch.NewClient(10 * time.Second),
 	}
 }
 
@@ -34,11 +43,10 @@ func (a *RedditAgent) Crawl(ctx context.Context, maxItems int) ([]Article, error
 		}
 		req.Header.Set("User-Agent", "EvolipiaRadar/1.0")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := a.client.Do(req)
 		if err != nil {
 			continue
 		}
-		defer resp.Body.Close()
 
 		var data struct {
 			Data struct {
@@ -55,7 +63,9 @@ func (a *RedditAgent) Crawl(ctx context.Context, maxItems int) ([]Article, error
 			} `json:"data"`
 		}
 
-		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		resp.Body.Close()
+		if err != nil {
 			continue
 		}
 
@@ -0,0 +1,167 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/robfig/cron/v3"
+)
+
+const maxAgentItems = 100
+
+// AgentFactory builds a DiscoveryAgent from its stored configuration.
+type AgentFactory func(cfg models.AgentConfig, database *db.DB) (DiscoveryAgent, error)
+
+var (
+	registryMu     sync.RWMutex
+	agentFactories = map[string]AgentFactory{
+		"rss": func(cfg models.AgentConfig, _ *db.DB) (DiscoveryAgent, error) {
+			return NewRSSAgent(cfg.Params.Feeds...), nil
+		},
+		"trending": func(models.AgentConfig, *db.DB) (DiscoveryAgent, error) {
+			return NewTrendingAgent(), nil
+		},
+		"reddit": func(cfg models.AgentConfig, _ *db.DB) (DiscoveryAgent, error) {
+			return NewRedditAgent(cfg.Params.Subreddits...), nil
+		},
+		"social": func(cfg models.AgentConfig, database *db.DB) (DiscoveryAgent, error) {
+			if database == nil {
+				return nil, fmt.Errorf("social agent requires a database for API keys")
+			}
+			agent := NewSocialAgent(cfg.Params.Platform, database)
+			agent.Query = cfg.Params.Query
+			return agent, nil
+		},
+	}
+)
+
+// RegisterAgentType makes a new agent type available to stored configs.
+func RegisterAgentType(agentType string, factory AgentFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	agentFactories[agentType] = factory
+}
+
+// AgentTypes lists the registered agent types.
+func AgentTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]string, 0, len(agentFactories))
+	for t := range agentFactories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ValidateAgentConfig checks cfg against the registry and its type's requirements.
+func ValidateAgentConfig(cfg models.AgentConfig) error {
+	if strings.TrimSpace(cfg.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	registryMu.RLock()
+	_, ok := agentFactories[cfg.Type]
+	registryMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown agent type %q (known: %s)", cfg.Type, strings.Join(AgentTypes(), ", "))
+	}
+
+	if cfg.MaxItems < 1 || cfg.MaxItems > maxAgentItems {
+		return fmt.Errorf("max_items must be between 1 and %d", maxAgentItems)
+	}
+	if cfg.Schedule != "" {
+		if _, err := cron.ParseStandard(cfg.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+	}
+	if cfg.Type == "social" && cfg.Params.Platform == "" {
+		return fmt.Errorf("social agents require params.platform")
+	}
+	return nil
+}
+
+// BuildAgent instantiates the agent described by cfg.
+func BuildAgent(cfg models.AgentConfig, database *db.DB) (DiscoveryAgent, error) {
+	if err := ValidateAgentConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	registryMu.RLock()
+	factory := agentFactories[cfg.Type]
+	registryMu.RUnlock()
+
+	agent, err := factory(cfg, database)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &configuredAgent{DiscoveryAgent: agent, cfg: cfg}
+	if cfg.Schedule != "" {
+		ca.schedule, _ = cron.ParseStandard(cfg.Schedule)
+	}
+	return ca, nil
+}
+
+// configuredAgent decorates an agent with its stored name, item cap and schedule.
+type configuredAgent struct {
+	DiscoveryAgent
+	cfg      models.AgentConfig
+	schedule cron.Schedule
+}
+
+func (a *configuredAgent) Name() string { return a.cfg.Name }
+
+func (a *configuredAgent) maxItems() int { return a.cfg.MaxItems }
+
+// due reports whether the agent's schedule has fired since its last run.
+func (a *configuredAgent) due(now time.Time) bool {
+	if a.schedule == nil || a.cfg.LastRunAt == nil {
+		return true
+	}
+	return !a.schedule.Next(*a.cfg.LastRunAt).After(now)
+}
+
+// loadAgents builds the enabled, due agents from agent_configs. It returns nil
+// when no database is configured or no agents are stored, so callers can fall
+// back to the built-in defaults.
+func (o *Orchestrator) loadAgents(ctx context.Context) []DiscoveryAgent {
+	if o.agentRepo == nil {
+		return nil
+	}
+
+	configs, err := o.agentRepo.List(ctx)
+	if err != nil {
+		log.Printf("[ORCHESTRATOR] Failed to load agent configs, using defaults: %v", err)
+		return nil
+	}
+
+	now := time.Now()
+	agents := make([]DiscoveryAgent, 0, len(configs))
+	for _, cfg := range configs {
+		if !cfg.Enabled {
+			continue
+		}
+		agent, err := BuildAgent(cfg, o.database)
+		if err != nil {
+			log.Printf("[ORCHESTRATOR] Skipping agent %s: %v", cfg.Name, err)
+			continue
+		}
+		if ca := agent.(*configuredAgent); !ca.due(now) {
+			continue
+		}
+		agents = append(agents, agent)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	return agents
+}
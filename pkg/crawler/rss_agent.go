@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 // RSSAgent crawls high-signal standard RSS feeds. (Zero Cost).
@@ -14,14 +16,21 @@ type RSSAgent struct {
 	feeds  []string
 }
 
-func NewRSSAgent() *RSSAgent {
+// defaultRSSFeeds is used when an agent is created without explicit feeds.
+var defaultRSSFeeds = []string{
+	"https://news.ycombinator.com/rss",                              // HackerNews
+	"https://techcrunch.com/category/artificial-intelligence/feed/", // TechCrunch AI
+}
+
+// NewRSSAgent creates an RSS agent for feeds, falling back to the default
+// high-signal feeds when none are given.
+func NewRSSAgent(feeds ...string) *RSSAgent {
+	if len(feeds) == 0 {
+		feeds = defaultRSSFeeds
+	}
 	return &RSSAgent{
-		client: &http.Client{Timeout: 10 * time.Second},
-		feeds: []string{
-			"https://news.ycombinator.com/rss",                              // HackerNews
-			"https://techcrunch.com/category/artificial-intelligence/feed/", // TechCrunch AI
-			// Add more high-signal feeds here
-		},
+		client: fetch.NewClient(10 * time.Second),
+		feeds:  feeds,
 	}
 }
 
@@ -0,0 +1,136 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// seenStore is the shared state behind CrawlBudget: the set of recently
+// crawled URLs and the hourly ingestion counters.
+type seenStore interface {
+	// Seen reports whether url was claimed and has not expired.
+	Seen(ctx context.Context, url string) (bool, error)
+	// Claim records url as seen for ttl and takes one slot from the current
+	// hourly window. seen is true when url was already claimed; allowed is
+	// false when the window is full, in which case nothing is recorded.
+	// ingested is the window's count after the call.
+	Claim(ctx context.Context, url string, ttl time.Duration, maxHourly int) (allowed, seen bool, ingested int, err error)
+	// EachSeen calls fn for up to limit unexpired URLs, most recently seen
+	// first.
+	EachSeen(ctx context.Context, limit int, fn func(url string)) error
+	// HourlyIngested returns the current window's count.
+	HourlyIngested(ctx context.Context) (int, error)
+	// Cleanup drops expired URLs and old windows.
+	Cleanup(ctx context.Context)
+}
+
+// pgSeenStore keeps the seen-set and counters in crawl_seen and
+// crawl_budget_windows so every instance shares them.
+type pgSeenStore struct {
+	db *pgxpool.Pool
+}
+
+func (s *pgSeenStore) Seen(ctx context.Context, url string) (bool, error) {
+	var seen bool
+	err := s.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM crawl_seen WHERE url = $1 AND expires_at > now())
+	`, url).Scan(&seen)
+	return seen, err
+}
+
+// Claim runs the seen-set insert and the counter update in one transaction,
+// so a URL is never marked seen without being counted.
+func (s *pgSeenStore) Claim(ctx context.Context, url string, ttl time.Duration, maxHourly int) (allowed, seen bool, ingested int, err error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, false, 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var claimed string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO crawl_seen (url, first_seen_at, expires_at)
+		VALUES ($1, now(), now() + make_interval(secs => $2))
+		ON CONFLICT (url) DO UPDATE
+		SET first_seen_at = EXCLUDED.first_seen_at, expires_at = EXCLUDED.expires_at
+		WHERE crawl_seen.expires_at <= now()
+		RETURNING url
+	`, url, ttl.Seconds()).Scan(&claimed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, true, 0, nil
+	}
+	if err != nil {
+		return false, false, 0, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO crawl_budget_windows (window_start, ingested)
+		VALUES (date_trunc('hour', now()), 1)
+		ON CONFLICT (window_start) DO UPDATE
+		SET ingested = crawl_budget_windows.ingested + 1
+		WHERE crawl_budget_windows.ingested < $1
+		RETURNING ingested
+	`, maxHourly).Scan(&ingested)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, false, maxHourly, nil
+	}
+	if err != nil {
+		return false, false, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, false, 0, err
+	}
+	return true, false, ingested, nil
+}
+
+func (s *pgSeenStore) EachSeen(ctx context.Context, limit int, fn func(url string)) error {
+	rows, err := s.db.Query(ctx, `
+		SELECT url FROM crawl_seen
+		WHERE expires_at > now()
+		ORDER BY expires_at DESC -- the TTL is fixed, so the newest first
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return err
+		}
+		fn(url)
+	}
+	return rows.Err()
+}
+
+func (s *pgSeenStore) HourlyIngested(ctx context.Context) (int, error) {
+	var ingested int
+	err := s.db.QueryRow(ctx, `
+		SELECT ingested FROM crawl_budget_windows WHERE window_start = date_trunc('hour', now())
+	`).Scan(&ingested)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return ingested, err
+}
+
+func (s *pgSeenStore) Cleanup(ctx context.Context) {
+	tag, err := s.db.Exec(ctx, `DELETE FROM crawl_seen WHERE expires_at <= now()`)
+	if err != nil {
+		log.Printf("[BUDGET] Failed to clean up crawl_seen: %v", err)
+	} else if tag.RowsAffected() > 0 {
+		log.Printf("[BUDGET] Expired %d seen URLs", tag.RowsAffected())
+	}
+
+	if _, err := s.db.Exec(ctx, `DELETE FROM crawl_budget_windows WHERE window_start < now() - interval '7 days'`); err != nil {
+		log.Printf("[BUDGET] Failed to clean up crawl_budget_windows: %v", err)
+	}
+}
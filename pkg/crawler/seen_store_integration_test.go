@@ -0,0 +1,65 @@
+//go:build integration
+
+package crawler
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestPgSeenStore(t *testing.T) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	url := "https://seen-store.test/" + time.Now().Format(time.RFC3339Nano)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM crawl_seen WHERE url = $1`, url)
+	})
+	store := &pgSeenStore{db: pool}
+
+	if seen, err := store.Seen(ctx, url); err != nil || seen {
+		t.Fatalf("Seen before claim = %v, %v", seen, err)
+	}
+	allowed, seen, ingested, err := store.Claim(ctx, url, time.Hour, 1<<30)
+	if err != nil || !allowed || seen || ingested < 1 {
+		t.Fatalf("Claim = %v, %v, %d, %v; want allowed", allowed, seen, ingested, err)
+	}
+	if seen, err := store.Seen(ctx, url); err != nil || !seen {
+		t.Errorf("Seen after claim = %v, %v", seen, err)
+	}
+	if allowed, seen, _, err := store.Claim(ctx, url, time.Hour, 1<<30); err != nil || allowed || !seen {
+		t.Errorf("second Claim = %v, %v, %v; want seen", allowed, seen, err)
+	}
+
+	found := false
+	if err := store.EachSeen(ctx, 1<<20, func(u string) { found = found || u == url }); err != nil || !found {
+		t.Errorf("EachSeen found %v, err %v", found, err)
+	}
+	if n, err := store.HourlyIngested(ctx); err != nil || n < 1 {
+		t.Errorf("HourlyIngested = %d, %v", n, err)
+	}
+
+	// Once expired, the URL can be claimed again.
+	if _, err := pool.Exec(ctx, `UPDATE crawl_seen SET expires_at = now() - interval '1 second' WHERE url = $1`, url); err != nil {
+		t.Fatal(err)
+	}
+	if seen, err := store.Seen(ctx, url); err != nil || seen {
+		t.Errorf("Seen after expiry = %v, %v", seen, err)
+	}
+	store.Cleanup(ctx)
+	if allowed, _, _, err := store.Claim(ctx, url, time.Hour, 1<<30); err != nil || !allowed {
+		t.Errorf("Claim after expiry = %v, %v; want allowed", allowed, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 type xSearchResponse struct {
@@ -22,13 +23,16 @@ type xSearchResponse struct {
 
 type SocialAgent struct {
 	Platform string
+	Query    string // overrides the platform's default search query when set
 	repo     *db.SettingRepository
+	client   *http.Client
 }
 
 func NewSocialAgent(platform string, pool *db.DB) *SocialAgent {
 	return &SocialAgent{
 		Platform: platform,
 		repo:     db.NewSettingRepository(pool),
+		client:   fetch.NewClient(10 * time.Second),
 	}
 }
 
@@ -48,6 +52,9 @@ func (a *SocialAgent) Crawl(ctx context.Context, maxItems int) ([]Article, error
 		keyName = "threads_api_key"
 		query = "AI Robotics Innovation"
 	}
+	if a.Query != "" {
+		query = a.Query
+	}
 
 	if keyName == "" {
 		return nil, nil
@@ -64,7 +71,7 @@ func (a *SocialAgent) Crawl(ctx context.Context, maxItems int) ([]Article, error
 		encodedQuery := url.QueryEscape(query)
 		urlStr := fmt.Sprintf("https://api.twitter.com/2/tweets/search/recent?query=%s&max_results=%d&tweet.fields=created_at",
 			encodedQuery,
-			10,
+			min(max(maxItems, 10), 100), // API accepts 10-100
 		)
 
 		req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
@@ -73,8 +80,7 @@ func (a *SocialAgent) Crawl(ctx context.Context, maxItems int) ([]Article, error
 		}
 		req.Header.Set("Authorization", "Bearer "+apiKey)
 
-		client := &http.Client{Timeout: 10 * time.Second}
-		resp, err := client.Do(req)
+		resp, err := a.client.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("x API request failed: %w", err)
 		}
@@ -2,84 +2,158 @@ package crawler
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log"
 
 	"github.com/google/uuid"
 	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/langdetect"
 	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/tagging"
 )
 
 type Summarizer struct {
 	aiSvc     *ai.Service
 	repo      *db.SummaryRepository
 	scoreRepo *db.ScoreRepository
+	keyword   *tagging.AutoTagger
+	tagger    *tagging.LLMTagger // nil when TAG_LLM_ENABLED is off
 }
 
 func NewSummarizer(aiSvc *ai.Service, pool *db.DB) *Summarizer {
-	return &Summarizer{
+	s := &Summarizer{
 		aiSvc:     aiSvc,
 		repo:      db.NewSummaryRepository(pool),
 		scoreRepo: db.NewScoreRepository(pool),
+		keyword:   tagging.NewAutoTagger(),
 	}
+	if config.Load().TagLLMEnabled {
+		s.tagger = tagging.NewLLMTagger(aiSvc)
+	}
+	return s
 }
 
+// Process runs LLM analysis for an item and stores the summary, its tags and
+// the LLM score components. If the LLM is unavailable a placeholder summary
+// with keyword tags is stored only when the item has none yet, and the error
+// is returned so the caller (the job queue) can retry later.
 func (s *Summarizer) Process(ctx context.Context, itemID uuid.UUID, title, content string) error {
 	// Call AI Service for structured analysis
 	resp, err := s.aiSvc.AnalyzeArticle(ctx, ai.AnalyzeRequest{
 		Title:   title,
 		Content: content,
 	})
-
-	var summaryContent, whyMatters string
-	var tags []string
-	method := "llm-openrouter"
-
-	var impact, engineeringValue, novelty float64
-	var reasoning string
-
 	if err != nil {
-		// Fallback if AI fails or budget exhausted
-		summaryContent = "Summary pending system capacity."
-		whyMatters = "Research discovery."
-		tags = []string{"AI", "Research"}
-		method = "fallback"
-	} else {
-		summaryContent = resp.TLDR
-		whyMatters = resp.WhyItMatters
-		tags = []string{"AI", "Innovation"} // Keep simple for now
-
-		impact = resp.Impact
-		engineeringValue = resp.EngineeringValue
-		novelty = resp.Novelty
-		reasoning = resp.Reasoning
+		// Fallback if AI fails or budget exhausted, without clobbering a better summary
+		existing, getErr := s.repo.GetByItemID(ctx, itemID)
+		if getErr == nil && existing == nil {
+			tags := s.keyword.Score(title, content)
+			fallback := &models.Summary{
+				ItemID:       itemID,
+				TLDR:         "Summary pending system capacity.",
+				WhyItMatters: "Research discovery.",
+				Tags:         tagging.Names(tags),
+				TagScores:    tags,
+				Method:       "fallback",
+			}
+			if upErr := s.repo.Upsert(ctx, fallback); upErr != nil {
+				return upErr
+			}
+		}
+		return fmt.Errorf("article analysis failed: %w", err)
 	}
 
+	tags := s.tags(ctx, itemID, title, content)
 	summary := &models.Summary{
 		ItemID:       itemID,
-		TLDR:         summaryContent,
-		WhyItMatters: whyMatters,
-		Tags:         tags,
-		Method:       method,
+		TLDR:         resp.TLDR,
+		WhyItMatters: resp.WhyItMatters,
+		Tags:         tagging.Names(tags),
+		TagScores:    tags,
+		Method:       "llm-openrouter",
 	}
 
 	if err := s.repo.Upsert(ctx, summary); err != nil {
 		return err
 	}
 
-	// Update existing score with LLM metrics if AI was successful
-	if err == nil {
-		existingScore, _ := s.scoreRepo.GetByItemID(ctx, itemID)
-		if existingScore == nil {
-			existingScore = &models.Score{ItemID: itemID}
-		}
+	// Store the raw ratings; scoring normalizes them into the components.
+	return s.scoreRepo.SetLLMRatings(ctx, itemID, models.LLMRatings{
+		Novelty:          resp.Novelty,
+		Impact:           resp.Impact,
+		EngineeringValue: resp.EngineeringValue,
+	}, resp.Reasoning)
+}
+
+// tags classifies the item with the LLM tagger, or by keyword when it is
+// disabled or fails. A failed classification does not fail the summary.
+func (s *Summarizer) tags(ctx context.Context, itemID uuid.UUID, title, content string) []models.TagScore {
+	if s.tagger == nil {
+		return s.keyword.Score(title, content)
+	}
+	tags, err := s.tagger.Tag(ctx, title, content)
+	if err != nil {
+		log.Printf("LLM tagging failed for %s, using keyword tags: %v", itemID, err)
+	}
+	return tags
+}
+
+// Translate writes the item's TLDR and why-it-matters in lang and stores them
+// next to the original text. Placeholder summaries and summaries already in
+// lang are left alone.
+func (s *Summarizer) Translate(ctx context.Context, itemID uuid.UUID, lang string) error {
+	summary, err := s.repo.GetByItemID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if summary == nil || summary.Method == "fallback" {
+		return nil
+	}
+	if langdetect.Detect(summary.TLDR+" "+summary.WhyItMatters) == lang {
+		return nil
+	}
 
-		existingScore.Impact = impact
-		existingScore.EngineeringValue = engineeringValue
-		existingScore.Novelty = novelty
-		existingScore.Reasoning = reasoning
+	source, err := json.Marshal(map[string]string{
+		"tldr":           summary.TLDR,
+		"why_it_matters": summary.WhyItMatters,
+	})
+	if err != nil {
+		return err
+	}
 
-		_ = s.scoreRepo.Upsert(ctx, existingScore)
+	temperature := float32(0.2)
+	resp, err := s.aiSvc.Chat(ctx, ai.ChatRequest{
+		Messages: []ai.ChatMessage{
+			{
+				Role: ai.RoleSystem,
+				Content: fmt.Sprintf("Translate the JSON values into %s. Keep product names, model names and numbers unchanged. "+
+					"Reply with only a JSON object with the keys \"tldr\" and \"why_it_matters\".", langdetect.Name(lang)),
+			},
+			{Role: ai.RoleUser, Content: string(source)},
+		},
+		Temperature: &temperature,
+	})
+	if err != nil {
+		return fmt.Errorf("summary translation failed: %w", err)
 	}
 
-	return nil
+	var out struct {
+		TLDR         string `json:"tldr"`
+		WhyItMatters string `json:"why_it_matters"`
+	}
+	if err := ai.DecodeJSONReply(resp.Content, &out); err != nil {
+		return fmt.Errorf("failed to parse translation: %w", err)
+	}
+	if out.TLDR == "" {
+		return fmt.Errorf("translation returned an empty tldr")
+	}
+
+	return s.repo.SetTranslation(ctx, itemID, models.SummaryTranslation{
+		Lang:         lang,
+		TLDR:         out.TLDR,
+		WhyItMatters: out.WhyItMatters,
+	})
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 // TrendingAgent hits free APIs (like Algolia HN Search) for viral buzz.
@@ -15,7 +17,7 @@ type TrendingAgent struct {
 
 func NewTrendingAgent() *TrendingAgent {
 	return &TrendingAgent{
-		client: &http.Client{Timeout: 10 * time.Second},
+		client: fetch.NewClient(10 * time.Second),
 	}
 }
 
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Advisory lock names shared by every process that talks to the database.
+const (
+	LockDiscoveryCycle = "crawler:discovery"
+	LockScoring        = "worker:scoring"
+	LockCrawlScheduler = "server:crawl-scheduler"
+	LockReputation     = "worker:reputation"
+)
+
+// advisoryKey maps a lock name onto the int64 key space of pg_advisory_lock.
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// TryLock takes the session-level advisory lock name without blocking. When
+// acquired is true the caller holds the lock until unlock is called; the
+// lock is also released automatically if the process dies and its
+// connection drops, so a crashed leader never blocks the others.
+func (d *DB) TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error) {
+	conn, err := d.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire connection for lock %s: %w", name, err)
+	}
+
+	key := advisoryKey(name)
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, false, fmt.Errorf("failed to take lock %s: %w", name, err)
+	}
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	var once sync.Once
+	unlock = func() {
+		once.Do(func() {
+			// Use a fresh context: the caller's may already be cancelled.
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, key); err != nil {
+				log.Printf("[LOCK] Failed to release %s, dropping connection: %v", name, err)
+				_ = conn.Conn().Close(ctx)
+			}
+			conn.Release()
+		})
+	}
+	return unlock, true, nil
+}
+
+// InstanceID identifies this process as a lease owner.
+func InstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return host + "-" + strconv.Itoa(os.Getpid()) + "-" + uuid.NewString()[:8]
+}
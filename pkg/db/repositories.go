@@ -18,14 +18,18 @@ type SourceRepository struct {
 	db *DB
 }
 
+const sourceColumns = `id, name, type, category, url, mapping_json, enabled, status,
+		       last_test_status, last_test_message, created_at, updated_at,
+		       circuit_state, consecutive_failures, next_attempt_at, failing_since, last_success_at,
+		       schedule, adaptive, poll_interval_seconds, last_polled_at, next_poll_at`
+
 func NewSourceRepository(db *DB) *SourceRepository {
 	return &SourceRepository{db: db}
 }
 
 func (r *SourceRepository) List(ctx context.Context) ([]models.Source, error) {
 	rows, err := r.db.Pool.Query(ctx, `
-		SELECT id, name, type, category, url, mapping_json, enabled, status,
-		       last_test_status, last_test_message, created_at, updated_at
+		SELECT `+sourceColumns+`
 		FROM sources
 		ORDER BY created_at DESC
 	`)
@@ -41,15 +45,45 @@ func (r *SourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.S
 	var s models.Source
 	var mappingJSON []byte
 	err := r.db.Pool.QueryRow(ctx, `
-		SELECT id, name, type, category, url, mapping_json, enabled, status,
-		       last_test_status, last_test_message, created_at, updated_at
+		SELECT `+sourceColumns+`
 		FROM sources
 		WHERE id = $1
 	`, id).Scan(
 		&s.ID, &s.Name, &s.Type, &s.Category, &s.URL, &mappingJSON,
 		&s.Enabled, &s.Status, &s.LastTestStatus, &s.LastTestMessage,
 		&s.CreatedAt, &s.UpdatedAt,
+		&s.CircuitState, &s.ConsecutiveFailures, &s.NextAttemptAt,
+		&s.FailingSince, &s.LastSuccessAt,
+		&s.Schedule, &s.Adaptive, &s.PollIntervalSeconds, &s.LastPolledAt, &s.NextPollAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if mappingJSON != nil {
+		s.MappingJSON = mappingJSON
+	}
+	return &s, nil
+}
+
+// GetByURL returns the source registered under url, or nil if none exists.
+func (r *SourceRepository) GetByURL(ctx context.Context, url string) (*models.Source, error) {
+	var s models.Source
+	var mappingJSON []byte
+	err := r.db.Pool.QueryRow(ctx, `
+		SELECT `+sourceColumns+`
+		FROM sources
+		WHERE url = $1
+	`, url).Scan(
+		&s.ID, &s.Name, &s.Type, &s.Category, &s.URL, &mappingJSON,
+		&s.Enabled, &s.Status, &s.LastTestStatus, &s.LastTestMessage,
+		&s.CreatedAt, &s.UpdatedAt,
+		&s.CircuitState, &s.ConsecutiveFailures, &s.NextAttemptAt,
+		&s.FailingSince, &s.LastSuccessAt,
+		&s.Schedule, &s.Adaptive, &s.PollIntervalSeconds, &s.LastPolledAt, &s.NextPollAt,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -61,8 +95,7 @@ func (r *SourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.S
 
 func (r *SourceRepository) GetEnabled(ctx context.Context) ([]models.Source, error) {
 	rows, err := r.db.Pool.Query(ctx, `
-		SELECT id, name, type, category, url, mapping_json, enabled, status,
-		       last_test_status, last_test_message, created_at, updated_at
+		SELECT `+sourceColumns+`
 		FROM sources
 		WHERE enabled = true
 		ORDER BY created_at DESC
@@ -84,6 +117,9 @@ func (r *SourceRepository) scanSources(rows pgx.Rows) ([]models.Source, error) {
 			&s.ID, &s.Name, &s.Type, &s.Category, &s.URL, &mappingJSON,
 			&s.Enabled, &s.Status, &s.LastTestStatus, &s.LastTestMessage,
 			&s.CreatedAt, &s.UpdatedAt,
+			&s.CircuitState, &s.ConsecutiveFailures, &s.NextAttemptAt,
+			&s.FailingSince, &s.LastSuccessAt,
+			&s.Schedule, &s.Adaptive, &s.PollIntervalSeconds, &s.LastPolledAt, &s.NextPollAt,
 		)
 		if err != nil {
 			return nil, err
@@ -102,10 +138,10 @@ func (r *SourceRepository) Create(ctx context.Context, s *models.Source) error {
 		mappingJSON = s.MappingJSON
 	}
 	err := r.db.Pool.QueryRow(ctx, `
-		INSERT INTO sources (name, type, category, url, mapping_json, enabled, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO sources (name, type, category, url, mapping_json, enabled, status, schedule, adaptive)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, created_at, updated_at
-	`, s.Name, s.Type, s.Category, s.URL, mappingJSON, s.Enabled, s.Status).Scan(
+	`, s.Name, s.Type, s.Category, s.URL, mappingJSON, s.Enabled, s.Status, s.Schedule, s.Adaptive).Scan(
 		&s.ID, &s.CreatedAt, &s.UpdatedAt,
 	)
 	return err
@@ -129,6 +165,114 @@ func (r *SourceRepository) SetEnabled(ctx context.Context, id uuid.UUID, enabled
 	return err
 }
 
+// UpdateCircuit persists the circuit breaker fields of s.
+func (r *SourceRepository) UpdateCircuit(ctx context.Context, s *models.Source) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE sources
+		SET circuit_state = $1, consecutive_failures = $2, next_attempt_at = $3,
+		    failing_since = $4, last_success_at = $5, updated_at = now()
+		WHERE id = $6
+	`, s.CircuitState, s.ConsecutiveFailures, s.NextAttemptAt, s.FailingSince, s.LastSuccessAt, s.ID)
+	return err
+}
+
+// ResetCircuit closes the circuit of source id and clears its failure history.
+func (r *SourceRepository) ResetCircuit(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE sources
+		SET circuit_state = 'closed', consecutive_failures = 0, next_attempt_at = NULL,
+		    failing_since = NULL, updated_at = now()
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// SetSchedule changes the polling schedule of source id and makes it due on
+// the next tick.
+func (r *SourceRepository) SetSchedule(ctx context.Context, id uuid.UUID, schedule string, adaptive bool) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE sources
+		SET schedule = $1, adaptive = $2, poll_interval_seconds = NULL, next_poll_at = NULL, updated_at = now()
+		WHERE id = $3
+	`, schedule, adaptive, id)
+	return err
+}
+
+// UpdatePolling persists when s was last polled and when it is next due.
+func (r *SourceRepository) UpdatePolling(ctx context.Context, s *models.Source) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE sources
+		SET last_polled_at = $1, next_poll_at = $2, poll_interval_seconds = $3
+		WHERE id = $4
+	`, s.LastPolledAt, s.NextPollAt, s.PollIntervalSeconds, s.ID)
+	return err
+}
+
+// LeaseDue claims up to limit enabled, due sources for owner until ttl
+// elapses. Rows locked by another replica are skipped, and leases left
+// behind by crashed workers count as free once expired. Sources polled at or
+// after polledBefore are excluded so one run never claims a source twice.
+func (r *SourceRepository) LeaseDue(ctx context.Context, owner string, ttl time.Duration, polledBefore time.Time, limit int) ([]models.Source, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		UPDATE sources
+		SET lease_owner = $1, lease_expires_at = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM sources
+			WHERE enabled = true
+			  AND type <> 'agent'
+			  AND (lease_expires_at IS NULL OR lease_expires_at < now())
+			  AND (next_poll_at IS NULL OR next_poll_at <= now())
+			  AND (last_polled_at IS NULL OR last_polled_at < $3)
+			  AND NOT (circuit_state = 'open' AND next_attempt_at > now())
+			ORDER BY next_poll_at NULLS FIRST, created_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+sourceColumns+`
+	`, owner, ttl.Seconds(), polledBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSources(rows)
+}
+
+// ReleaseLease frees source id if it is still leased by owner.
+func (r *SourceRepository) ReleaseLease(ctx context.Context, id uuid.UUID, owner string) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE sources
+		SET lease_owner = NULL, lease_expires_at = NULL
+		WHERE id = $1 AND lease_owner = $2
+	`, id, owner)
+	return err
+}
+
+// ReclaimExpiredLeases clears leases whose owner died before releasing them
+// and returns the names of the affected sources.
+func (r *SourceRepository) ReclaimExpiredLeases(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		UPDATE sources
+		SET lease_owner = NULL, lease_expires_at = NULL
+		WHERE lease_expires_at < now()
+		RETURNING name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
 type ItemRepository struct {
 	db *DB
 }
@@ -137,18 +281,27 @@ func NewItemRepository(db *DB) *ItemRepository {
 	return &ItemRepository{db: db}
 }
 
+// itemColumns is selected from "items i" so queries can join other tables.
+const itemColumns = `i.id, i.source_id, i.title, i.url, i.published_at, i.content_hash,
+		       i.domain, i.category, i.raw_excerpt, i.canonical_url, i.duplicate_of,
+		       COALESCE(i.lang, ''), i.created_at`
+
+// itemFields returns scan destinations matching itemColumns.
+func itemFields(item *models.Item) []any {
+	return []any{
+		&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
+		&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt, &item.CanonicalURL,
+		&item.DuplicateOf, &item.Lang, &item.CreatedAt,
+	}
+}
+
 func (r *ItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Item, error) {
 	var item models.Item
 	err := r.db.Pool.QueryRow(ctx, `
-		SELECT id, source_id, title, url, published_at, content_hash,
-		       domain, category, raw_excerpt, created_at
-		FROM items
+		SELECT `+itemColumns+`
+		FROM items i
 		WHERE id = $1
-	`, id).Scan(
-		&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
-		&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt,
-		&item.CreatedAt,
-	)
+	`, id).Scan(itemFields(&item)...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,15 +311,30 @@ func (r *ItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Ite
 func (r *ItemRepository) GetByContentHash(ctx context.Context, hash string) (*models.Item, error) {
 	var item models.Item
 	err := r.db.Pool.QueryRow(ctx, `
-		SELECT id, source_id, title, url, published_at, content_hash,
-		       domain, category, raw_excerpt, created_at
-		FROM items
+		SELECT `+itemColumns+`
+		FROM items i
 		WHERE content_hash = $1
-	`, hash).Scan(
-		&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
-		&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt,
-		&item.CreatedAt,
-	)
+	`, hash).Scan(itemFields(&item)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetByURL finds an item whose reported or canonical URL is url, so known
+// links can be matched without resolving them again.
+func (r *ItemRepository) GetByURL(ctx context.Context, url string) (*models.Item, error) {
+	var item models.Item
+	err := r.db.Pool.QueryRow(ctx, `
+		SELECT `+itemColumns+`
+		FROM items i
+		WHERE url = $1 OR canonical_url = $1
+		ORDER BY created_at
+		LIMIT 1
+	`, url).Scan(itemFields(&item)...)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -179,41 +347,72 @@ func (r *ItemRepository) GetByContentHash(ctx context.Context, hash string) (*mo
 func (r *ItemRepository) Create(ctx context.Context, item *models.Item) error {
 	err := r.db.Pool.QueryRow(ctx, `
 		INSERT INTO items (source_id, title, url, published_at, content_hash,
-		                   domain, category, raw_excerpt)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		                   domain, category, raw_excerpt, canonical_url, lang)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))
 		RETURNING id, created_at
 	`, item.SourceID, item.Title, item.URL, item.PublishedAt, item.ContentHash,
-		item.Domain, item.Category, item.RawExcerpt).Scan(
+		item.Domain, item.Category, item.RawExcerpt, item.CanonicalURL, item.Lang).Scan(
 		&item.ID, &item.CreatedAt,
 	)
 	return err
 }
 
-func (r *ItemRepository) GetTopDaily(ctx context.Context, date time.Time, topic *string, limit int) ([]models.Item, error) {
+// topicFilter is the condition that an item carries one of the tags in
+// parameter $argIdx. With a positive minConfidence the tag must also have
+// been assigned with at least the confidence in parameter $argIdx+1.
+func topicFilter(argIdx int, minConfidence float64) string {
+	if minConfidence <= 0 {
+		return fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM summaries su, jsonb_array_elements_text(su.tags) t
+			WHERE su.item_id = i.id
+			AND lower(t) = ANY($%d)
+		)`, argIdx)
+	}
+	return fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM summaries su, jsonb_array_elements(su.tag_scores) ts
+			WHERE su.item_id = i.id
+			AND lower(ts->>'tag') = ANY($%d)
+			AND (ts->>'confidence')::float8 >= $%d
+		)`, argIdx, argIdx+1)
+}
+
+func (r *ItemRepository) GetTopDaily(ctx context.Context, date time.Time, topicTags []string, minConfidence float64, lang *string, limit int) ([]models.Item, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
+	// Near-duplicates share a story key (duplicate_of, or the item's own ID);
+	// only the best-scored member of each story is listed.
 	query := `
-		SELECT i.id, i.source_id, i.title, i.url, i.published_at, i.content_hash,
-		       i.domain, i.category, i.raw_excerpt, i.created_at
-		FROM items i
-		JOIN scores s ON s.item_id = i.id
-		WHERE i.published_at >= $1 AND i.published_at < $2
+		SELECT ` + itemColumns + `
+		FROM (
+			SELECT DISTINCT ON (COALESCE(i.duplicate_of, i.id)) i.*, s.final AS final_score
+			FROM items i
+			JOIN scores s ON s.item_id = i.id
+			WHERE i.published_at >= $1 AND i.published_at < $2
 	`
 	args := []interface{}{startOfDay, endOfDay}
 	argIdx := 3
 
-	if topic != nil {
-		query += fmt.Sprintf(` AND EXISTS (
-			SELECT 1 FROM summaries su
-			WHERE su.item_id = i.id
-			AND su.tags @> $%d::jsonb
-		)`, argIdx)
-		args = append(args, fmt.Sprintf(`["%s"]`, *topic))
+	if len(topicTags) > 0 {
+		query += topicFilter(argIdx, minConfidence)
+		args = append(args, topicTags)
+		argIdx++
+		if minConfidence > 0 {
+			args = append(args, minConfidence)
+			argIdx++
+		}
+	}
+
+	if lang != nil {
+		query += fmt.Sprintf(` AND i.lang = $%d`, argIdx)
+		args = append(args, *lang)
 		argIdx++
 	}
 
-	query += ` ORDER BY s.final DESC LIMIT $` + fmt.Sprintf("%d", argIdx)
+	query += `
+			ORDER BY COALESCE(i.duplicate_of, i.id), s.final DESC
+		) i
+		ORDER BY i.final_score DESC LIMIT $` + fmt.Sprintf("%d", argIdx)
 	args = append(args, limit)
 
 	rows, err := r.db.Pool.Query(ctx, query, args...)
@@ -225,11 +424,7 @@ func (r *ItemRepository) GetTopDaily(ctx context.Context, date time.Time, topic
 	var items []models.Item
 	for rows.Next() {
 		var item models.Item
-		err := rows.Scan(
-			&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
-			&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt,
-			&item.CreatedAt,
-		)
+		err := rows.Scan(itemFields(&item)...)
 		if err != nil {
 			return nil, err
 		}
@@ -238,18 +433,16 @@ func (r *ItemRepository) GetTopDaily(ctx context.Context, date time.Time, topic
 	return items, rows.Err()
 }
 
-func (r *ItemRepository) GetRising(ctx context.Context, window time.Duration, limit int) ([]models.Item, error) {
-	cutoff := time.Now().Add(-window)
-
+// ListPublished returns up to limit items published in [from, to), newest
+// first.
+func (r *ItemRepository) ListPublished(ctx context.Context, from, to time.Time, limit int) ([]models.Item, error) {
 	rows, err := r.db.Pool.Query(ctx, `
-		SELECT DISTINCT ON (i.id) i.id, i.source_id, i.title, i.url, i.published_at,
-		       i.content_hash, i.domain, i.category, i.raw_excerpt, i.created_at
+		SELECT `+itemColumns+`
 		FROM items i
-		JOIN signals sig ON sig.item_id = i.id
-		WHERE sig.fetched_at >= $1
-		ORDER BY i.id, sig.fetched_at DESC
-		LIMIT $2
-	`, cutoff, limit)
+		WHERE i.published_at >= $1 AND i.published_at < $2
+		ORDER BY i.published_at DESC
+		LIMIT $3
+	`, from, to, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -258,12 +451,7 @@ func (r *ItemRepository) GetRising(ctx context.Context, window time.Duration, li
 	var items []models.Item
 	for rows.Next() {
 		var item models.Item
-		err := rows.Scan(
-			&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
-			&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt,
-			&item.CreatedAt,
-		)
-		if err != nil {
+		if err := rows.Scan(itemFields(&item)...); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -271,25 +459,246 @@ func (r *ItemRepository) GetRising(ctx context.Context, window time.Duration, li
 	return items, rows.Err()
 }
 
-func (r *ItemRepository) Search(ctx context.Context, query string, topic *string, limit, offset int) ([]models.Item, int, error) {
+// GetRising ranks items whose velocity was updated within window by how
+// unusual that velocity is for their source: the z-score of log velocity
+// (plus half the positive acceleration z-score) against the velocities the
+// source's items reached within baseline. Sources with fewer than
+// minBaseline items in the baseline get a z-score of 0 and fall back to raw
+// velocity order.
+func (r *ItemRepository) GetRising(ctx context.Context, window, baseline time.Duration, minBaseline int, lang *string, limit int) ([]models.RisingItem, error) {
+	now := time.Now()
+
+	// One item per story: the member rising fastest.
+	query := `
+		WITH baseline AS (
+			SELECT i.source_id,
+			       AVG(LN(1 + GREATEST(v.velocity, 0))) AS mean_v,
+			       STDDEV_SAMP(LN(1 + GREATEST(v.velocity, 0))) AS sd_v,
+			       AVG(v.acceleration) AS mean_a,
+			       STDDEV_SAMP(v.acceleration) AS sd_a,
+			       COUNT(*) AS n
+			FROM item_velocity v
+			JOIN items i ON i.id = v.item_id
+			WHERE v.computed_at >= $2
+			GROUP BY i.source_id
+		)
+		SELECT ` + itemColumns + `,
+		       i.engagement, i.velocity, i.acceleration, i.samples, i.velocity_at,
+		       i.z_velocity, i.z_acceleration, i.rising_score, i.baseline_n
+		FROM (
+			SELECT DISTINCT ON (COALESCE(i.duplicate_of, i.id)) i.*,
+			       v.engagement, v.velocity, v.acceleration, v.samples, v.computed_at AS velocity_at,
+			       z.z_velocity, z.z_acceleration, z.rising_score, z.baseline_n
+			FROM items i
+			JOIN item_velocity v ON v.item_id = i.id
+			LEFT JOIN baseline b ON b.source_id = i.source_id
+			CROSS JOIN LATERAL (
+				SELECT t.z_velocity, t.z_acceleration,
+				       t.z_velocity + 0.5 * GREATEST(t.z_acceleration, 0) AS rising_score,
+				       COALESCE(b.n, 0)::int AS baseline_n
+				FROM (
+					SELECT
+						CASE WHEN COALESCE(b.n, 0) >= $3
+							THEN COALESCE((LN(1 + GREATEST(v.velocity, 0)) - b.mean_v) / NULLIF(b.sd_v, 0), 0)
+							ELSE 0 END AS z_velocity,
+						CASE WHEN COALESCE(b.n, 0) >= $3
+							THEN COALESCE((v.acceleration - b.mean_a) / NULLIF(b.sd_a, 0), 0)
+							ELSE 0 END AS z_acceleration
+				) t
+			) z
+			WHERE v.computed_at >= $1
+	`
+	args := []interface{}{now.Add(-window), now.Add(-baseline), minBaseline}
+	argIdx := 4
+
+	if lang != nil {
+		query += fmt.Sprintf(` AND i.lang = $%d`, argIdx)
+		args = append(args, *lang)
+		argIdx++
+	}
+
+	query += `
+			ORDER BY COALESCE(i.duplicate_of, i.id), z.rising_score DESC, v.velocity DESC
+		) i
+		ORDER BY i.rising_score DESC, i.velocity DESC
+		LIMIT $` + fmt.Sprintf("%d", argIdx)
+	args = append(args, limit)
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.RisingItem
+	for rows.Next() {
+		var ri models.RisingItem
+		v := &ri.Velocity
+		fields := append(itemFields(&ri.Item),
+			&v.Engagement, &v.Velocity, &v.Acceleration, &v.Samples, &v.ComputedAt,
+			&ri.ZVelocity, &ri.ZAcceleration, &ri.RisingScore, &ri.BaselineSize)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		v.ItemID = ri.ID
+		items = append(items, ri)
+	}
+	return items, rows.Err()
+}
+
+// GetCoverage returns every item covering the same story as itemID, the item
+// itself included (Via "self"): its near-duplicates and the other members of
+// its clusters, oldest first. An item reached both ways is reported as a
+// duplicate.
+func (r *ItemRepository) GetCoverage(ctx context.Context, itemID uuid.UUID) ([]models.CoverageItem, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		WITH story AS (
+			SELECT COALESCE(duplicate_of, id) AS id FROM items WHERE id = $1
+		), related AS (
+			SELECT $1::uuid AS id, 'self' AS via
+			UNION ALL
+			SELECT i.id, 'duplicate' FROM items i, story
+			WHERE i.id = story.id OR i.duplicate_of = story.id
+			UNION ALL
+			SELECT cm.article_id, 'cluster'
+			FROM cluster_sources cs
+			JOIN cluster_sources cm ON cm.cluster_id = cs.cluster_id
+			WHERE cs.article_id = $1
+		)
+		SELECT i.id, i.title, i.url, i.domain, s.type, i.published_at,
+		       CASE WHEN bool_or(r.via = 'self') THEN 'self' ELSE MAX(r.via) END
+		FROM related r
+		JOIN items i ON i.id = r.id
+		JOIN sources s ON s.id = i.source_id
+		GROUP BY i.id, s.type
+		ORDER BY i.published_at
+	`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coverage []models.CoverageItem
+	for rows.Next() {
+		var ci models.CoverageItem
+		if err := rows.Scan(&ci.ID, &ci.Title, &ci.URL, &ci.Domain, &ci.SourceType, &ci.PublishedAt, &ci.Via); err != nil {
+			return nil, err
+		}
+		coverage = append(coverage, ci)
+	}
+	return coverage, rows.Err()
+}
+
+// GetStoryMembers returns, for each story key, every item in that story:
+// the canonical item and the near-duplicates linked to it.
+func (r *ItemRepository) GetStoryMembers(ctx context.Context, storyIDs []uuid.UUID) (map[uuid.UUID][]models.Item, error) {
+	members := make(map[uuid.UUID][]models.Item)
+	if len(storyIDs) == 0 {
+		return members, nil
+	}
+
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+itemColumns+`
+		FROM items i
+		WHERE i.id = ANY($1) OR i.duplicate_of = ANY($1)
+		ORDER BY i.published_at
+	`, storyIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.Item
+		if err := rows.Scan(itemFields(&item)...); err != nil {
+			return nil, err
+		}
+		key := item.ID
+		if item.DuplicateOf != nil {
+			key = *item.DuplicateOf
+		}
+		members[key] = append(members[key], item)
+	}
+	return members, rows.Err()
+}
+
+// SetMinHash stores an item's MinHash signature and its LSH band keys.
+func (r *ItemRepository) SetMinHash(ctx context.Context, itemID uuid.UUID, sig []uint32, bands []int64) error {
+	stored := make([]int64, len(sig))
+	for i, v := range sig {
+		stored[i] = int64(v)
+	}
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE items SET minhash = $2, minhash_bands = $3 WHERE id = $1
+	`, itemID, stored, bands)
+	return err
+}
+
+// FindNearDuplicateCandidates returns items published in [from, to) that
+// share at least one LSH band with bands, oldest first.
+func (r *ItemRepository) FindNearDuplicateCandidates(ctx context.Context, bands []int64, exclude uuid.UUID, from, to time.Time, limit int) ([]models.NearDuplicateCandidate, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT id, minhash, duplicate_of
+		FROM items
+		WHERE minhash_bands && $1
+		AND id <> $2
+		AND published_at >= $3 AND published_at < $4
+		ORDER BY created_at
+		LIMIT $5
+	`, bands, exclude, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []models.NearDuplicateCandidate
+	for rows.Next() {
+		var c models.NearDuplicateCandidate
+		var stored []int64
+		if err := rows.Scan(&c.ItemID, &stored, &c.DuplicateOf); err != nil {
+			return nil, err
+		}
+		c.Signature = make([]uint32, len(stored))
+		for i, v := range stored {
+			c.Signature[i] = uint32(v)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// MarkDuplicate links itemID to the canonical item of its story.
+func (r *ItemRepository) MarkDuplicate(ctx context.Context, itemID, canonicalID uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE items SET duplicate_of = $2 WHERE id = $1
+	`, itemID, canonicalID)
+	return err
+}
+
+func (r *ItemRepository) Search(ctx context.Context, query string, topicTags []string, minConfidence float64, lang *string, limit, offset int) ([]models.Item, int, error) {
 	searchQuery := `%` + query + `%`
 
 	baseQuery := `
-		SELECT i.id, i.source_id, i.title, i.url, i.published_at, i.content_hash,
-		       i.domain, i.category, i.raw_excerpt, i.created_at
+		SELECT ` + itemColumns + `
 		FROM items i
 		WHERE (i.title ILIKE $1 OR i.raw_excerpt ILIKE $1)
 	`
 	args := []interface{}{searchQuery}
 	argIdx := 2
 
-	if topic != nil {
-		baseQuery += fmt.Sprintf(` AND EXISTS (
-			SELECT 1 FROM summaries su
-			WHERE su.item_id = i.id
-			AND su.tags @> $%d::jsonb
-		)`, argIdx)
-		args = append(args, fmt.Sprintf(`["%s"]`, *topic))
+	if len(topicTags) > 0 {
+		baseQuery += topicFilter(argIdx, minConfidence)
+		args = append(args, topicTags)
+		argIdx++
+		if minConfidence > 0 {
+			args = append(args, minConfidence)
+			argIdx++
+		}
+	}
+
+	if lang != nil {
+		baseQuery += fmt.Sprintf(` AND i.lang = $%d`, argIdx)
+		args = append(args, *lang)
 		argIdx++
 	}
 
@@ -314,11 +723,7 @@ func (r *ItemRepository) Search(ctx context.Context, query string, topic *string
 	var items []models.Item
 	for rows.Next() {
 		var item models.Item
-		err := rows.Scan(
-			&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
-			&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt,
-			&item.CreatedAt,
-		)
+		err := rows.Scan(itemFields(&item)...)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -354,8 +759,7 @@ func (r *ItemRepository) SearchByEmbedding(ctx context.Context, queryEmbedding [
 	vecStr := float32sToVectorString(queryEmbedding)
 
 	rows, err := r.db.Pool.Query(ctx, `
-		SELECT i.id, i.source_id, i.title, i.url, i.published_at, i.content_hash,
-		       i.domain, i.category, i.raw_excerpt, i.created_at,
+		SELECT `+itemColumns+`,
 		       1 - (i.embedding <=> $1::vector) AS similarity
 		FROM items i
 		WHERE i.embedding IS NOT NULL
@@ -370,11 +774,7 @@ func (r *ItemRepository) SearchByEmbedding(ctx context.Context, queryEmbedding [
 	var results []models.ScoredItem
 	for rows.Next() {
 		var si models.ScoredItem
-		err := rows.Scan(
-			&si.ID, &si.SourceID, &si.Title, &si.URL, &si.PublishedAt,
-			&si.ContentHash, &si.Domain, &si.Category, &si.RawExcerpt,
-			&si.CreatedAt, &si.Similarity,
-		)
+		err := rows.Scan(append(itemFields(&si.Item), &si.Similarity)...)
 		if err != nil {
 			return nil, err
 		}
@@ -398,17 +798,27 @@ func (r *ItemRepository) HasEmbedding(ctx context.Context, itemID uuid.UUID) (bo
 	return hasEmbed, nil
 }
 
-// GetItemsNeedingScoring returns items that need score computation or recalculation
-func (r *ItemRepository) GetItemsNeedingScoring(ctx context.Context, days int, limit int) ([]models.Item, error) {
+// GetItemsNeedingScoring returns items that need score computation or recalculation,
+// including items last scored under a scoring config other than configVersion
+// and items whose story gained coverage since they were scored
+func (r *ItemRepository) GetItemsNeedingScoring(ctx context.Context, days int, limit int, configVersion string) ([]models.Item, error) {
 	rows, err := r.db.Pool.Query(ctx, `
-		SELECT i.id, i.source_id, i.title, i.url, i.published_at, i.content_hash,
-		       i.domain, i.category, i.raw_excerpt, i.created_at
+		SELECT `+itemColumns+`
 		FROM items i
 		LEFT JOIN scores s ON s.item_id = i.id
 		WHERE i.published_at >= now() - interval '1 day' * $1
-		AND (s.item_id IS NULL OR s.computed_at < i.created_at)
+		AND (s.item_id IS NULL OR s.computed_at < i.created_at OR s.config_version IS DISTINCT FROM $3
+			OR EXISTS (
+				SELECT 1 FROM items d
+				WHERE d.duplicate_of = COALESCE(i.duplicate_of, i.id) AND d.created_at > s.computed_at
+			)
+			OR EXISTS (
+				SELECT 1 FROM cluster_sources cs
+				JOIN cluster_sources cm ON cm.cluster_id = cs.cluster_id
+				WHERE cs.article_id = i.id AND cm.assigned_at > s.computed_at
+			))
 		LIMIT $2
-	`, days, limit)
+	`, days, limit, configVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -417,11 +827,7 @@ func (r *ItemRepository) GetItemsNeedingScoring(ctx context.Context, days int, l
 	var items []models.Item
 	for rows.Next() {
 		var item models.Item
-		err := rows.Scan(
-			&item.ID, &item.SourceID, &item.Title, &item.URL, &item.PublishedAt,
-			&item.ContentHash, &item.Domain, &item.Category, &item.RawExcerpt,
-			&item.CreatedAt,
-		)
+		err := rows.Scan(itemFields(&item)...)
 		if err != nil {
 			continue
 		}
@@ -469,6 +875,21 @@ func (r *SignalRepository) GetLatestByItemID(ctx context.Context, itemID uuid.UU
 	return &sig, nil
 }
 
+// UpsertVelocity stores the latest velocity computed for an item.
+func (r *SignalRepository) UpsertVelocity(ctx context.Context, v models.ItemVelocity) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO item_velocity (item_id, engagement, velocity, acceleration, samples, computed_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (item_id) DO UPDATE SET
+			engagement = EXCLUDED.engagement,
+			velocity = EXCLUDED.velocity,
+			acceleration = EXCLUDED.acceleration,
+			samples = EXCLUDED.samples,
+			computed_at = now()
+	`, v.ItemID, v.Engagement, v.Velocity, v.Acceleration, v.Samples)
+	return err
+}
+
 func (r *SignalRepository) GetRisingSignals(ctx context.Context, itemID uuid.UUID, window time.Duration) ([]models.Signal, error) {
 	cutoff := time.Now().Add(-window)
 	rows, err := r.db.Pool.Query(ctx, `
@@ -505,9 +926,23 @@ func NewScoreRepository(db *DB) *ScoreRepository {
 }
 
 func (r *ScoreRepository) Upsert(ctx context.Context, score *models.Score) error {
+	var explanation []byte
+	if score.Explanation != nil {
+		var err error
+		if explanation, err = json.Marshal(score.Explanation); err != nil {
+			return err
+		}
+	}
+
+	var llmNovelty, llmImpact, llmEngineering *float64
+	if r := score.LLMRatings; r != nil {
+		llmNovelty, llmImpact, llmEngineering = &r.Novelty, &r.Impact, &r.EngineeringValue
+	}
+
 	_, err := r.db.Pool.Exec(ctx, `
-		INSERT INTO scores (item_id, hot, relevance, credibility, novelty, impact, engineering_value, reasoning, final)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO scores (item_id, hot, relevance, credibility, novelty, impact, engineering_value, reasoning, final, config_version, explanation, algorithm_version,
+		                    llm_novelty, llm_impact, llm_engineering_value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, NULLIF($12, ''), $13, $14, $15)
 		ON CONFLICT (item_id) DO UPDATE SET
 			hot = EXCLUDED.hot,
 			relevance = EXCLUDED.relevance,
@@ -517,37 +952,172 @@ func (r *ScoreRepository) Upsert(ctx context.Context, score *models.Score) error
 			engineering_value = EXCLUDED.engineering_value,
 			reasoning = EXCLUDED.reasoning,
 			final = EXCLUDED.final,
+			config_version = COALESCE(EXCLUDED.config_version, scores.config_version),
+			explanation = COALESCE(EXCLUDED.explanation, scores.explanation),
+			algorithm_version = COALESCE(EXCLUDED.algorithm_version, scores.algorithm_version),
+			llm_novelty = COALESCE(EXCLUDED.llm_novelty, scores.llm_novelty),
+			llm_impact = COALESCE(EXCLUDED.llm_impact, scores.llm_impact),
+			llm_engineering_value = COALESCE(EXCLUDED.llm_engineering_value, scores.llm_engineering_value),
 			computed_at = now()
-	`, score.ItemID, score.Hot, score.Relevance, score.Credibility, score.Novelty, score.Impact, score.EngineeringValue, score.Reasoning, score.Final)
+	`, score.ItemID, score.Hot, score.Relevance, score.Credibility, score.Novelty, score.Impact, score.EngineeringValue, score.Reasoning, score.Final, score.ConfigVersion, explanation, score.AlgorithmVersion,
+		llmNovelty, llmImpact, llmEngineering)
 	return err
 }
 
-func (r *ScoreRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) (*models.Score, error) {
-	var score models.Score
-	err := r.db.Pool.QueryRow(ctx, `
-		SELECT item_id, hot, relevance, credibility, novelty, impact, engineering_value, reasoning, final, computed_at
-		FROM scores
-		WHERE item_id = $1
-	`, itemID).Scan(
-		&score.ItemID, &score.Hot, &score.Relevance, &score.Credibility,
-		&score.Novelty, &score.Impact, &score.EngineeringValue, &score.Reasoning,
-		&score.Final, &score.ComputedAt,
-	)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, nil
+// SetLLMRatings stores the LLM's ratings and reasoning for an item without
+// touching its computed score; the next scoring pass picks them up. An item
+// without a score gets a zero placeholder row.
+func (r *ScoreRepository) SetLLMRatings(ctx context.Context, itemID uuid.UUID, ratings models.LLMRatings, reasoning string) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO scores (item_id, hot, relevance, credibility, novelty, final, reasoning, llm_novelty, llm_impact, llm_engineering_value)
+		VALUES ($1, 0, 0, 0, 0, 0, $2, $3, $4, $5)
+		ON CONFLICT (item_id) DO UPDATE SET
+			reasoning = EXCLUDED.reasoning,
+			llm_novelty = EXCLUDED.llm_novelty,
+			llm_impact = EXCLUDED.llm_impact,
+			llm_engineering_value = EXCLUDED.llm_engineering_value
+	`, itemID, reasoning, ratings.Novelty, ratings.Impact, ratings.EngineeringValue)
+	return err
+}
+
+// Record stores a freshly computed score and appends it to the item's score
+// history unless it matches the latest entry. Use Upsert for partial updates
+// that are not a recomputation.
+func (r *ScoreRepository) Record(ctx context.Context, score *models.Score) error {
+	if err := r.Upsert(ctx, score); err != nil {
+		return err
 	}
+	// Rescoring an item without any change in its inputs, config or code
+	// leaves no new row, so the history only grows when something moved.
+	_, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO score_history (item_id, hot, relevance, credibility, novelty, impact, engineering_value, final, algorithm_version, config_version)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT * FROM score_history WHERE item_id = $1 ORDER BY computed_at DESC LIMIT 1
+			) last
+			WHERE (last.hot, last.relevance, last.credibility, last.novelty, last.impact, last.engineering_value, last.final)
+			    = ($2, $3, $4, $5, $6, $7, $8)
+			AND last.algorithm_version = $9 AND last.config_version = $10
+		)
+	`, score.ItemID, score.Hot, score.Relevance, score.Credibility, score.Novelty, score.Impact, score.EngineeringValue, score.Final, score.AlgorithmVersion, score.ConfigVersion)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to record score history: %w", err)
 	}
-	return &score, nil
+	return nil
 }
 
-type SummaryRepository struct {
-	db *DB
-}
+// GetHistory returns the item's latest limit score recomputations, oldest
+// first.
+func (r *ScoreRepository) GetHistory(ctx context.Context, itemID uuid.UUID, limit int) ([]models.ScoreHistory, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT * FROM (
+			SELECT id, item_id, hot, relevance, credibility, novelty, impact, engineering_value, final,
+			       algorithm_version, config_version, computed_at
+			FROM score_history
+			WHERE item_id = $1
+			ORDER BY computed_at DESC
+			LIMIT $2
+		) h
+		ORDER BY computed_at
+	`, itemID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-func NewSummaryRepository(db *DB) *SummaryRepository {
-	return &SummaryRepository{db: db}
+	var history []models.ScoreHistory
+	for rows.Next() {
+		var h models.ScoreHistory
+		if err := rows.Scan(
+			&h.ID, &h.ItemID, &h.Hot, &h.Relevance, &h.Credibility, &h.Novelty, &h.Impact,
+			&h.EngineeringValue, &h.Final, &h.AlgorithmVersion, &h.ConfigVersion, &h.ComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+// ListForDay returns the last score each item published on the UTC day
+// starting at day got under configVersion, with the item title.
+func (r *ScoreRepository) ListForDay(ctx context.Context, configVersion string, day time.Time) ([]models.ScoreHistory, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT DISTINCT ON (h.item_id)
+		       h.id, h.item_id, i.title, h.hot, h.relevance, h.credibility, h.novelty, h.impact,
+		       h.engineering_value, h.final, h.algorithm_version, h.config_version, h.computed_at
+		FROM score_history h
+		JOIN items i ON i.id = h.item_id
+		WHERE h.config_version = $1
+		AND i.published_at >= $2 AND i.published_at < $2 + interval '1 day'
+		ORDER BY h.item_id, h.computed_at DESC
+	`, configVersion, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []models.ScoreHistory
+	for rows.Next() {
+		var h models.ScoreHistory
+		if err := rows.Scan(
+			&h.ID, &h.ItemID, &h.Title, &h.Hot, &h.Relevance, &h.Credibility, &h.Novelty, &h.Impact,
+			&h.EngineeringValue, &h.Final, &h.AlgorithmVersion, &h.ConfigVersion, &h.ComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		scores = append(scores, h)
+	}
+	return scores, rows.Err()
+}
+
+func (r *ScoreRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) (*models.Score, error) {
+	var score models.Score
+	var explanation []byte
+	var llmNovelty, llmImpact, llmEngineering *float64
+	err := r.db.Pool.QueryRow(ctx, `
+		SELECT item_id, hot, relevance, credibility, novelty, impact, engineering_value, reasoning, final,
+		       COALESCE(config_version, ''), COALESCE(algorithm_version, ''), explanation, computed_at,
+		       llm_novelty, llm_impact, llm_engineering_value
+		FROM scores
+		WHERE item_id = $1
+	`, itemID).Scan(
+		&score.ItemID, &score.Hot, &score.Relevance, &score.Credibility,
+		&score.Novelty, &score.Impact, &score.EngineeringValue, &score.Reasoning,
+		&score.Final, &score.ConfigVersion, &score.AlgorithmVersion, &explanation, &score.ComputedAt,
+		&llmNovelty, &llmImpact, &llmEngineering,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if llmImpact != nil {
+		score.LLMRatings = &models.LLMRatings{Impact: *llmImpact}
+		if llmNovelty != nil {
+			score.LLMRatings.Novelty = *llmNovelty
+		}
+		if llmEngineering != nil {
+			score.LLMRatings.EngineeringValue = *llmEngineering
+		}
+	}
+	if explanation != nil {
+		score.Explanation = &models.ScoreExplanation{}
+		if err := json.Unmarshal(explanation, score.Explanation); err != nil {
+			return nil, fmt.Errorf("invalid explanation for score %s: %w", itemID, err)
+		}
+	}
+	return &score, nil
+}
+
+type SummaryRepository struct {
+	db *DB
+}
+
+func NewSummaryRepository(db *DB) *SummaryRepository {
+	return &SummaryRepository{db: db}
 }
 
 func (r *SummaryRepository) Upsert(ctx context.Context, summary *models.Summary) error {
@@ -555,28 +1125,47 @@ func (r *SummaryRepository) Upsert(ctx context.Context, summary *models.Summary)
 	if err != nil {
 		return err
 	}
+	tagScores := summary.TagScores
+	if tagScores == nil {
+		tagScores = []models.TagScore{}
+	}
+	tagScoresJSON, err := json.Marshal(tagScores)
+	if err != nil {
+		return err
+	}
 	_, err = r.db.Pool.Exec(ctx, `
-		INSERT INTO summaries (item_id, tldr, why_it_matters, tags, method)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO summaries (item_id, tldr, why_it_matters, tags, method, tag_scores)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (item_id) DO UPDATE SET
 			tldr = EXCLUDED.tldr,
 			why_it_matters = EXCLUDED.why_it_matters,
 			tags = EXCLUDED.tags,
-			method = EXCLUDED.method
-	`, summary.ItemID, summary.TLDR, summary.WhyItMatters, tagsJSON, summary.Method)
+			method = EXCLUDED.method,
+			tag_scores = EXCLUDED.tag_scores,
+			-- A translation only stays valid while the text it was made from does.
+			translated_lang = CASE WHEN summaries.tldr = EXCLUDED.tldr AND summaries.why_it_matters = EXCLUDED.why_it_matters
+				THEN summaries.translated_lang END,
+			translated_tldr = CASE WHEN summaries.tldr = EXCLUDED.tldr AND summaries.why_it_matters = EXCLUDED.why_it_matters
+				THEN summaries.translated_tldr END,
+			translated_why_it_matters = CASE WHEN summaries.tldr = EXCLUDED.tldr AND summaries.why_it_matters = EXCLUDED.why_it_matters
+				THEN summaries.translated_why_it_matters END
+	`, summary.ItemID, summary.TLDR, summary.WhyItMatters, tagsJSON, summary.Method, tagScoresJSON)
 	return err
 }
 
 func (r *SummaryRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) (*models.Summary, error) {
 	var summary models.Summary
-	var tagsJSON []byte
+	var tagsJSON, tagScoresJSON []byte
+	var trLang, trTLDR, trWhy *string
 	err := r.db.Pool.QueryRow(ctx, `
-		SELECT item_id, tldr, why_it_matters, tags, method, created_at
+		SELECT item_id, tldr, why_it_matters, tags, method, created_at, tag_scores,
+		       translated_lang, translated_tldr, translated_why_it_matters
 		FROM summaries
 		WHERE item_id = $1
 	`, itemID).Scan(
 		&summary.ItemID, &summary.TLDR, &summary.WhyItMatters,
-		&tagsJSON, &summary.Method, &summary.CreatedAt,
+		&tagsJSON, &summary.Method, &summary.CreatedAt, &tagScoresJSON,
+		&trLang, &trTLDR, &trWhy,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
@@ -587,9 +1176,28 @@ func (r *SummaryRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) (
 	if err := json.Unmarshal(tagsJSON, &summary.Tags); err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal(tagScoresJSON, &summary.TagScores); err != nil {
+		return nil, err
+	}
+	if trLang != nil && trTLDR != nil {
+		summary.Translation = &models.SummaryTranslation{Lang: *trLang, TLDR: *trTLDR}
+		if trWhy != nil {
+			summary.Translation.WhyItMatters = *trWhy
+		}
+	}
 	return &summary, nil
 }
 
+// SetTranslation stores t alongside the original summary of itemID.
+func (r *SummaryRepository) SetTranslation(ctx context.Context, itemID uuid.UUID, t models.SummaryTranslation) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE summaries
+		SET translated_lang = $2, translated_tldr = $3, translated_why_it_matters = $4
+		WHERE item_id = $1
+	`, itemID, t.Lang, t.TLDR, t.WhyItMatters)
+	return err
+}
+
 type FetchRunRepository struct {
 	db *DB
 }
@@ -600,15 +1208,234 @@ func NewFetchRunRepository(db *DB) *FetchRunRepository {
 
 func (r *FetchRunRepository) Create(ctx context.Context, run *models.FetchRun) error {
 	err := r.db.Pool.QueryRow(ctx, `
-		INSERT INTO fetch_runs (source_id, status, error, items_fetched, items_inserted)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO fetch_runs (source_id, status, error, items_fetched, items_inserted, crawl_run_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, fetched_at
-	`, run.SourceID, run.Status, run.Error, run.ItemsFetched, run.ItemsInserted).Scan(
+	`, run.SourceID, run.Status, run.Error, run.ItemsFetched, run.ItemsInserted, run.CrawlRunID).Scan(
 		&run.ID, &run.FetchedAt,
 	)
 	return err
 }
 
+// RecentInserted returns items_inserted of the last n successful runs of
+// sourceID, newest first.
+func (r *FetchRunRepository) RecentInserted(ctx context.Context, sourceID uuid.UUID, n int) ([]int, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT items_inserted
+		FROM fetch_runs
+		WHERE source_id = $1 AND status = 'success'
+		ORDER BY fetched_at DESC
+		LIMIT $2
+	`, sourceID, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []int
+	for rows.Next() {
+		var c int
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// ListByCrawlRun returns the fetch runs recorded during crawl run crawlRunID.
+func (r *FetchRunRepository) ListByCrawlRun(ctx context.Context, crawlRunID uuid.UUID) ([]models.FetchRun, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT id, source_id, fetched_at, status, error, items_fetched, items_inserted, crawl_run_id
+		FROM fetch_runs
+		WHERE crawl_run_id = $1
+		ORDER BY fetched_at
+	`, crawlRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []models.FetchRun
+	for rows.Next() {
+		var fr models.FetchRun
+		if err := rows.Scan(&fr.ID, &fr.SourceID, &fr.FetchedAt, &fr.Status, &fr.Error,
+			&fr.ItemsFetched, &fr.ItemsInserted, &fr.CrawlRunID); err != nil {
+			return nil, err
+		}
+		runs = append(runs, fr)
+	}
+	return runs, rows.Err()
+}
+
+type CrawlRunRepository struct {
+	db *DB
+}
+
+func NewCrawlRunRepository(db *DB) *CrawlRunRepository {
+	return &CrawlRunRepository{db: db}
+}
+
+const crawlRunColumns = `id, trigger_type, status, instance_id, started_at, finished_at,
+		       discovered, accepted, rejected, duplicates, inserted, error, sources`
+
+func scanCrawlRun(row pgx.Row) (*models.CrawlRun, error) {
+	var run models.CrawlRun
+	var sources []byte
+	err := row.Scan(
+		&run.ID, &run.TriggerType, &run.Status, &run.InstanceID, &run.StartedAt, &run.FinishedAt,
+		&run.Discovered, &run.Accepted, &run.Rejected, &run.Duplicates, &run.Inserted, &run.Error, &sources,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(sources, &run.Sources); err != nil {
+		return nil, fmt.Errorf("invalid sources for crawl run %s: %w", run.ID, err)
+	}
+	return &run, nil
+}
+
+// Create inserts run in the running state and fills in its ID and start time.
+func (r *CrawlRunRepository) Create(ctx context.Context, run *models.CrawlRun) error {
+	run.Status = "running"
+	return r.db.Pool.QueryRow(ctx, `
+		INSERT INTO crawl_runs (trigger_type, status, instance_id)
+		VALUES ($1, $2, $3)
+		RETURNING id, started_at
+	`, run.TriggerType, run.Status, run.InstanceID).Scan(&run.ID, &run.StartedAt)
+}
+
+// Finish stores the final status, counts and per-source stats of run.
+func (r *CrawlRunRepository) Finish(ctx context.Context, run *models.CrawlRun) error {
+	sources := run.Sources
+	if sources == nil {
+		sources = []models.CrawlRunSource{}
+	}
+	sourcesJSON, err := json.Marshal(sources)
+	if err != nil {
+		return fmt.Errorf("failed to encode crawl run sources: %w", err)
+	}
+
+	return r.db.Pool.QueryRow(ctx, `
+		UPDATE crawl_runs
+		SET status = $2, finished_at = now(), discovered = $3, accepted = $4,
+		    rejected = $5, duplicates = $6, inserted = $7, error = $8, sources = $9
+		WHERE id = $1
+		RETURNING finished_at
+	`, run.ID, run.Status, run.Discovered, run.Accepted, run.Rejected, run.Duplicates, run.Inserted,
+		run.Error, sourcesJSON).Scan(&run.FinishedAt)
+}
+
+// List returns crawl runs, newest first, optionally filtered by trigger type.
+func (r *CrawlRunRepository) List(ctx context.Context, triggerType string, limit, offset int) ([]models.CrawlRun, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+crawlRunColumns+`
+		FROM crawl_runs
+		WHERE ($1 = '' OR trigger_type = $1)
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3
+	`, triggerType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []models.CrawlRun
+	for rows.Next() {
+		run, err := scanCrawlRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *CrawlRunRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CrawlRun, error) {
+	run, err := scanCrawlRun(r.db.Pool.QueryRow(ctx, `SELECT `+crawlRunColumns+` FROM crawl_runs WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return run, err
+}
+
+// AverageSourceDurations returns the mean successful duration per source
+// over the last runs finished crawl runs.
+func (r *CrawlRunRepository) AverageSourceDurations(ctx context.Context, runs int) (map[uuid.UUID]time.Duration, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT (s->>'source_id')::uuid, AVG((s->>'duration_ms')::bigint)::bigint
+		FROM (
+			SELECT sources FROM crawl_runs
+			WHERE finished_at IS NOT NULL
+			ORDER BY started_at DESC
+			LIMIT $1
+		) recent, jsonb_array_elements(recent.sources) s
+		WHERE s->>'source_id' IS NOT NULL AND s->>'error' IS NULL
+		GROUP BY 1
+	`, runs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	durations := make(map[uuid.UUID]time.Duration)
+	for rows.Next() {
+		var id uuid.UUID
+		var ms int64
+		if err := rows.Scan(&id, &ms); err != nil {
+			return nil, err
+		}
+		durations[id] = time.Duration(ms) * time.Millisecond
+	}
+	return durations, rows.Err()
+}
+
+// Latest returns the most recently started run that has finished.
+func (r *CrawlRunRepository) Latest(ctx context.Context) (*models.CrawlRun, error) {
+	run, err := scanCrawlRun(r.db.Pool.QueryRow(ctx, `
+		SELECT `+crawlRunColumns+`
+		FROM crawl_runs
+		WHERE finished_at IS NOT NULL
+		ORDER BY started_at DESC
+		LIMIT 1
+	`))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return run, err
+}
+
+// ScoringConfigRepository stores every scoring config that was active, by
+// version (see scoring.SnapshotStore).
+type ScoringConfigRepository struct {
+	db *DB
+}
+
+func NewScoringConfigRepository(db *DB) *ScoringConfigRepository {
+	return &ScoringConfigRepository{db: db}
+}
+
+// Save stores a config snapshot. Versions are content hashes, so an existing
+// row is never replaced.
+func (r *ScoringConfigRepository) Save(ctx context.Context, version, algorithmVersion, config string) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO scoring_configs (version, algorithm_version, config)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (version) DO NOTHING
+	`, version, algorithmVersion, config)
+	return err
+}
+
+// Get returns the snapshot stored for version, or "" if there is none.
+func (r *ScoringConfigRepository) Get(ctx context.Context, version string) (string, error) {
+	var config string
+	err := r.db.Pool.QueryRow(ctx, `SELECT config FROM scoring_configs WHERE version = $1`, version).Scan(&config)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return config, err
+}
+
 type SettingRepository struct {
 	db *DB
 }
@@ -652,3 +1479,790 @@ func (r *SettingRepository) List(ctx context.Context) (map[string]string, error)
 	}
 	return settings, rows.Err()
 }
+
+type AgentConfigRepository struct {
+	db *DB
+}
+
+func NewAgentConfigRepository(db *DB) *AgentConfigRepository {
+	return &AgentConfigRepository{db: db}
+}
+
+const agentConfigColumns = `id, name, type, enabled, max_items, params, schedule, last_run_at, created_at, updated_at`
+
+func (r *AgentConfigRepository) List(ctx context.Context) ([]models.AgentConfig, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+agentConfigColumns+` FROM agent_configs ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAgentConfigs(rows)
+}
+
+func (r *AgentConfigRepository) GetEnabled(ctx context.Context) ([]models.AgentConfig, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+agentConfigColumns+` FROM agent_configs WHERE enabled = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAgentConfigs(rows)
+}
+
+func (r *AgentConfigRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AgentConfig, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+agentConfigColumns+` FROM agent_configs WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	configs, err := r.scanAgentConfigs(rows)
+	if err != nil || len(configs) == 0 {
+		return nil, err
+	}
+	return &configs[0], nil
+}
+
+func (r *AgentConfigRepository) scanAgentConfigs(rows pgx.Rows) ([]models.AgentConfig, error) {
+	var configs []models.AgentConfig
+	for rows.Next() {
+		var a models.AgentConfig
+		var paramsJSON []byte
+		err := rows.Scan(
+			&a.ID, &a.Name, &a.Type, &a.Enabled, &a.MaxItems, &paramsJSON,
+			&a.Schedule, &a.LastRunAt, &a.CreatedAt, &a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(paramsJSON) > 0 {
+			if err := json.Unmarshal(paramsJSON, &a.Params); err != nil {
+				return nil, fmt.Errorf("invalid params for agent %s: %w", a.Name, err)
+			}
+		}
+		configs = append(configs, a)
+	}
+	return configs, rows.Err()
+}
+
+func (r *AgentConfigRepository) Create(ctx context.Context, a *models.AgentConfig) error {
+	paramsJSON, err := json.Marshal(a.Params)
+	if err != nil {
+		return err
+	}
+	return r.db.Pool.QueryRow(ctx, `
+		INSERT INTO agent_configs (name, type, enabled, max_items, params, schedule)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`, a.Name, a.Type, a.Enabled, a.MaxItems, paramsJSON, a.Schedule).Scan(
+		&a.ID, &a.CreatedAt, &a.UpdatedAt,
+	)
+}
+
+func (r *AgentConfigRepository) Update(ctx context.Context, a *models.AgentConfig) error {
+	paramsJSON, err := json.Marshal(a.Params)
+	if err != nil {
+		return err
+	}
+	err = r.db.Pool.QueryRow(ctx, `
+		UPDATE agent_configs
+		SET name = $1, type = $2, enabled = $3, max_items = $4, params = $5, schedule = $6, updated_at = now()
+		WHERE id = $7
+		RETURNING updated_at
+	`, a.Name, a.Type, a.Enabled, a.MaxItems, paramsJSON, a.Schedule, a.ID).Scan(&a.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("agent config %s not found", a.ID)
+	}
+	return err
+}
+
+func (r *AgentConfigRepository) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM agent_configs WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// MarkRun records that the agent was dispatched at t.
+func (r *AgentConfigRepository) MarkRun(ctx context.Context, id uuid.UUID, t time.Time) error {
+	_, err := r.db.Pool.Exec(ctx, `UPDATE agent_configs SET last_run_at = $1 WHERE id = $2`, t, id)
+	return err
+}
+
+type JobRepository struct {
+	db *DB
+}
+
+func NewJobRepository(db *DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+const jobColumns = `id, type, dedup_key, payload, status, attempts, max_attempts, next_run_at,
+		       last_error, locked_by, locked_at, created_at, updated_at`
+
+func (r *JobRepository) scanJobs(rows pgx.Rows) ([]models.Job, error) {
+	var jobs []models.Job
+	for rows.Next() {
+		var j models.Job
+		err := rows.Scan(
+			&j.ID, &j.Type, &j.DedupKey, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts,
+			&j.NextRunAt, &j.LastError, &j.LockedBy, &j.LockedAt, &j.CreatedAt, &j.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// Enqueue adds a pending job, runnable from runAt (now if nil), unless a
+// live job with the same type and dedupKey already exists. It reports
+// whether a new job was created.
+func (r *JobRepository) Enqueue(ctx context.Context, jobType, dedupKey string, payload any, maxAttempts int, runAt *time.Time) (bool, error) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+	tag, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO jobs (type, dedup_key, payload, max_attempts, next_run_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, now()))
+		ON CONFLICT (type, dedup_key) WHERE status IN ('pending', 'running') DO NOTHING
+	`, jobType, dedupKey, payloadJSON, maxAttempts, runAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// EnqueueSpaced adds pending jobs of jobType on the shared schedule named
+// schedule (the payloads' "schedule" field): the first runs spacing after
+// the latest live job already on it, or now, and each next one spacing
+// later. Callers are serialized with an advisory lock so concurrent
+// schedules queue behind each other instead of overlapping. Payloads whose
+// dedupKey already has a live job are skipped without taking a slot. It
+// returns how many jobs were created.
+func (r *JobRepository) EnqueueSpaced(ctx context.Context, jobType, schedule string, dedupKeys []string, payloads []any, maxAttempts int, spacing time.Duration) (int, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, advisoryKey("jobs:schedule:"+schedule)); err != nil {
+		return 0, fmt.Errorf("failed to lock schedule %s: %w", schedule, err)
+	}
+
+	var last *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT max(next_run_at) FROM jobs
+		WHERE type = $1 AND status IN ('pending', 'running') AND payload->>'schedule' = $2
+	`, jobType, schedule).Scan(&last)
+	if err != nil {
+		return 0, err
+	}
+	next := time.Now()
+	if last != nil && last.Add(spacing).After(next) {
+		next = last.Add(spacing)
+	}
+
+	queued := 0
+	for i, key := range dedupKeys {
+		payloadJSON, err := json.Marshal(payloads[i])
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode job payload: %w", err)
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO jobs (type, dedup_key, payload, max_attempts, next_run_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (type, dedup_key) WHERE status IN ('pending', 'running') DO NOTHING
+		`, jobType, key, payloadJSON, maxAttempts, next)
+		if err != nil {
+			return 0, err
+		}
+		if tag.RowsAffected() > 0 {
+			queued++
+			next = next.Add(spacing)
+		}
+	}
+	return queued, tx.Commit(ctx)
+}
+
+// Claim marks up to limit runnable jobs as running for owner and returns
+// them. Jobs locked by another claimer are skipped; running jobs whose lock
+// is older than stale are treated as abandoned and claimed again.
+func (r *JobRepository) Claim(ctx context.Context, owner string, types []string, stale time.Duration, limit int) ([]models.Job, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		UPDATE jobs
+		SET status = 'running', locked_by = $1, locked_at = now(), attempts = attempts + 1, updated_at = now()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE type = ANY($2)
+			  AND ((status = 'pending' AND next_run_at <= now())
+			    OR (status = 'running' AND locked_at < now() - make_interval(secs => $3)))
+			ORDER BY next_run_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns+`
+	`, owner, types, stale.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanJobs(rows)
+}
+
+// Complete marks job id as done.
+func (r *JobRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = 'done', last_error = NULL, locked_by = NULL, locked_at = NULL, updated_at = now()
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// Fail records a failed attempt. The job is retried at nextRunAt, or moved
+// to the dead-letter state when dead is true.
+func (r *JobRepository) Fail(ctx context.Context, id uuid.UUID, lastErr string, nextRunAt time.Time, dead bool) error {
+	status := "pending"
+	if dead {
+		status = "dead"
+	}
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = $1, last_error = $2, next_run_at = $3, locked_by = NULL, locked_at = NULL, updated_at = now()
+		WHERE id = $4
+	`, status, lastErr, nextRunAt, id)
+	return err
+}
+
+// Release returns running jobs to pending without using up the attempt
+// Claim took, e.g. because the runner stopped before or while running them.
+// They keep their place in the queue.
+func (r *JobRepository) Release(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.Pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = 'pending', attempts = GREATEST(attempts - 1, 0), locked_by = NULL, locked_at = NULL, updated_at = now()
+		WHERE id = ANY($1) AND status = 'running'
+	`, ids)
+	return err
+}
+
+// List returns jobs filtered by status and type (empty matches all), newest first.
+func (r *JobRepository) List(ctx context.Context, status, jobType string, limit, offset int) ([]models.Job, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR type = $2)
+		ORDER BY updated_at DESC
+		LIMIT $3 OFFSET $4
+	`, status, jobType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanJobs(rows)
+}
+
+// CountByStatus returns the number of jobs per type and status.
+func (r *JobRepository) CountByStatus(ctx context.Context) (map[string]map[string]int, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT type, status, COUNT(*) FROM jobs GROUP BY type, status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]map[string]int)
+	for rows.Next() {
+		var jobType, status string
+		var n int
+		if err := rows.Scan(&jobType, &status, &n); err != nil {
+			return nil, err
+		}
+		if counts[jobType] == nil {
+			counts[jobType] = make(map[string]int)
+		}
+		counts[jobType][status] = n
+	}
+	return counts, rows.Err()
+}
+
+// Requeue resets a dead or finished job to pending with a fresh attempt
+// budget. It reports false if the job does not exist, is still live, or a
+// live duplicate already exists.
+func (r *JobRepository) Requeue(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `
+		UPDATE jobs j
+		SET status = 'pending', attempts = 0, next_run_at = now(), updated_at = now()
+		WHERE j.id = $1
+		  AND j.status IN ('dead', 'done')
+		  AND NOT EXISTS (
+			SELECT 1 FROM jobs o
+			WHERE o.type = j.type AND o.dedup_key = j.dedup_key AND o.status IN ('pending', 'running')
+		  )
+	`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// RequeueDead requeues every dead job, optionally only of jobType, and
+// returns how many were requeued.
+func (r *JobRepository) RequeueDead(ctx context.Context, jobType string) (int64, error) {
+	tag, err := r.db.Pool.Exec(ctx, `
+		UPDATE jobs j
+		SET status = 'pending', attempts = 0, next_run_at = now(), updated_at = now()
+		WHERE j.id IN (
+			SELECT DISTINCT ON (type, dedup_key) id FROM jobs
+			WHERE status = 'dead' AND ($1 = '' OR type = $1)
+			ORDER BY type, dedup_key, updated_at DESC
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM jobs o
+			WHERE o.type = j.type AND o.dedup_key = j.dedup_key AND o.status IN ('pending', 'running')
+		  )
+	`, jobType)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+type FeedbackRepository struct {
+	db *DB
+}
+
+func NewFeedbackRepository(db *DB) *FeedbackRepository {
+	return &FeedbackRepository{db: db}
+}
+
+func (r *FeedbackRepository) Create(ctx context.Context, fb *models.ItemFeedback) error {
+	return r.db.Pool.QueryRow(ctx, `
+		INSERT INTO item_feedback (item_id, vote, user_ref, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, fb.ItemID, fb.Vote, fb.User, fb.Note).Scan(&fb.ID, &fb.CreatedAt)
+}
+
+type ReputationRepository struct {
+	db *DB
+}
+
+func NewReputationRepository(db *DB) *ReputationRepository {
+	return &ReputationRepository{db: db}
+}
+
+// AggregateStats counts, per domain or per source, the history of items
+// published since: LLM quality, duplicates, top-decile placements and team
+// votes. LLM quality comes from the stored LLM ratings.
+func (r *ReputationRepository) AggregateStats(ctx context.Context, kind string, since time.Time, topPercentile float64) ([]models.ReputationStats, error) {
+	key := "i.domain"
+	if kind == models.ReputationSource {
+		key = "i.source_id::text"
+	}
+
+	rows, err := r.db.Pool.Query(ctx, `
+		WITH ranked AS (
+			SELECT i.id, `+key+` AS key, i.duplicate_of, s.item_id IS NOT NULL AS scored,
+			       COALESCE(s.llm_impact > 0, false) AS llm,
+			       (s.llm_impact + s.llm_engineering_value) / 20 AS llm_quality,
+			       PERCENT_RANK() OVER (
+			           PARTITION BY date_trunc('day', i.published_at)
+			           ORDER BY s.final ASC NULLS FIRST
+			       ) AS pct
+			FROM items i
+			LEFT JOIN scores s ON s.item_id = i.id
+			WHERE i.published_at >= $1
+		), votes AS (
+			SELECT item_id,
+			       COUNT(*) FILTER (WHERE vote > 0) AS up,
+			       COUNT(*) FILTER (WHERE vote < 0) AS down
+			FROM item_feedback
+			GROUP BY item_id
+		)
+		SELECT r.key,
+		       COUNT(*)::int,
+		       COUNT(*) FILTER (WHERE r.scored)::int,
+		       COUNT(*) FILTER (WHERE r.llm)::int,
+		       COALESCE(SUM(LEAST(GREATEST(r.llm_quality, 0), 1)) FILTER (WHERE r.llm), 0),
+		       COUNT(*) FILTER (WHERE r.duplicate_of IS NOT NULL)::int,
+		       COUNT(*) FILTER (WHERE r.scored AND r.pct >= $2)::int,
+		       COALESCE(SUM(v.up), 0)::int,
+		       COALESCE(SUM(v.down), 0)::int
+		FROM ranked r
+		LEFT JOIN votes v ON v.item_id = r.id
+		WHERE r.key IS NOT NULL AND r.key <> ''
+		GROUP BY r.key
+	`, since, topPercentile)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []models.ReputationStats
+	for rows.Next() {
+		st := models.ReputationStats{Kind: kind}
+		if err := rows.Scan(&st.Key, &st.Items, &st.Scored, &st.LLMScored, &st.LLMQualitySum,
+			&st.Duplicates, &st.TopRanked, &st.Upvotes, &st.Downvotes); err != nil {
+			return nil, err
+		}
+		stats = append(stats, st)
+	}
+	return stats, rows.Err()
+}
+
+// Replace stores reps as the complete set of kind reputations, dropping keys
+// that no longer have history.
+func (r *ReputationRepository) Replace(ctx context.Context, kind string, reps []models.Reputation) error {
+	n := len(reps)
+	keys := make([]string, n)
+	items, ups, downs := make([]int32, n), make([]int32, n), make([]int32, n)
+	llm, dup, top, fb := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	score, cred := make([]float64, n), make([]float64, n)
+	for i, rep := range reps {
+		keys[i] = rep.Key
+		items[i], ups[i], downs[i] = int32(rep.Items), int32(rep.Upvotes), int32(rep.Downvotes)
+		llm[i], dup[i], top[i], fb[i] = rep.LLMQuality, rep.DuplicateRate, rep.TopRate, rep.FeedbackScore
+		score[i], cred[i] = rep.Score, rep.Credibility
+	}
+
+	_, err := r.db.Pool.Exec(ctx, `
+		WITH incoming AS (
+			SELECT * FROM unnest($2::text[], $3::int[], $4::float8[], $5::float8[], $6::float8[],
+			                     $7::float8[], $8::int[], $9::int[], $10::float8[], $11::float8[])
+			       AS t(key, items, llm_quality, duplicate_rate, top_rate, feedback_score, upvotes, downvotes, score, credibility)
+		), upserted AS (
+			INSERT INTO reputations (kind, key, items, llm_quality, duplicate_rate, top_rate, feedback_score,
+			                         upvotes, downvotes, score, credibility, computed_at)
+			SELECT $1, key, items, llm_quality, duplicate_rate, top_rate, feedback_score,
+			       upvotes, downvotes, score, credibility, now()
+			FROM incoming
+			ON CONFLICT (kind, key) DO UPDATE SET
+				items = EXCLUDED.items,
+				llm_quality = EXCLUDED.llm_quality,
+				duplicate_rate = EXCLUDED.duplicate_rate,
+				top_rate = EXCLUDED.top_rate,
+				feedback_score = EXCLUDED.feedback_score,
+				upvotes = EXCLUDED.upvotes,
+				downvotes = EXCLUDED.downvotes,
+				score = EXCLUDED.score,
+				credibility = EXCLUDED.credibility,
+				computed_at = now()
+		)
+		DELETE FROM reputations
+		WHERE kind = $1 AND key <> ALL($2::text[])
+	`, kind, keys, items, llm, dup, top, fb, ups, downs, score, cred)
+	return err
+}
+
+// List returns kind reputations, best first.
+func (r *ReputationRepository) List(ctx context.Context, kind string) ([]models.Reputation, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT kind, key, items, llm_quality, duplicate_rate, top_rate, feedback_score,
+		       upvotes, downvotes, score, credibility, computed_at
+		FROM reputations
+		WHERE kind = $1
+		ORDER BY score DESC, items DESC
+	`, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reps []models.Reputation
+	for rows.Next() {
+		var rep models.Reputation
+		if err := rows.Scan(&rep.Kind, &rep.Key, &rep.Items, &rep.LLMQuality, &rep.DuplicateRate, &rep.TopRate,
+			&rep.FeedbackScore, &rep.Upvotes, &rep.Downvotes, &rep.Score, &rep.Credibility, &rep.ComputedAt); err != nil {
+			return nil, err
+		}
+		reps = append(reps, rep)
+	}
+	return reps, rows.Err()
+}
+
+type TopicRepository struct {
+	db *DB
+}
+
+func NewTopicRepository(db *DB) *TopicRepository {
+	return &TopicRepository{db: db}
+}
+
+// List returns every topic of the taxonomy.
+func (r *TopicRepository) List(ctx context.Context) ([]models.Topic, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT slug, COALESCE(parent, ''), name, aliases, keywords, patterns, updated_at
+		FROM topics
+		ORDER BY slug
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var topics []models.Topic
+	for rows.Next() {
+		var t models.Topic
+		if err := rows.Scan(&t.Slug, &t.Parent, &t.Name, &t.Aliases, &t.Keywords, &t.Patterns, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		topics = append(topics, t)
+	}
+	return topics, rows.Err()
+}
+
+// SeedIfEmpty inserts topics when the table has none and reports whether it
+// did.
+func (r *TopicRepository) SeedIfEmpty(ctx context.Context, topics []models.Topic) (bool, error) {
+	payload, err := json.Marshal(topics)
+	if err != nil {
+		return false, err
+	}
+	tag, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO topics (slug, parent, name, aliases, keywords, patterns)
+		SELECT slug, NULLIF(parent, ''), name,
+		       COALESCE(aliases, '{}'), COALESCE(keywords, '{}'), COALESCE(patterns, '{}')
+		FROM jsonb_to_recordset($1::jsonb)
+		     AS t(slug text, parent text, name text, aliases text[], keywords text[], patterns text[])
+		WHERE NOT EXISTS (SELECT 1 FROM topics)
+		ON CONFLICT (slug) DO NOTHING
+	`, payload)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Upsert creates or replaces a topic and sets its UpdatedAt.
+func (r *TopicRepository) Upsert(ctx context.Context, t *models.Topic) error {
+	orEmpty := func(s []string) []string {
+		if s == nil {
+			return []string{}
+		}
+		return s
+	}
+	return r.db.Pool.QueryRow(ctx, `
+		INSERT INTO topics (slug, parent, name, aliases, keywords, patterns, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, now())
+		ON CONFLICT (slug) DO UPDATE SET
+			parent = EXCLUDED.parent,
+			name = EXCLUDED.name,
+			aliases = EXCLUDED.aliases,
+			keywords = EXCLUDED.keywords,
+			patterns = EXCLUDED.patterns,
+			updated_at = now()
+		RETURNING updated_at
+	`, t.Slug, t.Parent, t.Name, orEmpty(t.Aliases), orEmpty(t.Keywords), orEmpty(t.Patterns)).Scan(&t.UpdatedAt)
+}
+
+// Delete removes a topic and reports whether it existed. Topics with
+// children cannot be deleted.
+func (r *TopicRepository) Delete(ctx context.Context, slug string) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM topics WHERE slug = $1`, slug)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+type EntityRepository struct {
+	db *DB
+}
+
+func NewEntityRepository(db *DB) *EntityRepository {
+	return &EntityRepository{db: db}
+}
+
+const entityColumns = `e.id, e.slug, e.name, e.kind, e.aliases, e.case_sensitive, e.origin, e.created_at, e.updated_at`
+
+func entityFields(e *models.Entity) []any {
+	return []any{&e.ID, &e.Slug, &e.Name, &e.Kind, &e.Aliases, &e.CaseSensitive, &e.Origin, &e.CreatedAt, &e.UpdatedAt}
+}
+
+// List returns every entity, or those of one kind, ordered by name.
+func (r *EntityRepository) List(ctx context.Context, kind *string) ([]models.Entity, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+entityColumns+`
+		FROM entities e
+		WHERE $1::text IS NULL OR e.kind = $1
+		ORDER BY lower(e.name)
+	`, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entities []models.Entity
+	for rows.Next() {
+		var e models.Entity
+		if err := rows.Scan(entityFields(&e)...); err != nil {
+			return nil, err
+		}
+		entities = append(entities, e)
+	}
+	return entities, rows.Err()
+}
+
+// Get returns the entity with the given ID or slug.
+func (r *EntityRepository) Get(ctx context.Context, idOrSlug string) (*models.Entity, error) {
+	var e models.Entity
+	err := r.db.Pool.QueryRow(ctx, `
+		SELECT `+entityColumns+`
+		FROM entities e
+		WHERE e.id::text = $1 OR e.slug = $1
+	`, idOrSlug).Scan(entityFields(&e)...)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// SeedIfEmpty inserts entities when the table has none and reports whether
+// it did.
+func (r *EntityRepository) SeedIfEmpty(ctx context.Context, entities []models.Entity) (bool, error) {
+	payload, err := json.Marshal(entities)
+	if err != nil {
+		return false, err
+	}
+	tag, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO entities (slug, name, kind, aliases, case_sensitive, origin)
+		SELECT slug, name, kind, COALESCE(aliases, '{}'), COALESCE(case_sensitive, false), 'seed'
+		FROM jsonb_to_recordset($1::jsonb)
+		     AS t(slug text, name text, kind text, aliases text[], case_sensitive boolean)
+		WHERE NOT EXISTS (SELECT 1 FROM entities)
+		ON CONFLICT (slug) DO NOTHING
+	`, payload)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Upsert creates or replaces the entity with e.Slug and fills in its ID and
+// timestamps.
+func (r *EntityRepository) Upsert(ctx context.Context, e *models.Entity) error {
+	aliases := e.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return r.db.Pool.QueryRow(ctx, `
+		INSERT INTO entities (slug, name, kind, aliases, case_sensitive, origin)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (slug) DO UPDATE SET
+			name = EXCLUDED.name,
+			kind = EXCLUDED.kind,
+			aliases = EXCLUDED.aliases,
+			case_sensitive = EXCLUDED.case_sensitive,
+			origin = EXCLUDED.origin,
+			updated_at = now()
+		RETURNING id, created_at, updated_at
+	`, e.Slug, e.Name, e.Kind, aliases, e.CaseSensitive, e.Origin).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+}
+
+// CreateIfAbsent inserts e unless an entity with its slug exists. Either
+// way e is filled in with the stored row.
+func (r *EntityRepository) CreateIfAbsent(ctx context.Context, e *models.Entity) error {
+	aliases := e.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	// The no-op update makes RETURNING yield the existing row on conflict.
+	return r.db.Pool.QueryRow(ctx, `
+		INSERT INTO entities AS e (slug, name, kind, aliases, case_sensitive, origin)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (slug) DO UPDATE SET slug = e.slug
+		RETURNING `+entityColumns+`
+	`, e.Slug, e.Name, e.Kind, aliases, e.CaseSensitive, e.Origin).Scan(entityFields(e)...)
+}
+
+// Link records which entities an item mentions. Existing links are kept.
+func (r *EntityRepository) Link(ctx context.Context, links []models.ItemEntity) error {
+	if len(links) == 0 {
+		return nil
+	}
+	items := make([]uuid.UUID, len(links))
+	entities := make([]uuid.UUID, len(links))
+	mentions := make([]string, len(links))
+	methods := make([]string, len(links))
+	for i, l := range links {
+		items[i], entities[i], mentions[i], methods[i] = l.ItemID, l.EntityID, l.Mention, l.Method
+	}
+	_, err := r.db.Pool.Exec(ctx, `
+		INSERT INTO item_entities (item_id, entity_id, mention, method)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::text[], $4::text[])
+		ON CONFLICT (item_id, entity_id) DO NOTHING
+	`, items, entities, mentions, methods)
+	return err
+}
+
+// ListForItem returns the entities an item mentions, ordered by name.
+func (r *EntityRepository) ListForItem(ctx context.Context, itemID uuid.UUID) ([]models.EntityMention, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+entityColumns+`, ie.mention, ie.method
+		FROM item_entities ie
+		JOIN entities e ON e.id = ie.entity_id
+		WHERE ie.item_id = $1
+		ORDER BY lower(e.name)
+	`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []models.EntityMention
+	for rows.Next() {
+		var m models.EntityMention
+		if err := rows.Scan(append(entityFields(&m.Entity), &m.Mention, &m.Method)...); err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, m)
+	}
+	return mentions, rows.Err()
+}
+
+// Timeline returns the items mentioning an entity, newest first. Each
+// near-duplicate story is listed once, by its earliest mentioning item.
+func (r *EntityRepository) Timeline(ctx context.Context, entityID uuid.UUID, limit int) ([]models.EntityTimelineItem, error) {
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT id, title, url, domain, published_at, final, mention, method
+		FROM (
+			SELECT DISTINCT ON (COALESCE(i.duplicate_of, i.id))
+			       i.id, i.title, i.url, i.domain, i.published_at, s.final, ie.mention, ie.method
+			FROM item_entities ie
+			JOIN items i ON i.id = ie.item_id
+			LEFT JOIN scores s ON s.item_id = i.id
+			WHERE ie.entity_id = $1
+			ORDER BY COALESCE(i.duplicate_of, i.id), i.published_at
+		) t
+		ORDER BY published_at DESC
+		LIMIT $2
+	`, entityID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.EntityTimelineItem
+	for rows.Next() {
+		var it models.EntityTimelineItem
+		if err := rows.Scan(&it.ID, &it.Title, &it.URL, &it.Domain, &it.PublishedAt, &it.Final, &it.Mention, &it.Method); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
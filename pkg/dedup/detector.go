@@ -0,0 +1,94 @@
+package dedup
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// maxCandidates bounds how many band matches are compared per item.
+const maxCandidates = 50
+
+// Detector signs new items and links near-duplicates to the earliest item
+// of the same story.
+type Detector struct {
+	items     *db.ItemRepository
+	enabled   bool
+	threshold float64
+	window    time.Duration
+}
+
+func NewDetector(database *db.DB, cfg *config.Config) *Detector {
+	return &Detector{
+		items:     db.NewItemRepository(database),
+		enabled:   cfg.NearDupEnabled,
+		threshold: cfg.NearDupThreshold,
+		window:    time.Duration(cfg.NearDupWindowHours) * time.Hour,
+	}
+}
+
+// Link stores item's MinHash signature and, when an item of the same story was
+// published within the window, marks item as its duplicate. It returns the
+// canonical item's ID, or nil when item starts a new story.
+func (d *Detector) Link(ctx context.Context, item *models.Item) (*uuid.UUID, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	excerpt := ""
+	if item.RawExcerpt != nil {
+		excerpt = *item.RawExcerpt
+	}
+	sig, ok := Signature(item.Title, excerpt)
+	if !ok {
+		return nil, nil
+	}
+
+	bands := BandKeys(sig)
+	if err := d.items.SetMinHash(ctx, item.ID, sig, bands); err != nil {
+		return nil, err
+	}
+
+	candidates, err := d.items.FindNearDuplicateCandidates(ctx, bands, item.ID,
+		item.PublishedAt.Add(-d.window), item.PublishedAt.Add(d.window), maxCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := nearest(sig, candidates, d.threshold)
+	if canonical == nil {
+		return nil, nil
+	}
+	if err := d.items.MarkDuplicate(ctx, item.ID, *canonical); err != nil {
+		return nil, err
+	}
+	item.DuplicateOf = canonical
+	return canonical, nil
+}
+
+// nearest returns the story of the most similar candidate at or above
+// threshold, preferring earlier candidates on ties. Candidates that are
+// themselves duplicates resolve to their canonical item so stories never
+// chain.
+func nearest(sig []uint32, candidates []models.NearDuplicateCandidate, threshold float64) *uuid.UUID {
+	var best *models.NearDuplicateCandidate
+	bestSim := threshold
+	for i := range candidates {
+		if sim := Similarity(sig, candidates[i].Signature); sim >= bestSim && (best == nil || sim > bestSim) {
+			best, bestSim = &candidates[i], sim
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	story := best.ItemID
+	if best.DuplicateOf != nil {
+		story = *best.DuplicateOf
+	}
+	return &story
+}
@@ -0,0 +1,151 @@
+// Package dedup detects near-duplicate items — the same story covered by
+// several outlets — using MinHash signatures over title and excerpt words,
+// with LSH bands to find candidates.
+package dedup
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// SignatureSize is the number of MinHash values per item.
+	SignatureSize = Bands * BandRows
+	// Bands and BandRows split a signature for LSH. Items with Jaccard
+	// similarity s share a band with probability 1-(1-s^4)^8: ~95% at 0.75,
+	// ~6% at 0.3.
+	Bands    = 8
+	BandRows = 4
+
+	// minTokens is the smallest word set that gets a signature; shorter
+	// titles collide too easily.
+	minTokens = 4
+	// excerptTokens caps how much of the excerpt joins the title words, so
+	// a long excerpt doesn't drown out the headline.
+	excerptTokens = 20
+)
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "has": true, "in": true, "is": true, "it": true,
+	"its": true, "of": true, "on": true, "or": true, "that": true, "the": true, "this": true,
+	"to": true, "was": true, "with": true, "new": true, "how": true, "what": true, "why": true,
+}
+
+// seeds derives the per-position hash seeds once.
+var seeds = func() [SignatureSize]uint64 {
+	var out [SignatureSize]uint64
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range out {
+		x = mix64(x + uint64(i))
+		out[i] = x
+	}
+	return out
+}()
+
+// Signature computes the MinHash signature of an item's title words and the
+// first words of its excerpt. ok is false when there are too few words to
+// compare reliably.
+func Signature(title, excerpt string) (sig []uint32, ok bool) {
+	words := make(map[string]bool)
+	add := func(tokens []string) {
+		for _, tok := range tokens {
+			words[tok] = true
+			// Version numbers and amounts tell apart otherwise identical
+			// headlines ("Llama 3" vs "Llama 4"), so they count twice.
+			if strings.IndexFunc(tok, unicode.IsDigit) >= 0 {
+				words[tok+"#"] = true
+			}
+		}
+	}
+	add(tokenize(title))
+	ex := tokenize(excerpt)
+	if len(ex) > excerptTokens {
+		ex = ex[:excerptTokens]
+	}
+	add(ex)
+	if len(words) < minTokens {
+		return nil, false
+	}
+
+	sig = make([]uint32, SignatureSize)
+	for i := range sig {
+		sig[i] = ^uint32(0)
+	}
+	for word := range words {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(word))
+		base := h.Sum64()
+		for i := range sig {
+			if v := uint32(mix64(base^seeds[i]) >> 32); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig, true
+}
+
+// Similarity estimates the Jaccard similarity of the word sets behind two
+// signatures.
+func Similarity(a, b []uint32) float64 {
+	if len(a) != SignatureSize || len(b) != SignatureSize {
+		return 0
+	}
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / SignatureSize
+}
+
+// BandKeys hashes each band of sig into an LSH key. The band index is kept
+// in the top byte so equal rows in different bands don't match.
+func BandKeys(sig []uint32) []int64 {
+	keys := make([]int64, Bands)
+	for b := 0; b < Bands; b++ {
+		h := fnv.New64a()
+		for _, v := range sig[b*BandRows : (b+1)*BandRows] {
+			_, _ = h.Write([]byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)})
+		}
+		keys[b] = int64(b)<<56 | int64(h.Sum64()&0x00ff_ffff_ffff_ffff)
+	}
+	return keys
+}
+
+// tokenize splits text into lowercase words. Inner dots and hyphens are
+// kept so "gpt-4.5" and "3.3" stay single words.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$' && r != '.' && r != '-'
+	})
+	tokens := fields[:0]
+	for _, f := range fields {
+		f = strings.Trim(f, ".-")
+		if f == "" || stopwords[f] {
+			continue
+		}
+		tokens = append(tokens, stem(f))
+	}
+	return tokens
+}
+
+// stem folds simple plurals so "model" and "models" count as one word.
+func stem(word string) string {
+	if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// mix64 is the splitmix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,85 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestSignatureSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		min, max float64
+	}{
+		{name: "reworded headline", a: "Meta releases Llama 4 open weights model family", b: "Meta releases Llama 4 family of open weights models", min: 0.9, max: 1},
+		{name: "synonym swap", a: "OpenAI launches GPT-5 with improved reasoning", b: "OpenAI launches GPT-5 with better reasoning", min: 0.6, max: 1},
+		{name: "different version", a: "Meta releases Llama 4 open weights model family", b: "Meta releases Llama 3.3 open weights model update", min: 0, max: 0.6},
+		{name: "unrelated", a: "Meta releases Llama 4 open weights model family", b: "Google announces Gemini 3 with native audio", min: 0, max: 0.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, ok := Signature(tt.a, "")
+			if !ok {
+				t.Fatal("expected a signature")
+			}
+			b, _ := Signature(tt.b, "")
+			if sim := Similarity(a, b); sim < tt.min || sim > tt.max {
+				t.Errorf("Similarity = %.2f, want within [%.2f, %.2f]", sim, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestSignatureShortText(t *testing.T) {
+	if _, ok := Signature("GPT-5 is here", ""); ok {
+		t.Error("expected no signature for a two-word title")
+	}
+}
+
+func TestBandKeysShareBandForIdenticalRows(t *testing.T) {
+	a, _ := Signature("Meta releases Llama 4 open weights model family", "")
+	b := append([]uint32(nil), a...)
+	for i := BandRows; i < SignatureSize; i++ {
+		b[i]++ // every band but the first differs
+	}
+
+	ka, kb := BandKeys(a), BandKeys(b)
+	if ka[0] != kb[0] {
+		t.Error("identical first band should produce the same key")
+	}
+	for i := 1; i < Bands; i++ {
+		if ka[i] == kb[i] {
+			t.Errorf("band %d should differ", i)
+		}
+		if ka[i]>>56 != int64(i) {
+			t.Errorf("band %d key missing band index", i)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	sig, _ := Signature("Meta releases Llama 4 open weights model family", "")
+	near := append([]uint32(nil), sig...)
+	near[0]++
+	far := append([]uint32(nil), sig...)
+	for i := 0; i < SignatureSize/2; i++ {
+		far[i]++
+	}
+
+	story := uuid.New()
+	candidates := []models.NearDuplicateCandidate{
+		{ItemID: uuid.New(), Signature: far},
+		{ItemID: uuid.New(), Signature: near, DuplicateOf: &story},
+	}
+
+	got := nearest(sig, candidates, 0.6)
+	if got == nil || *got != story {
+		t.Fatalf("nearest = %v, want story %v of the closest candidate", got, story)
+	}
+	if got := nearest(sig, candidates[:1], 0.6); got != nil {
+		t.Errorf("nearest = %v, want nil below threshold", got)
+	}
+}
@@ -0,0 +1,116 @@
+package entities
+
+import "github.com/hidatara-ds/evolipia-radar/pkg/models"
+
+// DefaultEntities returns the built-in gazetteer, seeded into an empty
+// entities table. Names that are also common words are case-sensitive.
+func DefaultEntities() []models.Entity {
+	model := func(slug, name string, aliases ...string) models.Entity {
+		return models.Entity{Slug: slug, Name: name, Kind: models.EntityModel, Aliases: aliases}
+	}
+	org := func(slug, name string, aliases ...string) models.Entity {
+		return models.Entity{Slug: slug, Name: name, Kind: models.EntityOrganization, Aliases: aliases}
+	}
+	dataset := func(slug, name string, aliases ...string) models.Entity {
+		return models.Entity{Slug: slug, Name: name, Kind: models.EntityDataset, Aliases: aliases}
+	}
+	person := func(slug, name string, aliases ...string) models.Entity {
+		return models.Entity{Slug: slug, Name: name, Kind: models.EntityPerson, Aliases: aliases}
+	}
+	product := func(slug, name string, aliases ...string) models.Entity {
+		return models.Entity{Slug: slug, Name: name, Kind: models.EntityProduct, Aliases: aliases}
+	}
+	caseSensitive := func(e models.Entity) models.Entity {
+		e.CaseSensitive = true
+		return e
+	}
+
+	return []models.Entity{
+		// Models
+		model("gpt-4", "GPT-4", "GPT4"),
+		model("gpt-4o", "GPT-4o", "GPT4o"),
+		model("claude", "Claude"),
+		model("gemini", "Gemini"),
+		caseSensitive(model("gemma", "Gemma")),
+		model("llama", "Llama", "LLaMA"),
+		model("llama-2", "Llama 2", "Llama2"),
+		model("llama-3", "Llama 3", "Llama3"),
+		model("mistral-7b", "Mistral 7B"),
+		model("mixtral", "Mixtral"),
+		model("qwen", "Qwen"),
+		model("deepseek-v3", "DeepSeek-V3"),
+		model("deepseek-r1", "DeepSeek-R1"),
+		model("phi-3", "Phi-3"),
+		model("stable-diffusion", "Stable Diffusion", "SDXL"),
+		model("dall-e", "DALL-E", "DALLE", "DALL·E"),
+		caseSensitive(model("whisper", "Whisper")),
+		caseSensitive(model("bert", "BERT")),
+
+		// Organizations
+		org("openai", "OpenAI"),
+		org("anthropic", "Anthropic"),
+		org("google-deepmind", "Google DeepMind", "DeepMind"),
+		org("meta-ai", "Meta AI"),
+		org("mistral-ai", "Mistral AI", "Mistral"),
+		org("hugging-face", "Hugging Face", "HuggingFace"),
+		org("microsoft-research", "Microsoft Research"),
+		org("nvidia", "NVIDIA"),
+		caseSensitive(org("xai", "xAI")),
+		org("cohere", "Cohere"),
+		org("stability-ai", "Stability AI"),
+		org("deepseek", "DeepSeek"),
+		org("ai2", "Allen Institute for AI", "AI2"),
+		org("eleutherai", "EleutherAI"),
+
+		// Datasets and benchmarks
+		dataset("mmlu", "MMLU"),
+		dataset("imagenet", "ImageNet"),
+		dataset("gsm8k", "GSM8K"),
+		dataset("humaneval", "HumanEval"),
+		dataset("swe-bench", "SWE-bench"),
+		dataset("hellaswag", "HellaSwag"),
+		dataset("arc-agi", "ARC-AGI"),
+		dataset("gpqa", "GPQA"),
+		dataset("big-bench", "BIG-bench"),
+		dataset("common-crawl", "Common Crawl"),
+		dataset("laion-5b", "LAION-5B", "LAION"),
+		caseSensitive(dataset("coco", "COCO", "MS COCO")),
+		dataset("chatbot-arena", "Chatbot Arena", "LMSYS Arena", "LMArena"),
+
+		// People
+		person("yann-lecun", "Yann LeCun"),
+		person("geoffrey-hinton", "Geoffrey Hinton"),
+		person("yoshua-bengio", "Yoshua Bengio"),
+		person("andrej-karpathy", "Andrej Karpathy"),
+		person("ilya-sutskever", "Ilya Sutskever"),
+		person("sam-altman", "Sam Altman"),
+		person("demis-hassabis", "Demis Hassabis"),
+		person("dario-amodei", "Dario Amodei"),
+		person("fei-fei-li", "Fei-Fei Li"),
+		person("andrew-ng", "Andrew Ng"),
+		person("jeff-dean", "Jeff Dean"),
+
+		// Products: AI IDEs and code assistants, assistants, frameworks
+		caseSensitive(product("cursor", "Cursor")),
+		caseSensitive(product("windsurf", "Windsurf")),
+		product("kiro", "Kiro"),
+		product("codeium", "Codeium"),
+		product("github-copilot", "GitHub Copilot", "Copilot"),
+		product("tabnine", "Tabnine"),
+		product("replit", "Replit", "Ghostwriter"),
+		caseSensitive(product("zed", "Zed")),
+		caseSensitive(product("warp", "Warp")),
+		caseSensitive(product("jetbrains-fleet", "JetBrains Fleet", "Fleet")),
+		product("vs-code", "VS Code", "VSCode", "Visual Studio Code"),
+		product("intellij", "IntelliJ", "IntelliJ IDEA"),
+		product("chatgpt", "ChatGPT"),
+		product("midjourney", "Midjourney"),
+		product("langchain", "LangChain"),
+		product("llamaindex", "LlamaIndex"),
+		product("pytorch", "PyTorch"),
+		product("tensorflow", "TensorFlow"),
+		caseSensitive(product("jax", "JAX")),
+		product("vllm", "vLLM"),
+		product("ollama", "Ollama"),
+	}
+}
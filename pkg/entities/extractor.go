@@ -0,0 +1,253 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+const (
+	// gazetteerTTL is how long the gazetteer is used before it is re-read,
+	// so entities added elsewhere are picked up.
+	gazetteerTTL  = time.Minute
+	maxNameLength = 80
+)
+
+// ErrInvalid wraps entity edits that fail validation.
+var ErrInvalid = errors.New("invalid entity")
+
+type snapshot struct {
+	gazetteer *Gazetteer
+	loadedAt  time.Time
+}
+
+// Extractor links items to the entities they mention.
+type Extractor struct {
+	repo    *db.EntityRepository
+	ai      *ai.Service // nil when the LLM pass is disabled
+	current atomic.Pointer[snapshot]
+	seeded  atomic.Bool
+}
+
+// NewExtractor returns an extractor that runs the LLM pass through aiSvc,
+// and so within its token budget, when LLMConfigured. aiSvc
+// may be nil where only the gazetteer is needed.
+func NewExtractor(database *db.DB, cfg *config.Config, aiSvc *ai.Service) *Extractor {
+	x := &Extractor{repo: db.NewEntityRepository(database)}
+	if LLMConfigured(cfg) {
+		x.ai = aiSvc
+	}
+	return x
+}
+
+// LLMConfigured reports whether the LLM pass is enabled and the AI service
+// has a key, so job runners can process entities jobs. Producers queue them
+// only then.
+func LLMConfigured(cfg *config.Config) bool {
+	return cfg.EntityLLMEnabled && config.LoadAIConfig().APIKey != ""
+}
+
+// LLMEnabled reports whether Process runs the LLM pass.
+func (x *Extractor) LLMEnabled() bool {
+	return x.ai != nil
+}
+
+// Gazetteer returns the stored entities as a gazetteer, seeding an empty
+// table with DefaultEntities first. It is cached for gazetteerTTL; if
+// re-reading fails the cached one is kept.
+func (x *Extractor) Gazetteer(ctx context.Context) (*Gazetteer, error) {
+	cached := x.current.Load()
+	if cached != nil && time.Since(cached.loadedAt) < gazetteerTTL {
+		return cached.gazetteer, nil
+	}
+
+	if !x.seeded.Load() {
+		seeded, err := x.repo.SeedIfEmpty(ctx, DefaultEntities())
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed entities: %w", err)
+		}
+		if seeded {
+			log.Printf("Seeded %d default entities", len(DefaultEntities()))
+		}
+		x.seeded.Store(true)
+	}
+
+	all, err := x.repo.List(ctx, nil)
+	if err != nil {
+		if cached != nil {
+			log.Printf("Failed to reload entities, keeping %d cached: %v", cached.gazetteer.Len(), err)
+			return cached.gazetteer, nil
+		}
+		return nil, fmt.Errorf("failed to list entities: %w", err)
+	}
+	g := NewGazetteer(all)
+	x.current.Store(&snapshot{gazetteer: g, loadedAt: time.Now()})
+	return g, nil
+}
+
+// invalidate makes the next Gazetteer call re-read the entities.
+func (x *Extractor) invalidate() {
+	if cached := x.current.Load(); cached != nil {
+		x.current.Store(&snapshot{gazetteer: cached.gazetteer})
+	}
+}
+
+// Link matches the gazetteer against a new item's title and excerpt and
+// stores the links. It returns the number of entities linked.
+func (x *Extractor) Link(ctx context.Context, item *models.Item) (int, error) {
+	g, err := x.Gazetteer(ctx)
+	if err != nil {
+		return 0, err
+	}
+	text := item.Title
+	if item.RawExcerpt != nil {
+		text += "\n" + *item.RawExcerpt
+	}
+	links := gazetteerLinks(g, item.ID, text)
+	return len(links), x.repo.Link(ctx, links)
+}
+
+// Process links an item with the gazetteer and then, when enabled, asks the
+// LLM for further names. Unknown names the LLM finds in the text become new
+// entities; names it only imagined are dropped.
+func (x *Extractor) Process(ctx context.Context, itemID uuid.UUID, title, content string) error {
+	g, err := x.Gazetteer(ctx)
+	if err != nil {
+		return err
+	}
+	text := title + "\n" + content
+	links := gazetteerLinks(g, itemID, text)
+
+	if x.ai != nil {
+		candidates, err := x.extractCandidates(ctx, title, ai.ArticleContent(content))
+		if err != nil {
+			return fmt.Errorf("entity extraction failed: %w", err)
+		}
+
+		linked := make(map[uuid.UUID]bool, len(links))
+		for _, l := range links {
+			linked[l.EntityID] = true
+		}
+		created := false
+		for _, c := range candidates {
+			name := strings.TrimSpace(c.Name)
+			kind := strings.ToLower(strings.TrimSpace(c.Kind))
+			if len(name) < 2 || len(name) > maxNameLength || !ValidKind(kind) || !mentions(text, name) {
+				continue
+			}
+
+			entity, known := g.Lookup(name)
+			if !known {
+				entity = models.Entity{Slug: Slugify(name), Name: name, Kind: kind, Origin: OriginLLM}
+				if entity.Slug == "" {
+					continue
+				}
+				if err := x.repo.CreateIfAbsent(ctx, &entity); err != nil {
+					return fmt.Errorf("failed to store entity %q: %w", name, err)
+				}
+				created = true
+			}
+			if !linked[entity.ID] {
+				linked[entity.ID] = true
+				links = append(links, models.ItemEntity{ItemID: itemID, EntityID: entity.ID, Mention: name, Method: MethodLLM})
+			}
+		}
+		if created {
+			x.invalidate()
+		}
+	}
+
+	return x.repo.Link(ctx, links)
+}
+
+// candidate is a named entity the LLM found in an article.
+type candidate struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+const entityPrompt = `List the named entities in the AI/ML news article. ` +
+	`Reply with only a JSON object {"entities": [{"name": ..., "kind": ...}]} where:
+- name: the entity's canonical name as written in the article, e.g. "Llama 3", "Mistral AI", "MMLU".
+- kind: one of model, organization, dataset, person, product. Benchmarks are datasets.
+Only include specific names, not generic terms like "language model" or "benchmark".`
+
+// extractCandidates asks the LLM for the models, organizations, datasets,
+// people and products an article names.
+func (x *Extractor) extractCandidates(ctx context.Context, title, content string) ([]candidate, error) {
+	temperature := float32(0)
+	resp, err := x.ai.Chat(ctx, ai.ChatRequest{
+		Messages: []ai.ChatMessage{
+			{Role: ai.RoleSystem, Content: entityPrompt},
+			{Role: ai.RoleUser, Content: "Title: " + title + "\n\n" + content},
+		},
+		Temperature: &temperature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseCandidates(resp.Content)
+}
+
+func parseCandidates(content string) ([]candidate, error) {
+	var out struct {
+		Entities []candidate `json:"entities"`
+	}
+	if err := ai.DecodeJSONReply(content, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse entities JSON: %w", err)
+	}
+	return out.Entities, nil
+}
+
+// Put creates or replaces the entity with e.Slug as a manual entry. Names and
+// aliases that already name another entity are rejected with ErrInvalid.
+func (x *Extractor) Put(ctx context.Context, e *models.Entity) error {
+	e.Name = strings.TrimSpace(e.Name)
+	switch {
+	case e.Slug == "" || Slugify(e.Slug) != e.Slug:
+		return fmt.Errorf("%w: slug must be lowercase words joined by dashes, e.g. %q", ErrInvalid, Slugify(e.Name))
+	case e.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalid)
+	case !ValidKind(e.Kind):
+		return fmt.Errorf("%w: kind must be one of %s", ErrInvalid, strings.Join(Kinds, ", "))
+	}
+
+	g, err := x.Gazetteer(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range append([]string{e.Name}, e.Aliases...) {
+		if Slugify(name) == "" {
+			return fmt.Errorf("%w: alias %q has no letters or digits", ErrInvalid, name)
+		}
+		if other, ok := g.Lookup(name); ok && other.Slug != e.Slug {
+			return fmt.Errorf("%w: %q already names %s", ErrInvalid, name, other.Slug)
+		}
+	}
+
+	e.Origin = OriginManual
+	if err := x.repo.Upsert(ctx, e); err != nil {
+		return fmt.Errorf("failed to store entity: %w", err)
+	}
+	x.invalidate()
+	return nil
+}
+
+func gazetteerLinks(g *Gazetteer, itemID uuid.UUID, text string) []models.ItemEntity {
+	matches := g.Match(text)
+	links := make([]models.ItemEntity, len(matches))
+	for i, m := range matches {
+		links[i] = models.ItemEntity{ItemID: itemID, EntityID: m.Entity.ID, Mention: m.Mention, Method: MethodGazetteer}
+	}
+	return links
+}
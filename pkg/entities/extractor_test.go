@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCandidates(t *testing.T) {
+	got, err := parseCandidates("```json\n{\"entities\": [{\"name\": \"Llama 3\", \"kind\": \"model\"}]}\n```")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []candidate{{Name: "Llama 3", Kind: "model"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCandidates = %v, want %v", got, want)
+	}
+	if _, err := parseCandidates("no entities here"); err == nil {
+		t.Error("parseCandidates accepted a reply without JSON")
+	}
+}
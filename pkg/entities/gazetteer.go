@@ -0,0 +1,220 @@
+// Package entities links items to the canonical models, organizations,
+// datasets, people and products they mention. A gazetteer of known names
+// and aliases is matched against every new item; an optional LLM pass
+// proposes names the gazetteer does not know yet and adds them.
+package entities
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// Link methods.
+const (
+	MethodGazetteer = "gazetteer"
+	MethodLLM       = "llm"
+)
+
+// Entity origins.
+const (
+	OriginSeed   = "seed"
+	OriginLLM    = "llm"
+	OriginManual = "manual"
+)
+
+// Kinds lists the valid entity kinds.
+var Kinds = []string{models.EntityModel, models.EntityOrganization, models.EntityDataset, models.EntityPerson, models.EntityProduct}
+
+// ValidKind reports whether kind is one of Kinds.
+func ValidKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// token is a run of letters and digits. Everything else separates tokens,
+// so "Llama-3", "Llama 3" and "llama_3" all read as llama, 3.
+type token struct {
+	text, lower string
+	start, end  int // byte offsets in the source text
+}
+
+func tokenize(s string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range s {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			tokens = append(tokens, token{text: s[start:i], lower: strings.ToLower(s[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{text: s[start:], lower: strings.ToLower(s[start:]), start: start, end: len(s)})
+	}
+	return tokens
+}
+
+type entry struct {
+	entity *models.Entity
+	tokens []token
+}
+
+// matchesAt reports whether e's name starts at tokens[i].
+func (e entry) matchesAt(tokens []token, i int) bool {
+	if i+len(e.tokens) > len(tokens) {
+		return false
+	}
+	for j, t := range e.tokens {
+		if e.entity.CaseSensitive && tokens[i+j].text != t.text {
+			return false
+		}
+		if tokens[i+j].lower != t.lower {
+			return false
+		}
+	}
+	return true
+}
+
+// continuesVersion reports whether tokens[next] extends the version number
+// ending just before it, as "5" does in "GPT-4.5": the previous token ends
+// in a digit, a single "." separates them and tokens[next] starts with one.
+// A name ending there would link a different version.
+func continuesVersion(text string, tokens []token, next int) bool {
+	if next == 0 || next >= len(tokens) {
+		return false
+	}
+	prev, t := tokens[next-1], tokens[next]
+	return t.start == prev.end+1 && text[prev.end] == '.' &&
+		isDigit(text[prev.end-1]) && isDigit(text[t.start])
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Gazetteer matches entity names and aliases in text. Names match on whole
+// tokens, ignoring punctuation and, unless the entity is case-sensitive,
+// case. Where names overlap the longest wins, so "Mistral AI" beats
+// "Mistral". A name never matches the start of a longer version number:
+// "Llama 3.1" is not "Llama 3".
+type Gazetteer struct {
+	entities []models.Entity
+	byFirst  map[string][]entry // first lowercased token → longest names first
+}
+
+// NewGazetteer indexes the names and aliases of entities.
+func NewGazetteer(entities []models.Entity) *Gazetteer {
+	g := &Gazetteer{
+		entities: entities,
+		byFirst:  make(map[string][]entry),
+	}
+	for i := range g.entities {
+		e := &g.entities[i]
+		for _, name := range append([]string{e.Name}, e.Aliases...) {
+			tokens := tokenize(name)
+			if len(tokens) == 0 {
+				continue
+			}
+			g.byFirst[tokens[0].lower] = append(g.byFirst[tokens[0].lower], entry{entity: e, tokens: tokens})
+		}
+	}
+	for _, entries := range g.byFirst {
+		sort.SliceStable(entries, func(i, j int) bool {
+			if len(entries[i].tokens) != len(entries[j].tokens) {
+				return len(entries[i].tokens) > len(entries[j].tokens)
+			}
+			// Case-sensitive names are the more specific reading.
+			return entries[i].entity.CaseSensitive && !entries[j].entity.CaseSensitive
+		})
+	}
+	return g
+}
+
+// Len returns the number of entities in the gazetteer.
+func (g *Gazetteer) Len() int {
+	return len(g.entities)
+}
+
+// Match is an entity found in a text.
+type Match struct {
+	Entity  models.Entity
+	Mention string // the text as written
+}
+
+// Match returns each entity mentioned in text once, in order of first
+// mention.
+func (g *Gazetteer) Match(text string) []Match {
+	tokens := tokenize(text)
+	var matches []Match
+	seen := make(map[string]bool) // by slug
+	for i := 0; i < len(tokens); {
+		matched := false
+		for _, e := range g.byFirst[tokens[i].lower] {
+			if !e.matchesAt(tokens, i) || continuesVersion(text, tokens, i+len(e.tokens)) {
+				continue
+			}
+			if !seen[e.entity.Slug] {
+				seen[e.entity.Slug] = true
+				end := tokens[i+len(e.tokens)-1].end
+				matches = append(matches, Match{Entity: *e.entity, Mention: text[tokens[i].start:end]})
+			}
+			i += len(e.tokens)
+			matched = true
+			break
+		}
+		if !matched {
+			i++
+		}
+	}
+	return matches
+}
+
+// Lookup returns the entity whose name or an alias is exactly name.
+func (g *Gazetteer) Lookup(name string) (models.Entity, bool) {
+	tokens := tokenize(name)
+	if len(tokens) == 0 {
+		return models.Entity{}, false
+	}
+	for _, e := range g.byFirst[tokens[0].lower] {
+		if len(e.tokens) == len(tokens) && e.matchesAt(tokens, 0) {
+			return *e.entity, true
+		}
+	}
+	return models.Entity{}, false
+}
+
+// mentions reports whether name occurs in text, ignoring case and
+// punctuation.
+func mentions(text, name string) bool {
+	e := entry{entity: &models.Entity{}, tokens: tokenize(name)}
+	if len(e.tokens) == 0 {
+		return false
+	}
+	tokens := tokenize(text)
+	for i := range tokens {
+		if e.matchesAt(tokens, i) && !continuesVersion(text, tokens, i+len(e.tokens)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slugify turns a name into an entity slug: "Llama 3" becomes "llama-3".
+func Slugify(name string) string {
+	tokens := tokenize(name)
+	parts := make([]string, len(tokens))
+	for i, t := range tokens {
+		parts[i] = t.lower
+	}
+	return strings.Join(parts, "-")
+}
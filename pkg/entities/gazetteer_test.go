@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func matchedSlugs(g *Gazetteer, text string) []string {
+	var slugs []string
+	for _, m := range g.Match(text) {
+		slugs = append(slugs, m.Entity.Slug)
+	}
+	return slugs
+}
+
+func TestGazetteer_Match(t *testing.T) {
+	g := NewGazetteer(DefaultEntities())
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Mistral AI releases Mistral 7B", []string{"mistral-ai", "mistral-7b"}},
+		{"Fine-tuning Llama-3 on MMLU", []string{"llama-3", "mmlu"}},
+		{"GPT-4o beats GPT4 on HumanEval", []string{"gpt-4o", "gpt-4", "humaneval"}},
+		{"OpenAI and openai again", []string{"openai"}},
+		{"Move the cursor to the next warp", nil}, // common words, case-sensitive entities
+		{"Cursor ships background agents", []string{"cursor"}},
+		{"Notes from Yann LeCun's talk", []string{"yann-lecun"}},
+		{"Pricing is fair for everyone", nil},
+		{"GPT-4.5 is not GPT-4", []string{"gpt-4"}}, // only the second mention
+		{"GPT4.5 preview", nil},
+		{"Benchmarks for Llama 3.1", []string{"llama"}}, // the family, not llama-3
+		{"Llama 3. Then GPT-4.", []string{"llama-3", "gpt-4"}},
+	}
+	for _, tt := range tests {
+		if got := matchedSlugs(g, tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Match(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestGazetteer_MatchMention(t *testing.T) {
+	g := NewGazetteer(DefaultEntities())
+	matches := g.Match("Benchmarks for llama 3, out today")
+	if len(matches) != 1 || matches[0].Mention != "llama 3" {
+		t.Fatalf("Match = %+v, want one mention %q", matches, "llama 3")
+	}
+}
+
+func TestGazetteer_Lookup(t *testing.T) {
+	g := NewGazetteer(DefaultEntities())
+	if e, ok := g.Lookup("huggingface"); !ok || e.Slug != "hugging-face" {
+		t.Errorf("Lookup(huggingface) = %v, %v; want hugging-face", e.Slug, ok)
+	}
+	if _, ok := g.Lookup("Hugging"); ok {
+		t.Error("Lookup matched a partial name")
+	}
+	if _, ok := g.Lookup("cursor"); ok {
+		t.Error("Lookup ignored case for a case-sensitive entity")
+	}
+}
+
+func TestMentions(t *testing.T) {
+	text := "A new DeepSeek-R1 distill"
+	if !mentions(text, "deepseek r1") {
+		t.Error("mentions missed a name written with different punctuation")
+	}
+	if mentions(text, "DeepSeek-V3") {
+		t.Error("mentions found a name that is not in the text")
+	}
+	if mentions("Llama 3.1 405B", "Llama 3") {
+		t.Error("mentions matched the start of a longer version number")
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	for name, want := range map[string]string{
+		"Llama 3":        "llama-3",
+		"Hugging Face":   "hugging-face",
+		"  DALL·E 3!  ":  "dall-e-3",
+		"---":            "",
+		"Claude Sonnet ": "claude-sonnet",
+	} {
+		if got := Slugify(name); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Package fetch provides the shared outbound HTTP layer used by connectors,
+// discovery agents and AI clients. Every request goes through a per-host
+// token bucket and a global concurrency cap so a single run cannot hammer
+// an upstream API.
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBackoff = 2 * time.Second
+	maxBackoff     = 5 * time.Minute
+)
+
+// Limits describes the politeness budget for one host.
+type Limits struct {
+	RPS   float64 // sustained requests per second
+	Burst int     // requests allowed back-to-back before throttling
+}
+
+// LimiterConfig configures a Limiter.
+type LimiterConfig struct {
+	Default       Limits
+	Overrides     map[string]Limits // keyed by domain; "example.com" also matches subdomains
+	MaxConcurrent int               // in-flight requests across all hosts (0 = unlimited)
+}
+
+// Limiter hands out per-host request slots and adapts to upstream throttling.
+type Limiter struct {
+	cfg LimiterConfig
+	sem chan struct{}
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is a token bucket whose refill rate shrinks on 429/503 and
+// recovers gradually on success (AIMD).
+type bucket struct {
+	limits       Limits
+	rate         float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	strikes      int
+}
+
+// NewLimiter creates a Limiter from cfg, filling in safe defaults.
+func NewLimiter(cfg LimiterConfig) *Limiter {
+	if cfg.Default.RPS <= 0 {
+		cfg.Default.RPS = 1
+	}
+	if cfg.Default.Burst <= 0 {
+		cfg.Default.Burst = 1
+	}
+
+	l := &Limiter{
+		cfg:     cfg,
+		buckets: make(map[string]*bucket),
+	}
+	if cfg.MaxConcurrent > 0 {
+		l.sem = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	return l
+}
+
+// limitsFor resolves the configured limits for host, preferring the most
+// specific override.
+func (l *Limiter) limitsFor(host string) Limits {
+	best := ""
+	for domain := range l.cfg.Overrides {
+		d := strings.TrimPrefix(domain, ".")
+		if (host == d || strings.HasSuffix(host, "."+d)) && len(d) > len(best) {
+			best = domain
+		}
+	}
+	if best == "" {
+		return l.cfg.Default
+	}
+
+	lim := l.cfg.Overrides[best]
+	if lim.RPS <= 0 {
+		lim.RPS = l.cfg.Default.RPS
+	}
+	if lim.Burst <= 0 {
+		lim.Burst = 1
+	}
+	return lim
+}
+
+func (l *Limiter) bucketFor(host string) *bucket {
+	b, ok := l.buckets[host]
+	if !ok {
+		lim := l.limitsFor(host)
+		b = &bucket{
+			limits: lim,
+			rate:   lim.RPS,
+			tokens: float64(lim.Burst),
+			last:   time.Now(),
+		}
+		l.buckets[host] = b
+	}
+	return b
+}
+
+// reserve takes a token for host if one is available, otherwise it reports
+// how long the caller should wait before trying again.
+func (l *Limiter) reserve(host string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucketFor(host)
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if burst := float64(b.limits.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+	}
+
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// Acquire blocks until a request to host may proceed. The returned release
+// func must be called once the response body has been consumed.
+func (l *Limiter) Acquire(ctx context.Context, host string) (func(), error) {
+	host = strings.ToLower(host)
+
+	for {
+		wait := l.reserve(host, time.Now())
+		if wait == 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-l.sem }) }, nil
+}
+
+// strikeBackoff returns defaultBackoff doubled for every strike after the
+// first, capped at maxBackoff.
+func strikeBackoff(strikes int) time.Duration {
+	d := defaultBackoff
+	for i := 1; i < strikes; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// Observe feeds a response status back into the host's bucket. 429 and 503
+// halve the refill rate and pause the host for Retry-After (or an
+// exponential default); successes restore the rate step by step.
+func (l *Limiter) Observe(host string, status int, retryAfter string) {
+	host = strings.ToLower(host)
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucketFor(host)
+
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		b.strikes++
+		b.rate /= 2
+		if floor := b.limits.RPS / 16; b.rate < floor {
+			b.rate = floor
+		}
+		b.tokens = 0
+
+		pause, ok := ParseRetryAfter(retryAfter, now)
+		if !ok {
+			pause = strikeBackoff(b.strikes)
+		}
+		if pause > maxBackoff {
+			pause = maxBackoff
+		}
+		if until := now.Add(pause); until.After(b.blockedUntil) {
+			b.blockedUntil = until
+		}
+		return
+	}
+
+	if status < 400 {
+		b.strikes = 0
+		if b.rate < b.limits.RPS {
+			b.rate += b.limits.RPS / 10
+			if b.rate > b.limits.RPS {
+				b.rate = b.limits.RPS
+			}
+		}
+	}
+}
+
+// ParseRetryAfter parses a Retry-After header given either as delay seconds
+// or as an HTTP date.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// ParseOverrides parses entries of the form "host=rps[:burst]".
+func ParseOverrides(entries []string) (map[string]Limits, error) {
+	out := make(map[string]Limits, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		host, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid host limit %q: expected host=rps[:burst]", entry)
+		}
+		host = strings.ToLower(strings.TrimSpace(host))
+
+		rpsStr, burstStr, hasBurst := strings.Cut(spec, ":")
+		rps, err := strconv.ParseFloat(strings.TrimSpace(rpsStr), 64)
+		if err != nil || rps <= 0 {
+			return nil, fmt.Errorf("invalid rps in host limit %q", entry)
+		}
+
+		lim := Limits{RPS: rps, Burst: 1}
+		if hasBurst {
+			burst, err := strconv.Atoi(strings.TrimSpace(burstStr))
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid burst in host limit %q", entry)
+			}
+			lim.Burst = burst
+		}
+		out[host] = lim
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package fetch
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{name: "seconds", value: "30", want: 30 * time.Second, ok: true},
+		{name: "http date", value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute, ok: true},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, ok: true},
+		{name: "empty", value: "", ok: false},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tt.value, now)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseOverrides(t *testing.T) {
+	got, err := ParseOverrides([]string{"reddit.com=0.5:1", " API.Example.com = 3 "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["reddit.com"] != (Limits{RPS: 0.5, Burst: 1}) {
+		t.Errorf("reddit.com = %+v", got["reddit.com"])
+	}
+	if got["api.example.com"] != (Limits{RPS: 3, Burst: 1}) {
+		t.Errorf("api.example.com = %+v", got["api.example.com"])
+	}
+
+	for _, bad := range []string{"reddit.com", "reddit.com=0", "reddit.com=1:x"} {
+		if _, err := ParseOverrides([]string{bad}); err == nil {
+			t.Errorf("ParseOverrides(%q) expected error", bad)
+		}
+	}
+}
+
+func TestLimiterBacksOffOnThrottle(t *testing.T) {
+	l := NewLimiter(LimiterConfig{
+		Default:   Limits{RPS: 10, Burst: 1},
+		Overrides: map[string]Limits{"reddit.com": {RPS: 2, Burst: 3}},
+	})
+
+	if lim := l.limitsFor("old.reddit.com"); lim.RPS != 2 || lim.Burst != 3 {
+		t.Errorf("subdomain should match override, got %+v", lim)
+	}
+
+	now := time.Now()
+	if wait := l.reserve("example.com", now); wait != 0 {
+		t.Fatalf("first request should not wait, got %v", wait)
+	}
+
+	l.Observe("example.com", http.StatusTooManyRequests, "")
+	if wait := l.reserve("example.com", time.Now()); wait <= 0 {
+		t.Errorf("expected backoff after 429, got %v", wait)
+	}
+	if rate := l.buckets["example.com"].rate; rate != 5 {
+		t.Errorf("rate after 429 = %v, want 5", rate)
+	}
+
+	l.Observe("example.com", http.StatusOK, "")
+	if rate := l.buckets["example.com"].rate; rate != 6 {
+		t.Errorf("rate after recovery step = %v, want 6", rate)
+	}
+}
+
+func TestStrikeBackoff(t *testing.T) {
+	for strikes, want := range map[int]time.Duration{
+		1:    defaultBackoff,
+		2:    2 * defaultBackoff,
+		3:    4 * defaultBackoff,
+		100:  maxBackoff, // would overflow a shift
+		1000: maxBackoff,
+	} {
+		if got := strikeBackoff(strikes); got != want {
+			t.Errorf("strikeBackoff(%d) = %v, want %v", strikes, got, want)
+		}
+	}
+}
+
+func TestLimiterKeepsPausingUnderSustainedThrottle(t *testing.T) {
+	l := NewLimiter(LimiterConfig{Default: Limits{RPS: 10, Burst: 1}})
+	for i := 0; i < 100; i++ {
+		l.Observe("example.com", http.StatusServiceUnavailable, "")
+	}
+	if until := time.Until(l.buckets["example.com"].blockedUntil); until < maxBackoff-time.Second {
+		t.Errorf("host paused for %v after 100 strikes, want about %v", until, maxBackoff)
+	}
+}
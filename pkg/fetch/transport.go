@@ -0,0 +1,99 @@
+package fetch
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+)
+
+// Transport is an http.RoundTripper that waits for a Limiter slot before
+// every request and reports the response status back to it.
+type Transport struct {
+	Base    http.RoundTripper
+	Limiter *Limiter
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) with limiter.
+func NewTransport(base http.RoundTripper, limiter *Limiter) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base, Limiter: limiter}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	release, err := t.Limiter.Acquire(req.Context(), host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	t.Limiter.Observe(host, resp.StatusCode, resp.Header.Get("Retry-After"))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		slog.Warn("Upstream throttled request, backing off host", "host", host, "status", resp.StatusCode, "retry_after", resp.Header.Get("Retry-After"))
+	}
+
+	// Hold the concurrency slot until the caller is done with the body.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+var (
+	sharedOnce    sync.Once
+	sharedLimiter *Limiter
+)
+
+// LimiterFromConfig builds a Limiter from the FETCH_* settings.
+func LimiterFromConfig(cfg *config.Config) *Limiter {
+	overrides, err := ParseOverrides(cfg.FetchHostLimits)
+	if err != nil {
+		slog.Warn("Ignoring invalid FETCH_HOST_LIMITS", "err", err)
+		overrides = nil
+	}
+	return NewLimiter(LimiterConfig{
+		Default:       Limits{RPS: cfg.FetchHostRPS, Burst: cfg.FetchHostBurst},
+		Overrides:     overrides,
+		MaxConcurrent: cfg.FetchMaxConcurrent,
+	})
+}
+
+// Shared returns the process-wide Limiter. All outbound clients should use
+// it so limits hold across connectors and agents running in parallel.
+func Shared() *Limiter {
+	sharedOnce.Do(func() {
+		sharedLimiter = LimiterFromConfig(config.Load())
+	})
+	return sharedLimiter
+}
+
+// NewClient returns an http.Client with the given timeout whose transport
+// goes through the shared Limiter.
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewTransport(nil, Shared()),
+	}
+}
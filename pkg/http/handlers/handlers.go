@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
 	"github.com/hidatara-ds/evolipia-radar/pkg/models"
 	"github.com/hidatara-ds/evolipia-radar/pkg/services"
 )
@@ -69,14 +71,21 @@ func (h *Handlers) GetFeed(c *gin.Context) {
 	if topic != "" {
 		topicPtr = &topic
 	}
+	minConfidence, ok := minConfidenceFilter(c)
+	if !ok {
+		return
+	}
 
-	items, err := h.feedService.GetTopDaily(c.Request.Context(), date, topicPtr, 20)
+	items, err := h.feedService.GetTopDaily(c.Request.Context(), date, topicPtr, minConfidence, langFilter(c), 20)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	response := h.feedService.BuildFeedResponse(c.Request.Context(), items, date, topicPtr)
+	if !h.expandAlsoCoveredBy(c, response, items) {
+		return
+	}
 	c.JSON(http.StatusOK, response)
 }
 
@@ -88,16 +97,37 @@ func (h *Handlers) GetRising(c *gin.Context) {
 		return
 	}
 
-	items, err := h.feedService.GetRising(c.Request.Context(), window, 20)
+	items, err := h.feedService.GetRising(c.Request.Context(), window, langFilter(c), 20)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	response := h.feedService.BuildRisingResponse(c.Request.Context(), items, window)
+	plain := make([]models.Item, len(items))
+	for i, ri := range items {
+		plain[i] = ri.Item
+	}
+	if !h.expandAlsoCoveredBy(c, response, plain) {
+		return
+	}
 	c.JSON(http.StatusOK, response)
 }
 
+// expandAlsoCoveredBy adds near-duplicate coverage to response when the
+// request asks for ?expand=also_covered_by. It reports false after writing an
+// error response.
+func (h *Handlers) expandAlsoCoveredBy(c *gin.Context, response map[string]interface{}, items []models.Item) bool {
+	if c.Query("expand") != "also_covered_by" {
+		return true
+	}
+	if err := h.feedService.AddAlsoCoveredBy(c.Request.Context(), response, items); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
 func (h *Handlers) GetItem(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -123,6 +153,7 @@ func (h *Handlers) GetItem(c *gin.Context) {
 		"title":        item.Title,
 		"url":          item.URL,
 		"domain":       item.Domain,
+		"lang":         item.Lang,
 		"published_at": item.PublishedAt.Format(time.RFC3339),
 		"category":     item.Category,
 		"source": gin.H{
@@ -150,21 +181,270 @@ func (h *Handlers) GetItem(c *gin.Context) {
 			"credibility": convertToScale10(score.Credibility),
 			"novelty":     convertToScale10(score.Novelty),
 			"computed_at": score.ComputedAt.Format(time.RFC3339),
+
+			"config_version": score.ConfigVersion,
 		}
 	}
 
 	if summary != nil {
-		response["summary"] = gin.H{
+		summaryResp := gin.H{
 			"tldr":           summary.TLDR,
 			"why_it_matters": summary.WhyItMatters,
 			"tags":           summary.Tags,
+			"tag_scores":     summary.TagScores,
 			"method":         summary.Method,
 		}
+		if summary.Translation != nil {
+			summaryResp["translation"] = summary.Translation
+		}
+		response["summary"] = summaryResp
+	}
+
+	corroboration, coverage, err := h.feedService.GetCorroboration(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response["corroboration"] = gin.H{
+		"factor":       corroboration.Factor,
+		"domains":      corroboration.Domains,
+		"source_types": corroboration.SourceTypes,
+		"spread_hours": corroboration.SpreadHours,
+		"sources":      coverage,
 	}
 
+	mentions, err := h.feedService.ItemEntities(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if mentions == nil {
+		mentions = []models.EntityMention{}
+	}
+	response["entities"] = mentions
+
 	c.JSON(http.StatusOK, response)
 }
 
+// ListEntities lists the known entities, optionally filtered by ?kind=.
+func (h *Handlers) ListEntities(c *gin.Context) {
+	var kind *string
+	if k := c.Query("kind"); k != "" {
+		if !entities.ValidKind(k) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be one of " + strings.Join(entities.Kinds, ", ")})
+			return
+		}
+		kind = &k
+	}
+
+	list, err := h.feedService.ListEntities(c.Request.Context(), kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = []models.Entity{}
+	}
+	c.JSON(http.StatusOK, gin.H{"entities": list})
+}
+
+// GetEntity returns an entity, looked up by ID or slug, with its timeline of
+// items, newest first.
+func (h *Handlers) GetEntity(c *gin.Context) {
+	limit := 50
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 500 {
+			limit = l
+		}
+	}
+
+	entity, err := h.feedService.GetEntity(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if entity == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		return
+	}
+
+	timeline, err := h.feedService.EntityTimeline(c.Request.Context(), entity.ID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if timeline == nil {
+		timeline = []models.EntityTimelineItem{}
+	}
+	c.JSON(http.StatusOK, gin.H{"entity": entity, "items": timeline})
+}
+
+// langFilter reads the optional ?lang= filter (ISO 639-1, e.g. "id").
+func langFilter(c *gin.Context) *string {
+	lang := strings.ToLower(strings.TrimSpace(c.Query("lang")))
+	if lang == "" {
+		return nil
+	}
+	return &lang
+}
+
+// minConfidenceFilter reads the optional ?min_confidence= (0-1) a topic tag
+// must have been assigned with. It responds 400 and reports false when the
+// value is invalid.
+func minConfidenceFilter(c *gin.Context) (float64, bool) {
+	v := c.Query("min_confidence")
+	if v == "" {
+		return 0, true
+	}
+	minConfidence, err := strconv.ParseFloat(v, 64)
+	if err != nil || minConfidence < 0 || minConfidence > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_confidence must be a number between 0 and 1"})
+		return 0, false
+	}
+	return minConfidence, true
+}
+
+// RecordFeedback stores a team member's up or down vote on an item.
+func (h *Handlers) RecordFeedback(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+
+	var req struct {
+		Vote string  `json:"vote" binding:"required"` // up or down
+		User *string `json:"user"`
+		Note *string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fb := &models.ItemFeedback{ItemID: id, User: req.User, Note: req.Note}
+	switch req.Vote {
+	case "up":
+		fb.Vote = 1
+	case "down":
+		fb.Vote = -1
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "vote must be up or down"})
+		return
+	}
+
+	if _, err := h.feedService.GetItemByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		return
+	}
+	if err := h.feedService.RecordFeedback(c.Request.Context(), fb); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, fb)
+}
+
+// ExplainScore answers "why is this ranked here?" for one item.
+func (h *Handlers) ExplainScore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+
+	item, score, recomputed, err := h.feedService.ExplainScore(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		return
+	}
+	if score == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item has not been scored yet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"item_id":        item.ID,
+		"title":          item.Title,
+		"final":          score.Final,
+		"final_scale10":  convertToScale10(score.Final),
+		"config_version": score.ConfigVersion,
+		"computed_at":    score.ComputedAt.Format(time.RFC3339),
+		"recomputed":     recomputed,
+		"explanation":    score.Explanation,
+	})
+}
+
+// ScoreHistory returns an item's score timeline across recomputations.
+func (h *Handlers) ScoreHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+
+	limit := 100
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 1000 {
+			limit = l
+		}
+	}
+
+	if _, err := h.feedService.GetItemByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		return
+	}
+	history, err := h.feedService.ScoreHistory(c.Request.Context(), id, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if history == nil {
+		history = []models.ScoreHistory{}
+	}
+	c.JSON(http.StatusOK, gin.H{"item_id": id, "history": history})
+}
+
+// CompareScores reports how one day's ranking differs between two scoring
+// config versions. Version b defaults to the active config.
+func (h *Handlers) CompareScores(c *gin.Context) {
+	a := c.Query("a")
+	if a == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter 'a' is required"})
+		return
+	}
+	b := c.Query("b")
+
+	day := time.Now().UTC()
+	if dateStr := c.Query("date"); dateStr != "" {
+		d, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		day = d
+	}
+
+	topN := 20
+	if topStr := c.Query("top"); topStr != "" {
+		if n, err := strconv.Atoi(topStr); err == nil && n > 0 {
+			topN = n
+		}
+	}
+	limit := 20
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 200 {
+			limit = l
+		}
+	}
+
+	cmp, err := h.feedService.CompareRankings(c.Request.Context(), day, a, b, topN, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cmp)
+}
+
 func (h *Handlers) Search(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
@@ -177,6 +457,12 @@ func (h *Handlers) Search(c *gin.Context) {
 	if topic != "" {
 		topicPtr = &topic
 	}
+	minConfidence, ok := minConfidenceFilter(c)
+	if !ok {
+		return
+	}
+
+	lang := langFilter(c)
 
 	mode := c.DefaultQuery("mode", "hybrid") // text | semantic | hybrid
 
@@ -204,6 +490,10 @@ func (h *Handlers) Search(c *gin.Context) {
 		} else {
 			responseItems := make([]gin.H, 0, len(results))
 			for _, res := range results {
+				// Vector search has no language filter of its own.
+				if lang != nil && res.Item.Lang != *lang {
+					continue
+				}
 				_, _, score, summary, _ := h.feedService.GetItemWithDetails(c.Request.Context(), res.Item.ID)
 
 				itemResp := gin.H{
@@ -212,6 +502,7 @@ func (h *Handlers) Search(c *gin.Context) {
 					"url":            res.Item.URL,
 					"published_at":   res.Item.PublishedAt.Format(time.RFC3339),
 					"domain":         res.Item.Domain,
+					"lang":           res.Item.Lang,
 					"final_score":    convertToScale10(res.FinalScore),
 					"semantic_score": res.SemanticScore, // Keep raw score for debugging/UI info
 					"text_score":     res.TextScore,
@@ -231,7 +522,7 @@ func (h *Handlers) Search(c *gin.Context) {
 				"q":              query,
 				"topic":          topic,
 				"mode":           mode,
-				"total_estimate": len(results), // Vector search doesn't easily yield total matches
+				"total_estimate": len(responseItems), // Vector search doesn't easily yield total matches
 				"items":          responseItems,
 			})
 			return
@@ -239,7 +530,7 @@ func (h *Handlers) Search(c *gin.Context) {
 	}
 
 	// Classic Text Search Path (Fallback or explicit mode=text)
-	items, total, err := h.feedService.SearchItems(c.Request.Context(), query, topicPtr, limit, offset)
+	items, total, err := h.feedService.SearchItems(c.Request.Context(), query, topicPtr, minConfidence, lang, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -255,6 +546,7 @@ func (h *Handlers) Search(c *gin.Context) {
 			"url":          item.URL,
 			"published_at": item.PublishedAt.Format(time.RFC3339),
 			"domain":       item.Domain,
+			"lang":         item.Lang,
 			"final_score":  0.0,
 			"tags":         []string{},
 		}
@@ -285,9 +577,19 @@ func (h *Handlers) ListSources(c *gin.Context) {
 		return
 	}
 
+	reps, err := h.sourceService.ListReputations(c.Request.Context(), models.ReputationSource)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	repBySource := make(map[string]models.Reputation, len(reps))
+	for _, rep := range reps {
+		repBySource[rep.Key] = rep
+	}
+
 	responseItems := make([]gin.H, 0, len(sources))
 	for _, s := range sources {
-		responseItems = append(responseItems, gin.H{
+		resp := gin.H{
 			"id":                s.ID,
 			"name":              s.Name,
 			"type":              s.Type,
@@ -297,12 +599,50 @@ func (h *Handlers) ListSources(c *gin.Context) {
 			"status":            s.Status,
 			"last_test_status":  s.LastTestStatus,
 			"last_test_message": s.LastTestMessage,
-		})
+			"schedule": gin.H{
+				"schedule":              s.Schedule,
+				"adaptive":              s.Adaptive,
+				"poll_interval_seconds": s.PollIntervalSeconds,
+				"last_polled_at":        s.LastPolledAt,
+				"next_poll_at":          s.NextPollAt,
+			},
+			"health": gin.H{
+				"circuit_state":        s.CircuitState,
+				"consecutive_failures": s.ConsecutiveFailures,
+				"next_attempt_at":      s.NextAttemptAt,
+				"failing_since":        s.FailingSince,
+				"last_success_at":      s.LastSuccessAt,
+			},
+		}
+		if rep, ok := repBySource[s.ID.String()]; ok {
+			resp["reputation"] = rep
+		}
+		responseItems = append(responseItems, resp)
 	}
 
 	c.JSON(http.StatusOK, gin.H{"sources": responseItems})
 }
 
+// ListReputations lists learned reputations, best first. ?kind=domain
+// (default) or ?kind=source.
+func (h *Handlers) ListReputations(c *gin.Context) {
+	kind := c.DefaultQuery("kind", models.ReputationDomain)
+	if kind != models.ReputationDomain && kind != models.ReputationSource {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be domain or source"})
+		return
+	}
+
+	reps, err := h.sourceService.ListReputations(c.Request.Context(), kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if reps == nil {
+		reps = []models.Reputation{}
+	}
+	c.JSON(http.StatusOK, gin.H{"kind": kind, "reputations": reps})
+}
+
 func (h *Handlers) CreateSource(c *gin.Context) {
 	var req struct {
 		Name        string          `json:"name" binding:"required"`
@@ -310,6 +650,8 @@ func (h *Handlers) CreateSource(c *gin.Context) {
 		Category    string          `json:"category" binding:"required"`
 		URL         string          `json:"url" binding:"required"`
 		MappingJSON json.RawMessage `json:"mapping_json,omitempty"`
+		Schedule    string          `json:"schedule,omitempty"`
+		Adaptive    bool            `json:"adaptive,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -317,6 +659,11 @@ func (h *Handlers) CreateSource(c *gin.Context) {
 		return
 	}
 
+	if err := services.ValidateSchedule(req.Schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	source := &models.Source{
 		Name:     req.Name,
 		Type:     req.Type,
@@ -324,6 +671,8 @@ func (h *Handlers) CreateSource(c *gin.Context) {
 		URL:      req.URL,
 		Enabled:  false,
 		Status:   "pending",
+		Schedule: req.Schedule,
+		Adaptive: req.Adaptive,
 	}
 
 	if req.MappingJSON != nil {
@@ -402,3 +751,37 @@ func (h *Handlers) EnableSource(c *gin.Context) {
 		"status":  source.Status,
 	})
 }
+
+func (h *Handlers) SetSourceSchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid source id"})
+		return
+	}
+
+	var req struct {
+		Schedule string `json:"schedule"`
+		Adaptive bool   `json:"adaptive"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := services.ValidateSchedule(req.Schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.sourceService.SetSchedule(c.Request.Context(), id, req.Schedule, req.Adaptive); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":       id,
+		"schedule": req.Schedule,
+		"adaptive": req.Adaptive,
+	})
+}
@@ -0,0 +1,145 @@
+// Package ingest stores newly discovered links as items. The connector
+// worker and the discovery orchestrator both go through Ingester, so an
+// item is normalized, deduplicated and linked the same way whichever path
+// found it.
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/connectors"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/dedup"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
+	"github.com/hidatara-ds/evolipia-radar/pkg/langdetect"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/normalizer"
+	"github.com/hidatara-ds/evolipia-radar/pkg/summarizer"
+)
+
+// ErrInvalid marks candidates that cannot be stored, e.g. because the URL
+// does not parse.
+var ErrInvalid = errors.New("invalid item")
+
+// Candidate is a discovered link to store.
+type Candidate struct {
+	SourceID    uuid.UUID
+	URL         string
+	Title       string
+	Excerpt     string
+	Domain      string // as reported; used when the canonical URL has no host
+	Category    string
+	PublishedAt time.Time // now when zero
+}
+
+// Ingester stores candidates as items.
+type Ingester struct {
+	cfg         *config.Config
+	itemRepo    *db.ItemRepository
+	summaryRepo *db.SummaryRepository
+	dedup       *dedup.Detector
+	entities    *entities.Extractor
+}
+
+// NewIngester returns an Ingester that links new items to entities with
+// extractor.
+func NewIngester(database *db.DB, cfg *config.Config, extractor *entities.Extractor) *Ingester {
+	return &Ingester{
+		cfg:         cfg,
+		itemRepo:    db.NewItemRepository(database),
+		summaryRepo: db.NewSummaryRepository(database),
+		dedup:       dedup.NewDetector(database, cfg),
+		entities:    extractor,
+	}
+}
+
+// Store normalizes c's URL, resolves its canonical URL and, unless the item
+// already exists under either URL or the same content hash, creates it,
+// links its near-duplicates and gazetteer entities and gives it an
+// extractive baseline summary. It reports whether the item was newly
+// created; otherwise the existing item is returned. Queueing enrichment is
+// left to the caller.
+func (in *Ingester) Store(ctx context.Context, c Candidate) (*models.Item, bool, error) {
+	normalizedURL, err := normalizer.NormalizeURL(c.URL)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: failed to normalize URL: %w", ErrInvalid, err)
+	}
+
+	// Links we've already stored (as reported or as canonical) skip resolution.
+	existing, err := in.itemRepo.GetByURL(ctx, normalizedURL)
+	if err != nil || existing != nil {
+		return existing, false, wrapLookup(err)
+	}
+
+	canonical := connectors.CanonicalURL(ctx, normalizedURL, in.cfg)
+	// A different link to a story we already have, e.g. a tracking
+	// redirect or an AMP page.
+	if canonical != normalizedURL {
+		existing, err = in.itemRepo.GetByURL(ctx, canonical)
+		if err != nil || existing != nil {
+			return existing, false, wrapLookup(err)
+		}
+	}
+	contentHash := normalizer.ContentHash(c.Title, canonical)
+	existing, err = in.itemRepo.GetByContentHash(ctx, contentHash)
+	if err != nil || existing != nil {
+		return existing, false, wrapLookup(err)
+	}
+
+	domain := c.Domain
+	if u, err := url.Parse(canonical); err == nil && u.Hostname() != "" {
+		domain = normalizer.NormalizeDomain(u.Hostname())
+	}
+	publishedAt := c.PublishedAt
+	if publishedAt.IsZero() {
+		publishedAt = time.Now()
+	}
+
+	item := &models.Item{
+		SourceID:     c.SourceID,
+		Title:        c.Title,
+		URL:          normalizedURL,
+		CanonicalURL: &canonical,
+		PublishedAt:  publishedAt,
+		ContentHash:  contentHash,
+		Domain:       domain,
+		Category:     c.Category,
+		Lang:         langdetect.Detect(c.Title + " " + c.Excerpt),
+	}
+	if c.Excerpt != "" {
+		excerpt := c.Excerpt
+		item.RawExcerpt = &excerpt
+	}
+
+	if err := in.itemRepo.Create(ctx, item); err != nil {
+		return nil, false, fmt.Errorf("failed to create item: %w", err)
+	}
+
+	if _, err := in.dedup.Link(ctx, item); err != nil {
+		log.Printf("Error checking near-duplicates for %s: %v", item.URL, err)
+	}
+	if _, err := in.entities.Link(ctx, item); err != nil {
+		log.Printf("Error linking entities for %s: %v", item.URL, err)
+	}
+
+	// Baseline summary so the item is servable even if LLM enrichment fails.
+	if err := in.summaryRepo.Upsert(ctx, summarizer.GenerateExtractiveSummary(item)); err != nil {
+		log.Printf("Error creating summary for %s: %v", item.URL, err)
+	}
+
+	return item, true, nil
+}
+
+func wrapLookup(err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to check duplicate: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+// Package jobs implements the Postgres-backed enrichment queue. Producers
+// enqueue work keyed by item; runners claim it with SKIP LOCKED, retry
+// failures with exponential backoff and dead-letter jobs that keep failing.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// Job types.
+const (
+	TypeSummarize = "summarize"
+	TypeEmbed     = "embed"
+	TypeScore     = "score"
+	TypeCluster   = "cluster"
+	TypeTranslate = "translate"
+	TypeEntities  = "entities"
+)
+
+// Job statuses.
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusDead    = "dead"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBaseBackoff = 30 * time.Second
+	defaultMaxBackoff  = 6 * time.Hour
+	defaultStaleAfter  = 15 * time.Minute
+	defaultBatchSize   = 10
+)
+
+// ItemPayload is the payload shared by all item enrichment jobs.
+type ItemPayload struct {
+	ItemID  uuid.UUID `json:"item_id"`
+	Title   string    `json:"title"`
+	Content string    `json:"content,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	// Schedule names the shared schedule the job was spaced on, if any (see
+	// EnqueueItemsSpaced).
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// DecodeItemPayload unmarshals an ItemPayload from job.
+func DecodeItemPayload(job models.Job) (ItemPayload, error) {
+	var p ItemPayload
+	if err := json.Unmarshal(job.Payload, &p); err != nil {
+		return p, fmt.Errorf("invalid %s payload: %w", job.Type, err)
+	}
+	if p.ItemID == uuid.Nil {
+		return p, fmt.Errorf("%s payload is missing item_id", job.Type)
+	}
+	return p, nil
+}
+
+// Queue enqueues jobs.
+type Queue struct {
+	repo *db.JobRepository
+}
+
+func NewQueue(database *db.DB) *Queue {
+	return &Queue{repo: db.NewJobRepository(database)}
+}
+
+// EnqueueItem schedules jobType for the item in p. Enqueuing the same type
+// for an item that already has a live job is a no-op.
+func (q *Queue) EnqueueItem(ctx context.Context, jobType string, p ItemPayload) error {
+	return q.enqueueItem(ctx, jobType, p, nil)
+}
+
+// EnqueueItemAt is EnqueueItem for a job that must not run before runAt.
+func (q *Queue) EnqueueItemAt(ctx context.Context, jobType string, p ItemPayload, runAt time.Time) error {
+	return q.enqueueItem(ctx, jobType, p, &runAt)
+}
+
+// EnqueueItemsSpaced schedules jobType for each item in ps, spacing apart,
+// after the jobs already on schedule. The schedule lives in the database, so
+// callers in any process queue behind each other instead of stacking their
+// jobs at the same times. It returns how many jobs were created.
+func (q *Queue) EnqueueItemsSpaced(ctx context.Context, jobType, schedule string, ps []ItemPayload, spacing time.Duration) (int, error) {
+	keys := make([]string, len(ps))
+	payloads := make([]any, len(ps))
+	for i, p := range ps {
+		p.Schedule = schedule
+		keys[i], payloads[i] = p.ItemID.String(), p
+	}
+	n, err := q.repo.EnqueueSpaced(ctx, jobType, schedule, keys, payloads, defaultMaxAttempts, spacing)
+	if err != nil {
+		return n, fmt.Errorf("failed to schedule %s jobs: %w", jobType, err)
+	}
+	return n, nil
+}
+
+func (q *Queue) enqueueItem(ctx context.Context, jobType string, p ItemPayload, runAt *time.Time) error {
+	_, err := q.repo.Enqueue(ctx, jobType, p.ItemID.String(), p, defaultMaxAttempts, runAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s job for %s: %w", jobType, p.ItemID, err)
+	}
+	return nil
+}
+
+// Handler processes one job. Returning an error schedules a retry.
+type Handler func(ctx context.Context, job models.Job) error
+
+// runnerStore is the part of db.JobRepository a Runner uses.
+type runnerStore interface {
+	Claim(ctx context.Context, owner string, types []string, stale time.Duration, limit int) ([]models.Job, error)
+	Complete(ctx context.Context, id uuid.UUID) error
+	Fail(ctx context.Context, id uuid.UUID, lastErr string, nextRunAt time.Time, dead bool) error
+	Release(ctx context.Context, ids []uuid.UUID) error
+}
+
+// Runner claims and executes jobs for the registered types.
+type Runner struct {
+	repo     runnerStore
+	owner    string
+	handlers map[string]Handler
+
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	StaleAfter  time.Duration // running jobs older than this are reclaimed
+	BatchSize   int
+}
+
+// NewRunner creates a Runner that claims jobs as this process's instance.
+func NewRunner(database *db.DB) *Runner {
+	return &Runner{
+		repo:        db.NewJobRepository(database),
+		owner:       db.InstanceID(),
+		handlers:    make(map[string]Handler),
+		BaseBackoff: defaultBaseBackoff,
+		MaxBackoff:  defaultMaxBackoff,
+		StaleAfter:  defaultStaleAfter,
+		BatchSize:   defaultBatchSize,
+	}
+}
+
+// Register sets the handler for jobType.
+func (r *Runner) Register(jobType string, h Handler) {
+	r.handlers[jobType] = h
+}
+
+func (r *Runner) types() []string {
+	types := make([]string, 0, len(r.handlers))
+	for t := range r.handlers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// RunOnce claims one batch and processes it. It returns the number of jobs
+// that completed successfully.
+func (r *Runner) RunOnce(ctx context.Context) (int, error) {
+	if len(r.handlers) == 0 {
+		return 0, nil
+	}
+
+	claimed, err := r.repo.Claim(ctx, r.owner, r.types(), r.StaleAfter, r.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim jobs: %w", err)
+	}
+
+	done := 0
+	for i, job := range claimed {
+		if ctx.Err() != nil {
+			r.release(ctx, claimed[i:]...)
+			break
+		}
+		if r.execute(ctx, job) {
+			done++
+		}
+	}
+	return done, nil
+}
+
+// release puts jobs the runner did not get to finish back in the queue,
+// without counting the attempt against them.
+func (r *Runner) release(ctx context.Context, unfinished ...models.Job) {
+	ids := make([]uuid.UUID, len(unfinished))
+	for i, job := range unfinished {
+		ids[i] = job.ID
+	}
+	if err := r.repo.Release(context.WithoutCancel(ctx), ids); err != nil {
+		log.Printf("[JOBS] Failed to release %d unfinished jobs: %v", len(ids), err)
+	}
+}
+
+// Drain runs batches until the queue has nothing runnable or ctx ends.
+func (r *Runner) Drain(ctx context.Context) int {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := r.RunOnce(ctx)
+		if err != nil {
+			log.Printf("[JOBS] %v", err)
+			break
+		}
+		total += n
+		if n == 0 {
+			break
+		}
+	}
+	return total
+}
+
+// Start polls the queue every interval until ctx is cancelled.
+func (r *Runner) Start(ctx context.Context, interval time.Duration) {
+	log.Printf("[JOBS] Runner %s started for %v (poll %v)", r.owner, r.types(), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n := r.Drain(ctx); n > 0 {
+			log.Printf("[JOBS] Completed %d jobs", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job models.Job) bool {
+	// Record the outcome even if the run context was cancelled mid-job.
+	bookkeeping := context.WithoutCancel(ctx)
+
+	err := r.handlers[job.Type](ctx, job)
+	if err == nil {
+		if cErr := r.repo.Complete(bookkeeping, job.ID); cErr != nil {
+			log.Printf("[JOBS] Failed to mark %s job %s done: %v", job.Type, job.ID, cErr)
+		}
+		return true
+	}
+	// The run was cut short, e.g. by a trigger's deadline: that says nothing
+	// about the job.
+	if ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		r.release(ctx, job)
+		return false
+	}
+
+	dead := job.Attempts >= job.MaxAttempts
+	next := time.Now().Add(r.backoff(job.Attempts))
+	if dead {
+		log.Printf("[JOBS] %s job %s dead after %d attempts: %v", job.Type, job.ID, job.Attempts, err)
+	} else {
+		log.Printf("[JOBS] %s job %s failed (attempt %d/%d), retrying at %s: %v",
+			job.Type, job.ID, job.Attempts, job.MaxAttempts, next.Format(time.RFC3339), err)
+	}
+
+	if fErr := r.repo.Fail(bookkeeping, job.ID, err.Error(), next, dead); fErr != nil {
+		log.Printf("[JOBS] Failed to record failure of %s job %s: %v", job.Type, job.ID, fErr)
+	}
+	return false
+}
+
+// backoff returns BaseBackoff doubled for every previous attempt, capped at MaxBackoff.
+func (r *Runner) backoff(attempts int) time.Duration {
+	d := r.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= r.MaxBackoff {
+			return r.MaxBackoff
+		}
+	}
+	return d
+}
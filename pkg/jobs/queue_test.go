@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestRunnerBackoff(t *testing.T) {
+	r := &Runner{BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 5 * time.Minute},
+		{12, 5 * time.Minute},
+	}
+	for _, tc := range cases {
+		if got := r.backoff(tc.attempts); got != tc.want {
+			t.Errorf("backoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
+
+// fakeStore hands out a fixed batch and records what happened to each job.
+type fakeStore struct {
+	batch     []models.Job
+	completed []uuid.UUID
+	failed    []uuid.UUID
+	released  []uuid.UUID
+}
+
+func (s *fakeStore) Claim(context.Context, string, []string, time.Duration, int) ([]models.Job, error) {
+	batch := s.batch
+	s.batch = nil
+	return batch, nil
+}
+
+func (s *fakeStore) Complete(_ context.Context, id uuid.UUID) error {
+	s.completed = append(s.completed, id)
+	return nil
+}
+
+func (s *fakeStore) Fail(_ context.Context, id uuid.UUID, _ string, _ time.Time, _ bool) error {
+	s.failed = append(s.failed, id)
+	return nil
+}
+
+func (s *fakeStore) Release(_ context.Context, ids []uuid.UUID) error {
+	s.released = append(s.released, ids...)
+	return nil
+}
+
+func TestRunner_CancelledRunReleasesJobs(t *testing.T) {
+	jobs := make([]models.Job, 3)
+	for i := range jobs {
+		jobs[i] = models.Job{ID: uuid.New(), Type: TypeScore, Attempts: 5, MaxAttempts: 5}
+	}
+	store := &fakeStore{batch: jobs}
+	r := &Runner{repo: store, handlers: make(map[string]Handler), BaseBackoff: time.Second, MaxBackoff: time.Minute}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	r.Register(TypeScore, func(ctx context.Context, job models.Job) error {
+		calls++
+		if calls == 1 {
+			return nil
+		}
+		cancel() // the deadline hits while the second job runs
+		return ctx.Err()
+	})
+
+	done, err := r.RunOnce(ctx)
+	if err != nil || done != 1 {
+		t.Fatalf("RunOnce = %d, %v; want 1 done", done, err)
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2: no job may start after cancellation", calls)
+	}
+	if len(store.failed) != 0 {
+		t.Errorf("cancelled jobs were failed: %v", store.failed)
+	}
+	if len(store.completed) != 1 || len(store.released) != 2 {
+		t.Errorf("completed %d, released %d; want 1 and 2", len(store.completed), len(store.released))
+	}
+}
+
+func TestRunner_HandlerErrorFails(t *testing.T) {
+	job := models.Job{ID: uuid.New(), Type: TypeScore, Attempts: 1, MaxAttempts: 5}
+	store := &fakeStore{batch: []models.Job{job}}
+	r := &Runner{repo: store, handlers: make(map[string]Handler), BaseBackoff: time.Second, MaxBackoff: time.Minute}
+	r.Register(TypeScore, func(context.Context, models.Job) error {
+		return errors.New("upstream returned 500")
+	})
+
+	if _, err := r.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.failed) != 1 || len(store.released) != 0 {
+		t.Errorf("failed %d, released %d; want 1 and 0", len(store.failed), len(store.released))
+	}
+}
@@ -0,0 +1,65 @@
+package langdetect
+
+// corpus holds sample text per language from which the trigram profiles are
+// built. The samples are written in the register of tech news so that
+// headlines and excerpts score well; add a language by adding a sample.
+var corpus = map[string]string{
+	"en": `The company announced a new open source language model that outperforms previous versions on reasoning and coding benchmarks.
+Researchers said the system was trained on a larger dataset and can run on a single graphics card, which makes it easier for developers to build their own applications.
+According to the report, the startup raised new funding from investors and plans to hire more engineers this year.
+The update also includes security fixes, better documentation and a faster inference engine for production workloads.
+Critics warned that the technology could be misused, while others argued that open models help the whole industry move forward.
+What this means for teams is that they should evaluate the release before they deploy it, because the results depend on the data they have.
+Meta releases a family of open weights models, and Google launches its latest assistant with improved reasoning and longer context.
+Show HN: a tiny library that makes training faster, written in Rust, with benchmarks against the most popular frameworks.
+How we scaled our inference cluster to millions of requests, and the lessons we learned from running large language models in production.`,
+
+	"id": `Perusahaan itu mengumumkan model bahasa sumber terbuka yang baru dan lebih unggul dibandingkan versi sebelumnya dalam pengujian penalaran dan pemrograman.
+Para peneliti mengatakan sistem tersebut dilatih dengan kumpulan data yang lebih besar dan dapat dijalankan pada satu kartu grafis, sehingga memudahkan pengembang untuk membangun aplikasi mereka sendiri.
+Menurut laporan tersebut, perusahaan rintisan ini mendapatkan pendanaan baru dari para investor dan berencana merekrut lebih banyak insinyur tahun ini.
+Pembaruan ini juga mencakup perbaikan keamanan, dokumentasi yang lebih baik, dan mesin inferensi yang lebih cepat untuk kebutuhan produksi.
+Pemerintah menyatakan bahwa kecerdasan buatan akan digunakan untuk meningkatkan layanan publik dan pendidikan di seluruh Indonesia.
+Hal ini berarti tim harus mengevaluasi rilis tersebut sebelum menggunakannya, karena hasilnya bergantung pada data yang mereka miliki.`,
+
+	"de": `Das Unternehmen hat ein neues quelloffenes Sprachmodell vorgestellt, das frühere Versionen bei Tests zum logischen Denken und Programmieren übertrifft.
+Die Forscher sagten, das System sei mit einem größeren Datensatz trainiert worden und könne auf einer einzigen Grafikkarte laufen, was es Entwicklern erleichtert, eigene Anwendungen zu bauen.
+Laut dem Bericht hat das Start-up neue Finanzierung von Investoren erhalten und will in diesem Jahr weitere Ingenieure einstellen.
+Das Update enthält außerdem Sicherheitskorrekturen, eine bessere Dokumentation und eine schnellere Inferenz für den produktiven Einsatz.
+Kritiker warnten, dass die Technologie missbraucht werden könnte, während andere meinten, dass offene Modelle der ganzen Branche helfen.
+Für Teams bedeutet das, dass sie die Veröffentlichung prüfen sollten, bevor sie sie einsetzen, weil die Ergebnisse von ihren Daten abhängen.`,
+
+	"fr": `L'entreprise a annoncé un nouveau modèle de langage open source qui surpasse les versions précédentes sur les tests de raisonnement et de programmation.
+Les chercheurs ont déclaré que le système a été entraîné sur un ensemble de données plus large et qu'il peut fonctionner sur une seule carte graphique, ce qui facilite le travail des développeurs.
+Selon le rapport, la jeune entreprise a levé de nouveaux fonds auprès des investisseurs et prévoit de recruter davantage d'ingénieurs cette année.
+La mise à jour comprend aussi des correctifs de sécurité, une meilleure documentation et un moteur d'inférence plus rapide pour la production.
+Les critiques ont averti que la technologie pourrait être détournée, tandis que d'autres estiment que les modèles ouverts aident toute l'industrie.
+Pour les équipes, cela signifie qu'elles doivent évaluer cette version avant de la déployer, car les résultats dépendent de leurs données.`,
+
+	"es": `La empresa anunció un nuevo modelo de lenguaje de código abierto que supera a las versiones anteriores en las pruebas de razonamiento y programación.
+Los investigadores dijeron que el sistema fue entrenado con un conjunto de datos más grande y que puede funcionar en una sola tarjeta gráfica, lo que facilita que los desarrolladores creen sus propias aplicaciones.
+Según el informe, la empresa emergente recaudó nueva financiación de inversores y planea contratar a más ingenieros este año.
+La actualización también incluye correcciones de seguridad, una mejor documentación y un motor de inferencia más rápido para entornos de producción.
+Los críticos advirtieron que la tecnología podría usarse de forma indebida, mientras que otros sostienen que los modelos abiertos ayudan a toda la industria.
+Para los equipos, esto significa que deben evaluar la versión antes de desplegarla, porque los resultados dependen de los datos que tienen.`,
+
+	"pt": `A empresa anunciou um novo modelo de linguagem de código aberto que supera as versões anteriores nos testes de raciocínio e programação.
+Os pesquisadores disseram que o sistema foi treinado com um conjunto de dados maior e que pode rodar em uma única placa de vídeo, o que facilita para os desenvolvedores criarem suas próprias aplicações.
+De acordo com o relatório, a startup captou novos investimentos e pretende contratar mais engenheiros neste ano.
+A atualização também traz correções de segurança, uma documentação melhor e um mecanismo de inferência mais rápido para produção.
+Os críticos alertaram que a tecnologia pode ser usada de forma indevida, enquanto outros afirmam que os modelos abertos ajudam toda a indústria.
+Para as equipes, isso significa que elas devem avaliar a versão antes de colocá-la em produção, porque os resultados dependem dos dados que possuem.`,
+
+	"it": `L'azienda ha annunciato un nuovo modello linguistico open source che supera le versioni precedenti nei test di ragionamento e di programmazione.
+I ricercatori hanno detto che il sistema è stato addestrato su un insieme di dati più ampio e che può funzionare su una sola scheda grafica, il che rende più facile per gli sviluppatori creare le proprie applicazioni.
+Secondo il rapporto, la startup ha raccolto nuovi finanziamenti dagli investitori e prevede di assumere altri ingegneri quest'anno.
+L'aggiornamento include anche correzioni di sicurezza, una documentazione migliore e un motore di inferenza più veloce per la produzione.
+I critici hanno avvertito che la tecnologia potrebbe essere usata in modo improprio, mentre altri sostengono che i modelli aperti aiutano tutto il settore.
+Per i gruppi di lavoro questo significa che devono valutare la versione prima di distribuirla, perché i risultati dipendono dai loro dati.`,
+
+	"nl": `Het bedrijf heeft een nieuw opensource taalmodel aangekondigd dat eerdere versies overtreft in tests voor redeneren en programmeren.
+De onderzoekers zeiden dat het systeem is getraind op een grotere dataset en op een enkele videokaart kan draaien, waardoor het voor ontwikkelaars makkelijker wordt om hun eigen toepassingen te bouwen.
+Volgens het rapport heeft de startup nieuwe financiering van investeerders opgehaald en wil het dit jaar meer ingenieurs aannemen.
+De update bevat ook beveiligingsoplossingen, betere documentatie en een snellere inferentie voor gebruik in productie.
+Critici waarschuwden dat de technologie kan worden misbruikt, terwijl anderen vinden dat open modellen de hele sector vooruit helpen.
+Voor teams betekent dit dat ze de release moeten beoordelen voordat ze die gebruiken, omdat de resultaten afhangen van hun eigen gegevens.`,
+}
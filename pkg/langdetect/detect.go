@@ -0,0 +1,181 @@
+// Package langdetect guesses the language of short texts such as headlines
+// and excerpts. Latin-script languages are told apart with a character
+// trigram naive Bayes model; other scripts are recognized by their Unicode
+// ranges.
+package langdetect
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minLetters is the shortest text (in letters) worth classifying.
+	minLetters = 12
+	// minMargin is the average per-trigram log-likelihood lead the best
+	// language needs over the runner-up; below it the result is unknown.
+	minMargin = 0.03
+)
+
+type profile struct {
+	counts map[string]int
+	total  int
+}
+
+var profiles = func() map[string]profile {
+	out := make(map[string]profile, len(corpus))
+	for lang, text := range corpus {
+		p := profile{counts: make(map[string]int)}
+		for _, g := range trigrams(text) {
+			p.counts[g]++
+			p.total++
+		}
+		out[lang] = p
+	}
+	return out
+}()
+
+// vocabulary is the number of distinct trigrams across all profiles, used
+// for add-one smoothing.
+var vocabulary = func() int {
+	seen := make(map[string]bool)
+	for _, p := range profiles {
+		for g := range p.counts {
+			seen[g] = true
+		}
+	}
+	return len(seen)
+}()
+
+// Detect returns the ISO 639-1 code of text's language, or "" when the text
+// is too short or too ambiguous to tell.
+func Detect(text string) string {
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+
+	grams := trigrams(text)
+	if countLetters(text) < minLetters || len(grams) == 0 {
+		return ""
+	}
+
+	best, second := math.Inf(-1), math.Inf(-1)
+	bestLang := ""
+	for lang, p := range profiles {
+		score := 0.0
+		for _, g := range grams {
+			score += math.Log(float64(p.counts[g]+1) / float64(p.total+vocabulary))
+		}
+		switch {
+		case score > best:
+			second = best
+			best, bestLang = score, lang
+		case score > second:
+			second = score
+		}
+	}
+
+	if (best-second)/float64(len(grams)) < minMargin {
+		return ""
+	}
+	return bestLang
+}
+
+// detectScript recognizes languages with their own script when at least
+// half of text's letters belong to it.
+func detectScript(text string) string {
+	var letters, han, kana, hangul, cyrillic, arabic, thai int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	half := func(n int) bool { return n*2 >= letters }
+	switch {
+	case kana > 0 && half(kana+han):
+		return "ja"
+	case half(han):
+		return "zh"
+	case half(hangul):
+		return "ko"
+	case half(cyrillic):
+		return "ru"
+	case half(arabic):
+		return "ar"
+	case half(thai):
+		return "th"
+	}
+	return ""
+}
+
+// trigrams returns the character trigrams of each word in text, padded with
+// spaces so word starts and ends are distinct features. Digits and
+// punctuation are dropped.
+func trigrams(text string) []string {
+	var out []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		runes := []rune(" " + strings.Trim(word, "'") + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			out = append(out, string(runes[i:i+3]))
+		}
+	}
+	return out
+}
+
+func countLetters(text string) int {
+	n := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
+
+var names = map[string]string{
+	"en": "English",
+	"id": "Indonesian",
+	"de": "German",
+	"fr": "French",
+	"es": "Spanish",
+	"pt": "Portuguese",
+	"it": "Italian",
+	"nl": "Dutch",
+	"ja": "Japanese",
+	"zh": "Chinese",
+	"ko": "Korean",
+	"ru": "Russian",
+	"ar": "Arabic",
+	"th": "Thai",
+}
+
+// Name returns the English name of a language code, or the code itself when
+// it is not one the detector knows.
+func Name(code string) string {
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return code
+}
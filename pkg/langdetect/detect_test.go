@@ -0,0 +1,32 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"OpenAI launches GPT-5 with improved reasoning", "en"},
+		{"Show HN: A tiny vector database written in Zig", "en"},
+		{"Pemerintah luncurkan pusat data kecerdasan buatan di Jakarta", "id"},
+		{"Startup AI asal Bandung raih pendanaan Rp 50 miliar", "id"},
+		{"Google stellt neues KI-Modell für Entwickler vor", "de"},
+		{"Mistral lève 600 millions d'euros pour ses modèles ouverts", "fr"},
+		{"La inteligencia artificial llega a los hospitales de Madrid", "es"},
+		{"Nova ferramenta de IA ajuda médicos a diagnosticar doenças", "pt"},
+		{"Il nuovo modello di intelligenza artificiale arriva in Italia", "it"},
+		{"Nieuwe AI-chip van ASML maakt training sneller", "nl"},
+		{"人工智能模型发布", "zh"},
+		{"新しいAIモデルを発表", "ja"},
+		{"새로운 인공지능 모델 공개", "ko"},
+		{"GPT-5", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Detect(tt.text); got != tt.want {
+			t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/fetch"
 )
 
 // Client handles LLM API interactions via OpenRouter
@@ -61,11 +63,9 @@ type CompletionResponse struct {
 // NewClient creates a new LLM client
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey:  apiKey,
-		baseURL: "https://openrouter.ai/api/v1",
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+		apiKey:     apiKey,
+		baseURL:    "https://openrouter.ai/api/v1",
+		httpClient: fetch.NewClient(60 * time.Second),
 	}
 }
 
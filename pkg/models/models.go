@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,19 +20,126 @@ type Source struct {
 	LastTestMessage *string   `json:"last_test_message,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Circuit breaker state, updated after every fetch run
+	CircuitState        string     `json:"circuit_state"` // closed, open, half_open
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextAttemptAt       *time.Time `json:"next_attempt_at,omitempty"`
+	FailingSince        *time.Time `json:"failing_since,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+
+	// Polling schedule; an empty Schedule polls on every worker tick
+	Schedule            string     `json:"schedule"` // cron expression or "@every <duration>"
+	Adaptive            bool       `json:"adaptive"`
+	PollIntervalSeconds *int       `json:"poll_interval_seconds,omitempty"`
+	LastPolledAt        *time.Time `json:"last_polled_at,omitempty"`
+	NextPollAt          *time.Time `json:"next_poll_at,omitempty"`
 }
 
 type Item struct {
+	ID           uuid.UUID  `json:"id"`
+	SourceID     uuid.UUID  `json:"source_id"`
+	Title        string     `json:"title"`
+	URL          string     `json:"url"`
+	PublishedAt  time.Time  `json:"published_at"`
+	ContentHash  string     `json:"content_hash"`
+	Domain       string     `json:"domain"`
+	Category     string     `json:"category"`
+	RawExcerpt   *string    `json:"raw_excerpt,omitempty"`
+	CanonicalURL *string    `json:"canonical_url,omitempty"`
+	DuplicateOf  *uuid.UUID `json:"duplicate_of,omitempty"` // canonical item of a near-duplicate story
+	Lang         string     `json:"lang,omitempty"`         // ISO 639-1, empty when unknown
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CoverageItem is an item covering the same story as another: a
+// near-duplicate of it or a member of the same cluster.
+type CoverageItem struct {
 	ID          uuid.UUID `json:"id"`
-	SourceID    uuid.UUID `json:"source_id"`
 	Title       string    `json:"title"`
 	URL         string    `json:"url"`
+	Domain      string    `json:"domain"`
+	SourceType  string    `json:"source_type"`
 	PublishedAt time.Time `json:"published_at"`
-	ContentHash string    `json:"content_hash"`
+	Via         string    `json:"via"` // self, duplicate, cluster
+}
+
+// Corroboration summarises how independently a story is covered.
+type Corroboration struct {
+	Factor      float64  `json:"factor"`       // 0-1, the corroboration score component
+	Domains     int      `json:"domains"`      // distinct domains, the item's own included
+	SourceTypes int      `json:"source_types"` // distinct source types, the item's own included
+	SpreadHours *float64 `json:"spread_hours"` // first coverage to first coverage on another domain; nil if none
+	Sources     []string `json:"sources"`      // other domains covering the story
+}
+
+// Topic is a node of the topic taxonomy shared by tagging, feed topic
+// filters and relevance scoring.
+type Topic struct {
+	Slug      string    `json:"slug"`
+	Parent    string    `json:"parent,omitempty"` // slug of the parent topic; empty for roots
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`  // other names tags and filters may use
+	Keywords  []string  `json:"keywords"` // case-insensitive substrings that tag an item
+	Patterns  []string  `json:"patterns"` // regular expressions matched against lowercased text
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Entity kinds.
+const (
+	EntityModel        = "model"
+	EntityOrganization = "organization"
+	EntityDataset      = "dataset"
+	EntityPerson       = "person"
+	EntityProduct      = "product"
+)
+
+// Entity is a canonical named thing items mention: a model, organization,
+// dataset, person or product.
+type Entity struct {
+	ID            uuid.UUID `json:"id"`
+	Slug          string    `json:"slug"`
+	Name          string    `json:"name"`
+	Kind          string    `json:"kind"`
+	Aliases       []string  `json:"aliases"`        // other names that refer to the entity
+	CaseSensitive bool      `json:"case_sensitive"` // match names only with their exact case, for common words
+	Origin        string    `json:"origin"`         // seed, llm or manual
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ItemEntity links an item to an entity it mentions.
+type ItemEntity struct {
+	ItemID   uuid.UUID `json:"item_id"`
+	EntityID uuid.UUID `json:"entity_id"`
+	Mention  string    `json:"mention"` // the text that matched
+	Method   string    `json:"method"`  // gazetteer or llm
+}
+
+// EntityMention is an entity as mentioned by one item.
+type EntityMention struct {
+	Entity
+	Mention string `json:"mention"`
+	Method  string `json:"method"`
+}
+
+// EntityTimelineItem is an item on an entity's timeline.
+type EntityTimelineItem struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
 	Domain      string    `json:"domain"`
-	Category    string    `json:"category"`
-	RawExcerpt  *string   `json:"raw_excerpt,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
+	PublishedAt time.Time `json:"published_at"`
+	Final       *float64  `json:"final"` // nil until scored
+	Mention     string    `json:"mention"`
+	Method      string    `json:"method"`
+}
+
+// NearDuplicateCandidate is a stored item sharing an LSH band with a new item.
+type NearDuplicateCandidate struct {
+	ItemID      uuid.UUID
+	Signature   []uint32 // MinHash signature
+	DuplicateOf *uuid.UUID
 }
 
 type Signal struct {
@@ -43,6 +151,77 @@ type Signal struct {
 	FetchedAt time.Time `json:"fetched_at"`
 }
 
+// ItemVelocity is how fast an item's engagement (points plus half the
+// comments) is growing, from its recent signal time series.
+type ItemVelocity struct {
+	ItemID       uuid.UUID `json:"item_id"`
+	Engagement   float64   `json:"engagement"`
+	Velocity     float64   `json:"velocity"`     // engagement per hour
+	Acceleration float64   `json:"acceleration"` // change in velocity per hour
+	Samples      int       `json:"samples"`
+	ComputedAt   time.Time `json:"computed_at"`
+}
+
+// RisingItem is an item ranked by velocity relative to its source's
+// baseline.
+type RisingItem struct {
+	Item
+	Velocity      ItemVelocity `json:"velocity"`
+	ZVelocity     float64      `json:"z_velocity"`     // log-velocity z-score against the source baseline
+	ZAcceleration float64      `json:"z_acceleration"` // acceleration z-score against the source baseline
+	RisingScore   float64      `json:"rising_score"`
+	BaselineSize  int          `json:"baseline_size"` // items in the source baseline
+	Anomalous     bool         `json:"anomalous"`
+}
+
+// ItemFeedback is a team member's vote on an item.
+type ItemFeedback struct {
+	ID        uuid.UUID `json:"id"`
+	ItemID    uuid.UUID `json:"item_id"`
+	Vote      int       `json:"vote"` // +1 or -1
+	User      *string   `json:"user,omitempty"`
+	Note      *string   `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Reputation kinds.
+const (
+	ReputationDomain = "domain"
+	ReputationSource = "source"
+)
+
+// ReputationStats are the raw history counts a reputation is learned from.
+type ReputationStats struct {
+	Kind          string
+	Key           string // domain, or source ID
+	Items         int
+	Scored        int
+	LLMScored     int
+	LLMQualitySum float64 // sum of (impact + engineering value) / 2 over LLM-scored items, 0-1 each
+	Duplicates    int
+	TopRanked     int // scored items in the top decile of their publication day
+	Upvotes       int
+	Downvotes     int
+}
+
+// Reputation is a domain's or source's learned quality. Each rate is
+// Bayesian-smoothed towards the population average, so keys with little
+// history stay near the prior.
+type Reputation struct {
+	Kind          string    `json:"kind"`
+	Key           string    `json:"key"`
+	Items         int       `json:"items"`
+	LLMQuality    float64   `json:"llm_quality"`
+	DuplicateRate float64   `json:"duplicate_rate"`
+	TopRate       float64   `json:"top_rate"`
+	FeedbackScore float64   `json:"feedback_score"`
+	Upvotes       int       `json:"upvotes"`
+	Downvotes     int       `json:"downvotes"`
+	Score         float64   `json:"score"`       // combined quality, 0-1
+	Credibility   float64   `json:"credibility"` // credibility multiplier used by scoring
+	ComputedAt    time.Time `json:"computed_at"`
+}
+
 type Score struct {
 	ItemID           uuid.UUID `json:"item_id"`
 	Hot              float64   `json:"hot"`
@@ -53,9 +232,130 @@ type Score struct {
 	EngineeringValue float64   `json:"engineering_value"`
 	Reasoning        string    `json:"reasoning"`
 	Final            float64   `json:"final"`
+	AlgorithmVersion string    `json:"algorithm_version"` // scoring code the score was computed with
+	ConfigVersion    string    `json:"config_version"`    // scoring config the score was computed with
+	ComputedAt       time.Time `json:"computed_at"`
+
+	// LLMRatings are the raw 1-10 ratings of the LLM article analysis, nil
+	// until the item is analyzed. Novelty, Impact and EngineeringValue above
+	// are the normalized values the final score was computed from.
+	LLMRatings *LLMRatings `json:"llm_ratings,omitempty"`
+
+	Explanation *ScoreExplanation `json:"explanation,omitempty"`
+}
+
+// LLMRatings are an LLM's 1-10 ratings of an article.
+type LLMRatings struct {
+	Novelty          float64 `json:"novelty"`
+	Impact           float64 `json:"impact"`
+	EngineeringValue float64 `json:"engineering_value"`
+}
+
+// ScoreHistory is one recomputation of an item's score.
+type ScoreHistory struct {
+	ID               int64     `json:"id"`
+	ItemID           uuid.UUID `json:"item_id"`
+	Title            string    `json:"title,omitempty"` // set by day listings only
+	Hot              float64   `json:"hot"`
+	Relevance        float64   `json:"relevance"`
+	Credibility      float64   `json:"credibility"`
+	Novelty          float64   `json:"novelty"`
+	Impact           float64   `json:"impact"`
+	EngineeringValue float64   `json:"engineering_value"`
+	Final            float64   `json:"final"`
+	AlgorithmVersion string    `json:"algorithm_version"`
+	ConfigVersion    string    `json:"config_version"`
 	ComputedAt       time.Time `json:"computed_at"`
 }
 
+// RankingComparison compares one day's ranking under two scoring versions.
+type RankingComparison struct {
+	Date        string       `json:"date"`
+	VersionA    string       `json:"version_a"`
+	VersionB    string       `json:"version_b"`
+	Method      string       `json:"method"` // rescored or history
+	ItemsA      int          `json:"items_a"`
+	ItemsB      int          `json:"items_b"`
+	Common      int          `json:"common"`       // items ranked under both versions
+	TopN        int          `json:"top_n"`        // size of the top lists compared by TopOverlap
+	TopOverlap  int          `json:"top_overlap"`  // items in the top N of both rankings
+	SpearmanRho *float64     `json:"spearman_rho"` // rank correlation over common items; nil below 2
+	Movers      []RankChange `json:"movers"`       // common items, largest rank change first
+	OnlyA       []RankChange `json:"only_a"`       // ranked under A only, best first
+	OnlyB       []RankChange `json:"only_b"`       // ranked under B only, best first
+}
+
+// RankChange is an item's position in the two rankings of a
+// RankingComparison. Rank and final are nil under a version that did not
+// score the item.
+type RankChange struct {
+	ItemID uuid.UUID `json:"item_id"`
+	Title  string    `json:"title"`
+	RankA  *int      `json:"rank_a"`
+	RankB  *int      `json:"rank_b"`
+	FinalA *float64  `json:"final_a"`
+	FinalB *float64  `json:"final_b"`
+	Delta  int       `json:"delta"` // places moved up under B (negative: down)
+}
+
+// ScoreExplanation records how a Score was built so a ranking can be
+// explained after the fact.
+type ScoreExplanation struct {
+	ConfigVersion   string           `json:"config_version"`
+	TaxonomyVersion string           `json:"taxonomy_version"` // topic taxonomy the relevance keywords came from
+	Final           float64          `json:"final"`
+	Components      []ScoreComponent `json:"components"` // largest contribution first
+	Relevance       float64          `json:"relevance"`  // keyword relevance; stands in for impact/engineering value without LLM scores
+	Keywords        []KeywordMatch   `json:"matched_keywords"`
+	Credibility     CredibilityTier  `json:"credibility"`
+	Decay           DecayFactors     `json:"decay"`
+	Corroboration   Corroboration    `json:"corroboration"`
+	Provenance      ScoreProvenance  `json:"provenance"`
+}
+
+// ScoreComponent is one weighted term of the final score.
+type ScoreComponent struct {
+	Name         string  `json:"name"` // popularity, impact, credibility, engineering_value, novelty, corroboration
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"` // value * weight
+	Source       string  `json:"source"`       // llm, heuristic, signal, domain, coverage
+}
+
+// KeywordMatch is a relevance keyword (or summary tag) found in an item.
+type KeywordMatch struct {
+	Group   string  `json:"group"` // weighted topic the match counts for, or tag
+	Topic   string  `json:"topic"` // topic the keyword belongs to; a descendant of Group or Group itself
+	Keyword string  `json:"keyword"`
+	Weight  float64 `json:"weight"`
+}
+
+// CredibilityTier is the credibility tier an item's domain fell into.
+type CredibilityTier struct {
+	Domain string  `json:"domain"`
+	Tier   string  `json:"tier"` // tier1, tier2, tier3, domain_reputation, source_reputation, baseline
+	Value  float64 `json:"value"`
+}
+
+// DecayFactors are the time-based inputs of a score.
+type DecayFactors struct {
+	Profile            string  `json:"profile"` // decay profile the item matched, or default
+	AgeHours           float64 `json:"age_hours"`
+	HotHalfLifeHours   float64 `json:"hot_half_life_hours"`
+	HotDecayFactor     float64 `json:"hot_decay_factor"`
+	NoveltyWindowHours float64 `json:"novelty_window_hours"`
+}
+
+// ScoreProvenance records which data a score was computed from.
+type ScoreProvenance struct {
+	LLMScores       bool       `json:"llm_scores"` // impact, engineering value and novelty came from the LLM
+	Reasoning       string     `json:"reasoning,omitempty"`
+	SummaryMethod   string     `json:"summary_method,omitempty"`
+	SignalFetchedAt *time.Time `json:"signal_fetched_at,omitempty"`
+	Points          *int       `json:"points,omitempty"`
+	Comments        *int       `json:"comments,omitempty"`
+}
+
 type Summary struct {
 	ItemID       uuid.UUID `json:"item_id"`
 	TLDR         string    `json:"tldr"`
@@ -63,16 +363,65 @@ type Summary struct {
 	Tags         []string  `json:"tags"`
 	Method       string    `json:"method"` // extractive, llm
 	CreatedAt    time.Time `json:"created_at"`
+
+	TagScores   []TagScore          `json:"tag_scores"` // Tags with their confidence
+	Translation *SummaryTranslation `json:"translation,omitempty"`
+}
+
+// TagScore is a topic tag with the confidence it was assigned with.
+type TagScore struct {
+	Tag        string  `json:"tag"`
+	Confidence float64 `json:"confidence"` // 0-1
+	Method     string  `json:"method"`     // keyword, llm
+}
+
+// SummaryTranslation is a summary rendered in the team's preferred language.
+type SummaryTranslation struct {
+	Lang         string `json:"lang"`
+	TLDR         string `json:"tldr"`
+	WhyItMatters string `json:"why_it_matters"`
 }
 
 type FetchRun struct {
-	ID            uuid.UUID `json:"id"`
-	SourceID      uuid.UUID `json:"source_id"`
-	FetchedAt     time.Time `json:"fetched_at"`
-	Status        string    `json:"status"` // success, failed
-	Error         *string   `json:"error,omitempty"`
-	ItemsFetched  int       `json:"items_fetched"`
-	ItemsInserted int       `json:"items_inserted"`
+	ID            uuid.UUID  `json:"id"`
+	SourceID      uuid.UUID  `json:"source_id"`
+	FetchedAt     time.Time  `json:"fetched_at"`
+	Status        string     `json:"status"` // success, failed
+	Error         *string    `json:"error,omitempty"`
+	ItemsFetched  int        `json:"items_fetched"`
+	ItemsInserted int        `json:"items_inserted"`
+	CrawlRunID    *uuid.UUID `json:"crawl_run_id,omitempty"`
+}
+
+// CrawlRun is the persisted record of one crawl cycle.
+type CrawlRun struct {
+	ID          uuid.UUID        `json:"id"`
+	TriggerType string           `json:"trigger_type"` // auto, manual, worker
+	Status      string           `json:"status"`       // running, success, failed
+	InstanceID  *string          `json:"instance_id,omitempty"`
+	StartedAt   time.Time        `json:"started_at"`
+	FinishedAt  *time.Time       `json:"finished_at,omitempty"`
+	Discovered  int              `json:"discovered"`
+	Accepted    int              `json:"accepted"`
+	Rejected    int              `json:"rejected"` // includes duplicates
+	Duplicates  int              `json:"duplicates"`
+	Inserted    int              `json:"inserted"`
+	Error       *string          `json:"error,omitempty"`
+	Sources     []CrawlRunSource `json:"sources"`
+}
+
+// CrawlRunSource holds the timing and counts of one source within a crawl run.
+type CrawlRunSource struct {
+	Name       string     `json:"name"`
+	SourceID   *uuid.UUID `json:"source_id,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	DurationMs int64      `json:"duration_ms"`
+	Discovered int        `json:"discovered"`
+	Accepted   int        `json:"accepted"`
+	Rejected   int        `json:"rejected"`
+	Duplicates int        `json:"duplicates"`
+	Inserted   int        `json:"inserted"`
+	Error      string     `json:"error,omitempty"`
 }
 
 type Setting struct {
@@ -86,3 +435,42 @@ type ScoredItem struct {
 	Item
 	Similarity float64 `json:"similarity"`
 }
+
+// AgentConfig is a stored discovery agent definition.
+type AgentConfig struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	Type      string      `json:"type"` // rss, trending, reddit, social
+	Enabled   bool        `json:"enabled"`
+	MaxItems  int         `json:"max_items"`
+	Params    AgentParams `json:"params"`
+	Schedule  string      `json:"schedule"` // cron expression; empty runs every cycle
+	LastRunAt *time.Time  `json:"last_run_at,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// AgentParams holds the type-specific knobs of an AgentConfig.
+type AgentParams struct {
+	Feeds      []string `json:"feeds,omitempty"`
+	Subreddits []string `json:"subreddits,omitempty"`
+	Query      string   `json:"query,omitempty"`
+	Platform   string   `json:"platform,omitempty"`
+}
+
+// Job is a unit of deferred enrichment work in the jobs queue.
+type Job struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"` // summarize, embed, score, cluster
+	DedupKey    string          `json:"dedup_key"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"` // pending, running, done, dead
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	NextRunAt   time.Time       `json:"next_run_at"`
+	LastError   *string         `json:"last_error,omitempty"`
+	LockedBy    *string         `json:"locked_by,omitempty"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
@@ -35,7 +35,8 @@ func NormalizeURL(rawURL string) (string, error) {
 	// Remove tracking query params
 	query := parsed.Query()
 	for key := range query {
-		if trackingParams[strings.ToLower(key)] {
+		lower := strings.ToLower(key)
+		if trackingParams[lower] || strings.HasPrefix(lower, "utm_") {
 			query.Del(key)
 		}
 	}
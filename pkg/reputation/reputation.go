@@ -0,0 +1,97 @@
+// Package reputation learns a credibility score for every domain and source
+// from its item history: LLM quality, duplicate rate, how often its items
+// rank in the daily top decile and team votes. Each rate is shrunk towards
+// the population average (Bayesian smoothing), so a domain with little
+// history starts at the scoring baseline and moves away only as evidence
+// accumulates.
+package reputation
+
+import (
+	"math"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// Weights of each component in the combined score.
+const (
+	weightLLM       = 0.4
+	weightUnique    = 0.2 // 1 - duplicate rate
+	weightTop       = 0.25
+	weightFeedback  = 0.15
+	feedbackPrior   = 0.5 // votes start neutral
+	feedbackWeight  = 5   // pseudo-votes behind the feedback prior
+	minCredibility  = 0.1
+	maxCredibility  = 1.5
+	defaultLLMPrior = 0.5
+)
+
+// Prior holds the population averages each key is smoothed towards.
+type Prior struct {
+	LLMQuality    float64
+	DuplicateRate float64
+	TopRate       float64
+}
+
+// PriorFrom pools all stats of one kind into population averages.
+func PriorFrom(stats []models.ReputationStats) Prior {
+	var items, scored, llmScored, dups, top int
+	var llmSum float64
+	for _, s := range stats {
+		items += s.Items
+		scored += s.Scored
+		llmScored += s.LLMScored
+		llmSum += s.LLMQualitySum
+		dups += s.Duplicates
+		top += s.TopRanked
+	}
+
+	p := Prior{LLMQuality: defaultLLMPrior}
+	if llmScored > 0 {
+		p.LLMQuality = llmSum / float64(llmScored)
+	}
+	if items > 0 {
+		p.DuplicateRate = float64(dups) / float64(items)
+	}
+	if scored > 0 {
+		p.TopRate = float64(top) / float64(scored)
+	}
+	return p
+}
+
+// smooth returns the posterior mean of a rate with hits out of n
+// observations, given prior mean and prior weight (in pseudo-observations).
+func smooth(hits float64, n int, prior, weight float64) float64 {
+	return (prior*weight + hits) / (weight + float64(n))
+}
+
+func combine(llm, dup, top, feedback float64) float64 {
+	return weightLLM*llm + weightUnique*(1-dup) + weightTop*top + weightFeedback*feedback
+}
+
+// Compute turns a key's raw stats into a smoothed reputation. weight is how
+// many items of evidence the prior counts as; baseline is the credibility a
+// key exactly at the prior gets.
+func Compute(s models.ReputationStats, prior Prior, weight, baseline float64) models.Reputation {
+	rep := models.Reputation{
+		Kind:          s.Kind,
+		Key:           s.Key,
+		Items:         s.Items,
+		Upvotes:       s.Upvotes,
+		Downvotes:     s.Downvotes,
+		LLMQuality:    smooth(s.LLMQualitySum, s.LLMScored, prior.LLMQuality, weight),
+		DuplicateRate: smooth(float64(s.Duplicates), s.Items, prior.DuplicateRate, weight),
+		TopRate:       smooth(float64(s.TopRanked), s.Scored, prior.TopRate, weight),
+		FeedbackScore: smooth(float64(s.Upvotes), s.Upvotes+s.Downvotes, feedbackPrior, feedbackWeight),
+	}
+	rep.Score = combine(rep.LLMQuality, rep.DuplicateRate, rep.TopRate, rep.FeedbackScore)
+
+	// Credibility scales the baseline by how far the key is above or below
+	// a key with no history of its own.
+	priorScore := combine(prior.LLMQuality, prior.DuplicateRate, prior.TopRate, feedbackPrior)
+	rep.Credibility = baseline
+	if priorScore > 0 {
+		rep.Credibility = baseline * rep.Score / priorScore
+	}
+	rep.Credibility = math.Round(math.Min(math.Max(rep.Credibility, minCredibility), maxCredibility)*1000) / 1000
+	return rep
+}
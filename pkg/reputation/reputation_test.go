@@ -0,0 +1,52 @@
+package reputation
+
+import (
+	"testing"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestCompute(t *testing.T) {
+	stats := []models.ReputationStats{
+		{Key: "new.example", Items: 0},
+		{Key: "lab.example", Items: 200, Scored: 200, LLMScored: 150, LLMQualitySum: 150 * 0.8, Duplicates: 4, TopRanked: 60, Upvotes: 12, Downvotes: 1},
+		{Key: "spam.example", Items: 200, Scored: 200, LLMScored: 100, LLMQualitySum: 100 * 0.2, Duplicates: 120, TopRanked: 2, Downvotes: 8},
+		{Key: "lucky.example", Items: 1, Scored: 1, LLMScored: 1, LLMQualitySum: 1, TopRanked: 1, Upvotes: 1},
+	}
+	prior := PriorFrom(stats)
+	const baseline = 0.5
+
+	got := make(map[string]models.Reputation)
+	for _, st := range stats {
+		got[st.Key] = Compute(st, prior, 20, baseline)
+	}
+
+	if c := got["new.example"].Credibility; c != baseline {
+		t.Errorf("domain without history credibility = %v, want baseline %v", c, baseline)
+	}
+	if got["lab.example"].Credibility <= baseline {
+		t.Errorf("strong domain credibility = %v, want above baseline", got["lab.example"].Credibility)
+	}
+	if got["spam.example"].Credibility >= baseline {
+		t.Errorf("duplicate-heavy domain credibility = %v, want below baseline", got["spam.example"].Credibility)
+	}
+
+	// One great item must not outrank a long, strong track record.
+	lucky := got["lucky.example"].Credibility
+	if lucky <= baseline || lucky >= got["lab.example"].Credibility {
+		t.Errorf("single-item domain credibility = %v, want slightly above baseline and below lab.example", lucky)
+	}
+
+	for key, rep := range got {
+		if rep.Credibility < minCredibility || rep.Credibility > maxCredibility {
+			t.Errorf("%s credibility %v outside [%v, %v]", key, rep.Credibility, minCredibility, maxCredibility)
+		}
+	}
+}
+
+func TestPriorFrom_Empty(t *testing.T) {
+	p := PriorFrom(nil)
+	if p.LLMQuality != defaultLLMPrior || p.DuplicateRate != 0 || p.TopRate != 0 {
+		t.Errorf("PriorFrom(nil) = %+v", p)
+	}
+}
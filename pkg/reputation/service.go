@@ -0,0 +1,125 @@
+package reputation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+)
+
+// topPercentile is the daily percent rank from which an item counts as
+// top-ranked.
+const topPercentile = 0.9
+
+// Service recomputes reputations from history and installs them for scoring.
+type Service struct {
+	database *db.DB
+	repo     *db.ReputationRepository
+	cfg      *config.Config
+}
+
+func NewService(database *db.DB, cfg *config.Config) *Service {
+	return &Service{
+		database: database,
+		repo:     db.NewReputationRepository(database),
+		cfg:      cfg,
+	}
+}
+
+// Recompute learns domain and source reputations from the last
+// REPUTATION_LOOKBACK_DAYS of items and stores them. Only one process
+// recomputes at a time; the others skip.
+func (s *Service) Recompute(ctx context.Context) error {
+	unlock, acquired, err := s.database.TryLock(ctx, db.LockReputation)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	defer unlock()
+
+	since := time.Now().AddDate(0, 0, -s.cfg.ReputationLookbackDays)
+	baseline := scoring.Current().Credibility.Baseline
+
+	for _, kind := range []string{models.ReputationDomain, models.ReputationSource} {
+		stats, err := s.repo.AggregateStats(ctx, kind, since, topPercentile)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate %s stats: %w", kind, err)
+		}
+
+		prior := PriorFrom(stats)
+		reps := make([]models.Reputation, 0, len(stats))
+		for _, st := range stats {
+			reps = append(reps, Compute(st, prior, s.cfg.ReputationPriorWeight, baseline))
+		}
+		if err := s.repo.Replace(ctx, kind, reps); err != nil {
+			return fmt.Errorf("failed to store %s reputations: %w", kind, err)
+		}
+		log.Printf("Recomputed %d %s reputations", len(reps), kind)
+	}
+	return nil
+}
+
+// Load installs the stored reputations as scoring.CurrentReputation.
+func (s *Service) Load(ctx context.Context) error {
+	domains, err := s.repo.List(ctx, models.ReputationDomain)
+	if err != nil {
+		return err
+	}
+	sources, err := s.repo.List(ctx, models.ReputationSource)
+	if err != nil {
+		return err
+	}
+
+	rep := &scoring.Reputation{
+		Domains: make(map[string]float64, len(domains)),
+		Sources: make(map[uuid.UUID]float64, len(sources)),
+	}
+	for _, d := range domains {
+		rep.Domains[d.Key] = d.Credibility
+	}
+	for _, src := range sources {
+		if id, err := uuid.Parse(src.Key); err == nil {
+			rep.Sources[id] = src.Credibility
+		}
+	}
+	scoring.SetReputation(rep)
+	return nil
+}
+
+// Refresh recomputes and then loads reputations.
+func (s *Service) Refresh(ctx context.Context) error {
+	if err := s.Recompute(ctx); err != nil {
+		return err
+	}
+	return s.Load(ctx)
+}
+
+// Watch refreshes reputations now and then every interval until ctx is
+// cancelled. It does nothing when reputation is disabled.
+func (s *Service) Watch(ctx context.Context, interval time.Duration) {
+	if !s.cfg.ReputationEnabled || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			log.Printf("Reputation refresh failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,9 +1,232 @@
 package scoring
 
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the complete scoring configuration. It is loaded from a YAML
+// file or the settings table (see Loader) and can be swapped at runtime.
+type Config struct {
+	Weights     Weights           `yaml:"weights" json:"weights"`
+	Credibility CredibilityConfig `yaml:"credibility" json:"credibility"`
+	Keywords    RelevanceKeywords `yaml:"keywords" json:"keywords"`
+	Decay       DecayConfig       `yaml:"decay" json:"decay"`
+
+	// Version is a content hash of the fields above and AlgorithmVersion,
+	// recorded on every Score computed with this configuration.
+	Version string `yaml:"-" json:"version"`
+}
+
+// tagWeightKey is the RelevanceKeywords.Weights key for summary tags; every
+// other key names a taxonomy topic.
+const tagWeightKey = "tag"
+
+var topicKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// DefaultConfig returns the compiled-in scoring configuration.
+func DefaultConfig() *Config {
+	cfg := &Config{
+		Weights:     DefaultWeights,
+		Credibility: DefaultCredibilityConfig(),
+		Keywords:    DefaultRelevanceKeywords(),
+		Decay:       DefaultDecayConfig(),
+	}
+	cfg.Version = cfg.computeVersion()
+	return cfg
+}
+
+var current atomic.Pointer[Config]
+
+// Current returns the active scoring configuration.
+func Current() *Config {
+	if cfg := current.Load(); cfg != nil {
+		return cfg
+	}
+	cfg := DefaultConfig()
+	current.CompareAndSwap(nil, cfg)
+	return current.Load()
+}
+
+// SetCurrent makes cfg the active scoring configuration.
+func SetCurrent(cfg *Config) {
+	current.Store(cfg)
+}
+
+// Parse decodes a YAML (or JSON) scoring configuration and validates it.
+// Sections left out of data keep their defaults; unknown keys are rejected.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid scoring config: %w", err)
+	}
+
+	cfg.applyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.Version = cfg.computeVersion()
+	return &cfg, nil
+}
+
+func (c *Config) applyDefaults() {
+	if c.Weights == (Weights{}) {
+		c.Weights = DefaultWeights
+	}
+
+	def := DefaultCredibilityConfig()
+	if c.Credibility.Tier1 == nil && c.Credibility.Tier2 == nil && c.Credibility.Tier3 == nil {
+		c.Credibility.Tier1, c.Credibility.Tier2, c.Credibility.Tier3 = def.Tier1, def.Tier2, def.Tier3
+	}
+	if c.Credibility.Baseline == 0 {
+		c.Credibility.Baseline = def.Baseline
+	}
+
+	kw := DefaultRelevanceKeywords()
+	if c.Keywords.Weights == nil {
+		c.Keywords.Weights = make(map[string]float64, len(kw.Weights))
+	}
+	for group, w := range kw.Weights {
+		if _, ok := c.Keywords.Weights[group]; !ok {
+			c.Keywords.Weights[group] = w
+		}
+	}
+
+	decay := DefaultDecayConfig()
+	if c.Decay.HotHalfLifeHours == 0 {
+		c.Decay.HotHalfLifeHours = decay.HotHalfLifeHours
+	}
+	if c.Decay.NoveltyWindowHours == 0 {
+		c.Decay.NoveltyWindowHours = decay.NoveltyWindowHours
+	}
+	if c.Decay.Profiles == nil {
+		c.Decay.Profiles = decay.Profiles
+	}
+}
+
+// Validate reports every problem in c at once.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	w := c.Weights
+	for name, v := range map[string]float64{
+		"popularity": w.W1, "impact": w.W2, "credibility": w.W3, "engineering_value": w.W4, "novelty": w.W5,
+		"corroboration": w.W6,
+	} {
+		if v < 0 || v > 1 {
+			fail("weights.%s must be between 0 and 1, got %g", name, v)
+		}
+	}
+	if w.W1+w.W2+w.W3+w.W4+w.W5+w.W6 <= 0 {
+		fail("weights must not all be zero")
+	}
+
+	seen := make(map[string]string)
+	for tier, domains := range map[string]map[string]float64{
+		"tier1": c.Credibility.Tier1, "tier2": c.Credibility.Tier2, "tier3": c.Credibility.Tier3,
+	} {
+		for domain, v := range domains {
+			if domain == "" || domain != strings.ToLower(domain) || strings.ContainsAny(domain, "/: ") {
+				fail("credibility.%s: %q is not a lowercase bare domain", tier, domain)
+			}
+			if v <= 0 || v > 2 {
+				fail("credibility.%s.%s must be in (0, 2], got %g", tier, domain, v)
+			}
+			if other, dup := seen[domain]; dup {
+				fail("credibility: %s is listed in both %s and %s", domain, other, tier)
+			}
+			seen[domain] = tier
+		}
+	}
+	if b := c.Credibility.Baseline; b <= 0 || b > 2 {
+		fail("credibility.baseline must be in (0, 2], got %g", b)
+	}
+
+	for key, v := range c.Keywords.Weights {
+		if key != tagWeightKey && !topicKeyPattern.MatchString(key) {
+			fail("keywords.weights.%s: not a topic slug or %q", key, tagWeightKey)
+		}
+		if v < 0 || v > 1 {
+			fail("keywords.weights.%s must be between 0 and 1, got %g", key, v)
+		}
+	}
+
+	if c.Decay.HotHalfLifeHours <= 0 {
+		fail("decay.hot_half_life_hours must be positive")
+	}
+	if c.Decay.NoveltyWindowHours <= 0 {
+		fail("decay.novelty_window_hours must be positive")
+	}
+	names := make(map[string]bool)
+	for i, p := range c.Decay.Profiles {
+		if p.Name == "" {
+			fail("decay.profiles[%d]: name is required", i)
+		} else if names[p.Name] {
+			fail("decay.profiles: duplicate profile %s", p.Name)
+		}
+		names[p.Name] = true
+		if len(p.Categories) == 0 && len(p.SourceTypes) == 0 {
+			fail("decay.profiles.%s: needs categories or source_types", p.Name)
+		}
+		if p.HotHalfLifeHours < 0 {
+			fail("decay.profiles.%s.hot_half_life_hours must not be negative", p.Name)
+		}
+		if p.NoveltyWindowHours < 0 {
+			fail("decay.profiles.%s.novelty_window_hours must not be negative", p.Name)
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+// computeVersion hashes the configuration. encoding/json sorts map keys, so
+// equal configurations always get the same version.
+// Snapshot encodes c in the YAML form Parse reads, so a configuration that
+// is no longer active can be stored and rebuilt by version.
+func (c *Config) Snapshot() (string, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode scoring config: %w", err)
+	}
+	return string(data), nil
+}
+
+func (c *Config) computeVersion() string {
+	clone := *c
+	clone.Version = ""
+	data, err := json.Marshal(clone)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte(AlgorithmVersion+"\n"), data...))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// CredibilityConfig maps domains to manual credibility multipliers by tier.
+// Domains not listed in any tier use their learned Reputation, falling back
+// to Baseline.
 type CredibilityConfig struct {
-	Tier1 map[string]float64
-	Tier2 map[string]float64
-	Tier3 map[string]float64
+	Tier1    map[string]float64 `yaml:"tier1" json:"tier1"`
+	Tier2    map[string]float64 `yaml:"tier2" json:"tier2"`
+	Tier3    map[string]float64 `yaml:"tier3" json:"tier3"`
+	Baseline float64            `yaml:"baseline" json:"baseline"`
 }
 
 // DefaultCredibilityConfig returns the default credibility configuration
@@ -26,28 +249,25 @@ func DefaultCredibilityConfig() CredibilityConfig {
 			"techcrunch.com":  0.7,
 			"venturebeat.com": 0.7,
 		},
+		Baseline: 0.5,
 	}
 }
 
-// RelevanceKeywords holds keyword configuration for relevance scoring
+// RelevanceKeywords weights taxonomy topics for relevance scoring. The
+// keywords themselves come from the topic taxonomy; a keyword of a topic
+// without a weight counts for its nearest weighted ancestor.
 type RelevanceKeywords struct {
-	LLM     []string
-	MLOps   []string
-	CV      []string
-	Weights map[string]float64
+	Weights map[string]float64 `yaml:"weights" json:"weights"` // per topic slug or alias, plus "tag"
 }
 
 // DefaultRelevanceKeywords returns the default relevance keywords configuration
 func DefaultRelevanceKeywords() RelevanceKeywords {
 	return RelevanceKeywords{
-		LLM:   []string{"llm", "transformer", "rag", "prompt", "inference", "fine-tune", "gpt", "gemini", "llama", "mistral"},
-		MLOps: []string{"mlops", "deployment", "monitoring", "drift", "kubernetes", "kubeflow", "airflow", "feature store"},
-		CV:    []string{"computer vision", "yolo", "segmentation", "detection", "opencv"},
 		Weights: map[string]float64{
-			"llm":   0.3,
-			"mlops": 0.25,
-			"cv":    0.2,
-			"tag":   0.2,
+			"llm":        0.3,
+			"mlops":      0.25,
+			"vision":     0.2,
+			tagWeightKey: 0.2,
 		},
 	}
 }
@@ -0,0 +1,144 @@
+package scoring
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse_ShippedConfigMatchesDefaults(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "configs", "scoring.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		t.Fatalf("shipped config is invalid: %v", err)
+	}
+	if want := DefaultConfig().Version; cfg.Version != want {
+		t.Errorf("configs/scoring.yaml version %s differs from compiled-in defaults %s", cfg.Version, want)
+	}
+}
+
+func TestParse_PartialKeepsDefaults(t *testing.T) {
+	cfg, err := Parse([]byte("credibility:\n  tier1:\n    example.org: 1.5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Weights != DefaultWeights {
+		t.Errorf("weights = %+v, want defaults", cfg.Weights)
+	}
+	if got, _ := computeCredibilityScoreWithConfig("example.org", cfg.Credibility); got != 1.5 {
+		t.Errorf("example.org credibility = %v, want 1.5", got)
+	}
+	// Tiers given in the file replace the default tiers rather than merging.
+	if got, _ := computeCredibilityScoreWithConfig("arxiv.org", cfg.Credibility); got != 0.5 {
+		t.Errorf("arxiv.org credibility = %v, want baseline 0.5", got)
+	}
+	if cfg.Version == DefaultConfig().Version {
+		t.Error("changed config kept the default version")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unknown key", "weights:\n  hype: 0.5\n", "field hype not found"},
+		{"weight out of range", "weights:\n  impact: 1.5\n", "weights.impact"},
+		{"bad domain", "credibility:\n  tier1:\n    https://Arxiv.org: 1.2\n", "not a lowercase bare domain"},
+		{"duplicate domain", "credibility:\n  tier1:\n    a.com: 1.2\n  tier3:\n    a.com: 0.7\n", "listed in both"},
+		{"keyword list", "keywords:\n  llm: [gpt]\n", "field llm not found"},
+		{"bad topic key", "keywords:\n  weights:\n    Computer Vision: 0.2\n", "not a topic slug"},
+		{"negative decay", "decay:\n  hot_half_life_hours: -1\n", "hot_half_life_hours"},
+		{"profile without match", "decay:\n  profiles:\n    - name: papers\n      hot_half_life_hours: 100\n", "needs categories or source_types"},
+		{"duplicate profile", "decay:\n  profiles:\n    - {name: a, categories: [news]}\n    - {name: a, categories: [research]}\n", "duplicate profile a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSettings map[string]string
+
+func (f fakeSettings) Get(_ context.Context, key string) (string, error) {
+	return f[key], nil
+}
+
+func TestLoader_Reload(t *testing.T) {
+	t.Cleanup(func() { SetCurrent(DefaultConfig()) })
+
+	path := filepath.Join(t.TempDir(), "scoring.yaml")
+	if err := os.WriteFile(path, []byte("decay:\n  hot_half_life_hours: 24\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	settings := fakeSettings{}
+	loader := NewLoader(path, settings)
+
+	cfg, err := loader.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Decay.HotHalfLifeHours != 24 || Current() != cfg || loader.Source() != "file:"+path {
+		t.Fatalf("file config not applied: %+v from %s", cfg.Decay, loader.Source())
+	}
+
+	// The settings table wins over the file.
+	settings[SettingsKey] = `{"decay": {"hot_half_life_hours": 12}}`
+	if cfg, err = loader.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Decay.HotHalfLifeHours != 12 {
+		t.Fatalf("settings config not applied: %+v", cfg.Decay)
+	}
+
+	// A broken config is rejected and the active one kept.
+	settings[SettingsKey] = "decay: [oops"
+	if _, err := loader.Reload(context.Background()); err == nil {
+		t.Fatal("expected an error for a broken config")
+	}
+	if Current().Version != cfg.Version {
+		t.Error("broken config replaced the active one")
+	}
+}
+
+type fakeSnapshots map[string]string
+
+func (f fakeSnapshots) Save(_ context.Context, version, _, config string) error {
+	f[version] = config
+	return nil
+}
+
+func TestLoader_KeepSnapshots(t *testing.T) {
+	t.Cleanup(func() { SetCurrent(DefaultConfig()) })
+
+	settings := fakeSettings{SettingsKey: "weights:\n  popularity: 0.5\n  impact: 0.5\n"}
+	snapshots := fakeSnapshots{}
+	loader := NewLoader("", settings)
+	loader.KeepSnapshots(snapshots)
+
+	cfg, err := loader.Reload(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, ok := snapshots[cfg.Version]
+	if !ok {
+		t.Fatalf("config %s was not stored", cfg.Version)
+	}
+
+	// The snapshot rebuilds the same configuration.
+	restored, err := Parse([]byte(snapshot))
+	if err != nil {
+		t.Fatalf("stored snapshot does not parse: %v", err)
+	}
+	if restored.Version != cfg.Version {
+		t.Errorf("restored version %s, want %s", restored.Version, cfg.Version)
+	}
+}
@@ -0,0 +1,82 @@
+package scoring
+
+import (
+	"math"
+	"sort"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// Shares of the corroboration factor and how fast its parts saturate.
+const (
+	corroborationDomainShare = 0.5
+	corroborationTypeShare   = 0.3
+	corroborationSpeedShare  = 0.2
+	domainSaturation         = 2  // extra domains for half the domain share
+	sourceTypeSaturation     = 1  // extra source types for half the type share
+	spreadHalfLifeHours      = 12 // pickup delay that halves the speed share
+)
+
+// ComputeCorroboration rates how independently a story is covered, given
+// all its coverage including the item itself (Via "self"). Extra domains and
+// source types each add with diminishing returns, and coverage reaching
+// another domain quickly adds a speed bonus. A story covered once scores 0.
+func ComputeCorroboration(coverage []models.CoverageItem) models.Corroboration {
+	c := models.Corroboration{Sources: []string{}}
+	if len(coverage) == 0 {
+		return c
+	}
+
+	sorted := append([]models.CoverageItem(nil), coverage...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].PublishedAt.Before(sorted[j].PublishedAt) })
+
+	self := ""
+	for _, ci := range sorted {
+		if ci.Via == "self" {
+			self = ci.Domain
+		}
+	}
+
+	domains := make(map[string]bool)
+	types := make(map[string]bool)
+	first := sorted[0]
+	for _, ci := range sorted {
+		if ci.Domain != "" && !domains[ci.Domain] {
+			domains[ci.Domain] = true
+			if ci.Domain != self {
+				c.Sources = append(c.Sources, ci.Domain)
+			}
+			if ci.Domain != first.Domain && c.SpreadHours == nil {
+				hours := math.Max(ci.PublishedAt.Sub(first.PublishedAt).Hours(), 0)
+				c.SpreadHours = &hours
+			}
+		}
+		if ci.SourceType != "" {
+			types[ci.SourceType] = true
+		}
+	}
+	c.Domains, c.SourceTypes = len(domains), len(types)
+
+	saturate := func(extra int, k float64) float64 {
+		if extra <= 0 {
+			return 0
+		}
+		return float64(extra) / (float64(extra) + k)
+	}
+	c.Factor = corroborationDomainShare*saturate(c.Domains-1, domainSaturation) +
+		corroborationTypeShare*saturate(c.SourceTypes-1, sourceTypeSaturation)
+	if c.SpreadHours != nil {
+		c.Factor += corroborationSpeedShare * math.Exp2(-*c.SpreadHours/spreadHalfLifeHours)
+	}
+	return c
+}
+
+// selfSourceType returns the source type of the "self" coverage entry.
+func selfSourceType(coverage []models.CoverageItem) string {
+	for _, ci := range coverage {
+		if ci.Via == "self" {
+			return ci.SourceType
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestComputeCorroboration(t *testing.T) {
+	t0 := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	cov := func(domain, sourceType, via string, hours float64) models.CoverageItem {
+		return models.CoverageItem{
+			Domain: domain, SourceType: sourceType, Via: via,
+			PublishedAt: t0.Add(time.Duration(hours * float64(time.Hour))),
+		}
+	}
+
+	t.Run("single coverage", func(t *testing.T) {
+		c := ComputeCorroboration([]models.CoverageItem{cov("blog.example", "rss_atom", "self", 0)})
+		if c.Factor != 0 || c.SpreadHours != nil || len(c.Sources) != 0 {
+			t.Errorf("got %+v, want no corroboration", c)
+		}
+	})
+
+	t.Run("same domain reposts do not count", func(t *testing.T) {
+		c := ComputeCorroboration([]models.CoverageItem{
+			cov("blog.example", "rss_atom", "self", 0),
+			cov("blog.example", "rss_atom", "duplicate", 1),
+		})
+		if c.Factor != 0 || c.Domains != 1 {
+			t.Errorf("got %+v, want no corroboration", c)
+		}
+	})
+
+	t.Run("independent coverage", func(t *testing.T) {
+		c := ComputeCorroboration([]models.CoverageItem{
+			cov("news.ycombinator.com", "hacker_news", "duplicate", 0),
+			cov("blog.example", "rss_atom", "self", 6),
+			cov("arxiv.org", "arxiv", "cluster", 30),
+		})
+		if c.Domains != 3 || c.SourceTypes != 3 {
+			t.Fatalf("domains/types = %d/%d, want 3/3", c.Domains, c.SourceTypes)
+		}
+		if c.SpreadHours == nil || *c.SpreadHours != 6 {
+			t.Errorf("SpreadHours = %v, want 6", c.SpreadHours)
+		}
+		if len(c.Sources) != 2 || c.Sources[0] != "news.ycombinator.com" || c.Sources[1] != "arxiv.org" {
+			t.Errorf("Sources = %v, want the other two domains oldest first", c.Sources)
+		}
+		// 0.5 × 2/4 + 0.3 × 2/3 + 0.2 × 2^(-6/12)
+		want := 0.25 + 0.2 + 0.2*math.Sqrt(0.5)
+		if math.Abs(c.Factor-want) > 1e-9 {
+			t.Errorf("Factor = %v, want %v", c.Factor, want)
+		}
+	})
+
+	t.Run("faster pickup scores higher", func(t *testing.T) {
+		fast := ComputeCorroboration([]models.CoverageItem{cov("a.com", "rss_atom", "self", 0), cov("b.com", "rss_atom", "duplicate", 1)})
+		slow := ComputeCorroboration([]models.CoverageItem{cov("a.com", "rss_atom", "self", 0), cov("b.com", "rss_atom", "duplicate", 48)})
+		if fast.Factor <= slow.Factor {
+			t.Errorf("fast %v <= slow %v", fast.Factor, slow.Factor)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package scoring
+
+import (
+	"math"
+	"slices"
+)
+
+// DecayConfig controls how fast time-sensitive components fade. The
+// top-level values apply to items no profile matches.
+type DecayConfig struct {
+	HotHalfLifeHours   float64 `yaml:"hot_half_life_hours" json:"hot_half_life_hours"`
+	NoveltyWindowHours float64 `yaml:"novelty_window_hours" json:"novelty_window_hours"` // novelty bottoms out after this
+
+	// Profiles give items of some categories or source types their own
+	// decay. The first matching profile wins.
+	Profiles []DecayProfile `yaml:"profiles" json:"profiles"`
+}
+
+// DecayProfile is the decay of items matching any of Categories or
+// SourceTypes. Zero durations inherit the top-level DecayConfig values.
+type DecayProfile struct {
+	Name               string   `yaml:"name" json:"name"`
+	Categories         []string `yaml:"categories,omitempty" json:"categories,omitempty"`
+	SourceTypes        []string `yaml:"source_types,omitempty" json:"source_types,omitempty"`
+	HotHalfLifeHours   float64  `yaml:"hot_half_life_hours,omitempty" json:"hot_half_life_hours,omitempty"`
+	NoveltyWindowHours float64  `yaml:"novelty_window_hours,omitempty" json:"novelty_window_hours,omitempty"`
+}
+
+// DefaultDecayConfig returns the default decay configuration
+func DefaultDecayConfig() DecayConfig {
+	return DecayConfig{
+		HotHalfLifeHours:   48,
+		NoveltyWindowHours: 168,
+		Profiles: []DecayProfile{
+			// arXiv items are filed as news, so match papers by source type
+			// before the news profile sees them.
+			{Name: "papers", Categories: []string{"research"}, SourceTypes: []string{"arxiv"}, HotHalfLifeHours: 168, NoveltyWindowHours: 720},
+			{Name: "benchmarks", Categories: []string{"benchmarks"}, HotHalfLifeHours: 120, NoveltyWindowHours: 504},
+			{Name: "releases", Categories: []string{"models", "tools"}, HotHalfLifeHours: 72, NoveltyWindowHours: 336},
+			{Name: "incidents", Categories: []string{"status", "incidents"}, HotHalfLifeHours: 12, NoveltyWindowHours: 48},
+			{Name: "news", Categories: []string{"news"}, HotHalfLifeHours: 36, NoveltyWindowHours: 120},
+		},
+	}
+}
+
+// Resolve returns the decay for an item of category from a source of
+// sourceType (empty when unknown), with inherited durations filled in. Items
+// no profile matches get the top-level values under the name "default".
+func (d DecayConfig) Resolve(category, sourceType string) DecayProfile {
+	for _, p := range d.Profiles {
+		if slices.Contains(p.Categories, category) || (sourceType != "" && slices.Contains(p.SourceTypes, sourceType)) {
+			if p.HotHalfLifeHours == 0 {
+				p.HotHalfLifeHours = d.HotHalfLifeHours
+			}
+			if p.NoveltyWindowHours == 0 {
+				p.NoveltyWindowHours = d.NoveltyWindowHours
+			}
+			return p
+		}
+	}
+	return DecayProfile{Name: "default", HotHalfLifeHours: d.HotHalfLifeHours, NoveltyWindowHours: d.NoveltyWindowHours}
+}
+
+// WindowDays is the longest novelty window of the top-level values and all
+// profiles, in whole days: how far back rescoring can still change novelty.
+func (d DecayConfig) WindowDays() int {
+	longest := d.NoveltyWindowHours
+	for _, p := range d.Profiles {
+		longest = math.Max(longest, p.NoveltyWindowHours)
+	}
+	return int(math.Ceil(longest / 24))
+}
@@ -0,0 +1,37 @@
+package scoring
+
+import "testing"
+
+func TestDecayConfig_Resolve(t *testing.T) {
+	d := DecayConfig{
+		HotHalfLifeHours:   48,
+		NoveltyWindowHours: 168,
+		Profiles: []DecayProfile{
+			{Name: "papers", Categories: []string{"research"}, SourceTypes: []string{"arxiv"}, HotHalfLifeHours: 168, NoveltyWindowHours: 720},
+			{Name: "news", Categories: []string{"news"}, HotHalfLifeHours: 24},
+		},
+	}
+
+	tests := []struct {
+		category, sourceType string
+		want                 DecayProfile
+	}{
+		{"research", "", DecayProfile{Name: "papers", HotHalfLifeHours: 168, NoveltyWindowHours: 720}},
+		{"news", "arxiv", DecayProfile{Name: "papers", HotHalfLifeHours: 168, NoveltyWindowHours: 720}},    // first match wins
+		{"news", "hacker_news", DecayProfile{Name: "news", HotHalfLifeHours: 24, NoveltyWindowHours: 168}}, // novelty inherited
+		{"tools", "", DecayProfile{Name: "default", HotHalfLifeHours: 48, NoveltyWindowHours: 168}},
+	}
+	for _, tt := range tests {
+		got := d.Resolve(tt.category, tt.sourceType)
+		if got.Name != tt.want.Name || got.HotHalfLifeHours != tt.want.HotHalfLifeHours || got.NoveltyWindowHours != tt.want.NoveltyWindowHours {
+			t.Errorf("Resolve(%q, %q) = %+v, want %+v", tt.category, tt.sourceType, got, tt.want)
+		}
+	}
+
+	if got := d.WindowDays(); got != 30 {
+		t.Errorf("WindowDays = %d, want 30 from the papers profile", got)
+	}
+	if got := (DecayConfig{NoveltyWindowHours: 168}).WindowDays(); got != 7 {
+		t.Errorf("WindowDays without profiles = %d, want 7", got)
+	}
+}
@@ -0,0 +1,153 @@
+package scoring
+
+import (
+	"sort"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// AlgorithmVersion identifies the scoring code. Bump it whenever
+// ComputeScoreWithConfig produces different scores from the same inputs and
+// config, so score history can tell the two apart.
+const AlgorithmVersion = "v1"
+
+// rankedScore is a score's 1-based position in a ranking.
+type rankedScore struct {
+	models.ScoreHistory
+	rank int
+}
+
+// rank orders scores by final score, best first. Ties are broken by item ID
+// so the ranking is stable.
+func rank(scores []models.ScoreHistory) map[string]rankedScore {
+	sorted := append([]models.ScoreHistory(nil), scores...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Final != sorted[j].Final {
+			return sorted[i].Final > sorted[j].Final
+		}
+		return sorted[i].ItemID.String() < sorted[j].ItemID.String()
+	})
+
+	ranks := make(map[string]rankedScore, len(sorted))
+	for i, s := range sorted {
+		ranks[s.ItemID.String()] = rankedScore{ScoreHistory: s, rank: i + 1}
+	}
+	return ranks
+}
+
+// CompareRankings ranks the scores of a and b by final score and reports how
+// the rankings differ: overlap of the top topN, Spearman rank correlation
+// over the items both scored, and up to limit entries in each of the mover
+// and only-in-one-ranking lists. Date and versions are left to the caller.
+func CompareRankings(a, b []models.ScoreHistory, topN, limit int) models.RankingComparison {
+	ranksA, ranksB := rank(a), rank(b)
+	cmp := models.RankingComparison{
+		ItemsA: len(ranksA),
+		ItemsB: len(ranksB),
+		TopN:   topN,
+		Movers: []models.RankChange{},
+		OnlyA:  []models.RankChange{},
+		OnlyB:  []models.RankChange{},
+	}
+
+	// Items scored under both versions, in A's order, for the correlation.
+	var common []models.RankChange
+	for key, ra := range ranksA {
+		rb, ok := ranksB[key]
+		if !ok {
+			cmp.OnlyA = append(cmp.OnlyA, change(&ra, nil))
+			continue
+		}
+		common = append(common, change(&ra, &rb))
+		if ra.rank <= topN && rb.rank <= topN {
+			cmp.TopOverlap++
+		}
+	}
+	for key, rb := range ranksB {
+		if _, ok := ranksA[key]; !ok {
+			cmp.OnlyB = append(cmp.OnlyB, change(nil, &rb))
+		}
+	}
+	cmp.Common = len(common)
+	cmp.SpearmanRho = spearman(common)
+
+	for _, c := range common {
+		if c.Delta != 0 {
+			cmp.Movers = append(cmp.Movers, c)
+		}
+	}
+	sort.Slice(cmp.Movers, func(i, j int) bool {
+		di, dj := abs(cmp.Movers[i].Delta), abs(cmp.Movers[j].Delta)
+		if di != dj {
+			return di > dj
+		}
+		return *cmp.Movers[i].RankB < *cmp.Movers[j].RankB
+	})
+	sort.Slice(cmp.OnlyA, func(i, j int) bool { return *cmp.OnlyA[i].RankA < *cmp.OnlyA[j].RankA })
+	sort.Slice(cmp.OnlyB, func(i, j int) bool { return *cmp.OnlyB[i].RankB < *cmp.OnlyB[j].RankB })
+
+	cmp.Movers = truncate(cmp.Movers, limit)
+	cmp.OnlyA = truncate(cmp.OnlyA, limit)
+	cmp.OnlyB = truncate(cmp.OnlyB, limit)
+	return cmp
+}
+
+func change(a, b *rankedScore) models.RankChange {
+	var c models.RankChange
+	if a != nil {
+		c.ItemID, c.Title = a.ItemID, a.Title
+		c.RankA, c.FinalA = &a.rank, &a.Final
+	}
+	if b != nil {
+		c.ItemID = b.ItemID
+		if c.Title == "" {
+			c.Title = b.Title
+		}
+		c.RankB, c.FinalB = &b.rank, &b.Final
+	}
+	if a != nil && b != nil {
+		c.Delta = a.rank - b.rank
+	}
+	return c
+}
+
+// spearman returns the Spearman rank correlation of the common items,
+// re-ranked among themselves so items scored under one version only do not
+// leave gaps.
+func spearman(common []models.RankChange) *float64 {
+	n := len(common)
+	if n < 2 {
+		return nil
+	}
+
+	byA := append([]models.RankChange(nil), common...)
+	sort.Slice(byA, func(i, j int) bool { return *byA[i].RankA < *byA[j].RankA })
+	posA := make(map[string]int, n)
+	for i, c := range byA {
+		posA[c.ItemID.String()] = i
+	}
+	byB := append([]models.RankChange(nil), common...)
+	sort.Slice(byB, func(i, j int) bool { return *byB[i].RankB < *byB[j].RankB })
+
+	var sumD2 float64
+	for i, c := range byB {
+		d := float64(posA[c.ItemID.String()] - i)
+		sumD2 += d * d
+	}
+	rho := 1 - 6*sumD2/float64(n*(n*n-1))
+	return &rho
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func truncate(changes []models.RankChange, limit int) []models.RankChange {
+	if limit > 0 && len(changes) > limit {
+		return changes[:limit]
+	}
+	return changes
+}
@@ -0,0 +1,66 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestCompareRankings(t *testing.T) {
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	h := func(id uuid.UUID, final float64) models.ScoreHistory {
+		return models.ScoreHistory{ItemID: id, Final: final}
+	}
+
+	// A ranks 0,1,2,3; B swaps 0 and 2, drops 3 and adds 4 in first place.
+	a := []models.ScoreHistory{h(ids[0], 0.9), h(ids[1], 0.8), h(ids[2], 0.7), h(ids[3], 0.6)}
+	b := []models.ScoreHistory{h(ids[4], 0.95), h(ids[2], 0.9), h(ids[1], 0.8), h(ids[0], 0.7)}
+
+	cmp := CompareRankings(a, b, 2, 10)
+
+	if cmp.ItemsA != 4 || cmp.ItemsB != 4 || cmp.Common != 3 {
+		t.Fatalf("counts = %d/%d/%d, want 4/4/3", cmp.ItemsA, cmp.ItemsB, cmp.Common)
+	}
+	// Top 2: A {0,1}, B {4,2}.
+	if cmp.TopOverlap != 0 {
+		t.Errorf("TopOverlap = %d, want 0", cmp.TopOverlap)
+	}
+	// Common items re-ranked: A 0,1,2 vs B 2,1,0 — a full reversal.
+	if cmp.SpearmanRho == nil || math.Abs(*cmp.SpearmanRho+1) > 1e-9 {
+		t.Errorf("SpearmanRho = %v, want -1", cmp.SpearmanRho)
+	}
+
+	if len(cmp.Movers) != 3 {
+		t.Fatalf("Movers = %+v, want items 0, 2 and 1", cmp.Movers)
+	}
+	// Item 0 fell 1 → 4, item 2 rose 3 → 2 and item 1 fell 2 → 3. Larger
+	// moves come first, ties by rank under B.
+	if cmp.Movers[0].ItemID != ids[0] || cmp.Movers[0].Delta != -3 {
+		t.Errorf("first mover = %+v, want item 0 with delta -3", cmp.Movers[0])
+	}
+	if cmp.Movers[1].ItemID != ids[2] || cmp.Movers[1].Delta != 1 {
+		t.Errorf("second mover = %+v, want item 2 with delta 1", cmp.Movers[1])
+	}
+	if cmp.Movers[2].ItemID != ids[1] || cmp.Movers[2].Delta != -1 {
+		t.Errorf("third mover = %+v, want item 1 with delta -1", cmp.Movers[2])
+	}
+
+	if len(cmp.OnlyA) != 1 || cmp.OnlyA[0].ItemID != ids[3] || cmp.OnlyA[0].RankB != nil {
+		t.Errorf("OnlyA = %+v, want item 3", cmp.OnlyA)
+	}
+	if len(cmp.OnlyB) != 1 || cmp.OnlyB[0].ItemID != ids[4] || *cmp.OnlyB[0].RankB != 1 {
+		t.Errorf("OnlyB = %+v, want item 4 ranked first", cmp.OnlyB)
+	}
+}
+
+func TestCompareRankings_Empty(t *testing.T) {
+	cmp := CompareRankings(nil, nil, 10, 10)
+	if cmp.SpearmanRho != nil || cmp.Movers == nil || cmp.OnlyA == nil || cmp.OnlyB == nil {
+		t.Errorf("empty comparison = %+v, want nil rho and empty lists", cmp)
+	}
+}
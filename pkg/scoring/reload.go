@@ -0,0 +1,160 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SettingsKey is the settings table key holding a scoring config override.
+const SettingsKey = "scoring_config"
+
+// SettingsSource reads values from the settings table.
+type SettingsSource interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// SnapshotStore keeps a copy of every configuration that was active, by
+// version, so rankings can later be recomputed under an old one.
+type SnapshotStore interface {
+	Save(ctx context.Context, version, algorithmVersion, config string) error
+}
+
+// Loader reads the scoring configuration and installs it as Current. A
+// non-empty SettingsKey entry wins over the file; with neither present the
+// compiled-in defaults are used.
+type Loader struct {
+	path     string
+	settings SettingsSource
+
+	snapshots SnapshotStore
+
+	mu     sync.Mutex
+	source string
+	saved  string // last version written to snapshots
+}
+
+// NewLoader creates a Loader for the YAML file at path (may be empty) and the
+// settings table (may be nil).
+func NewLoader(path string, settings SettingsSource) *Loader {
+	return &Loader{path: path, settings: settings, source: "defaults"}
+}
+
+// KeepSnapshots makes Reload store every configuration it installs in
+// store. Call it before the first Reload.
+func (l *Loader) KeepSnapshots(store SnapshotStore) {
+	l.snapshots = store
+}
+
+// Load reads and validates the configuration without installing it. It also
+// reports where the configuration came from.
+func (l *Loader) Load(ctx context.Context) (*Config, string, error) {
+	if l.settings != nil {
+		raw, err := l.settings.Get(ctx, SettingsKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read %s setting: %w", SettingsKey, err)
+		}
+		if raw != "" {
+			cfg, err := Parse([]byte(raw))
+			if err != nil {
+				return nil, "", fmt.Errorf("settings %s: %w", SettingsKey, err)
+			}
+			return cfg, "settings:" + SettingsKey, nil
+		}
+	}
+
+	if l.path != "" {
+		data, err := os.ReadFile(l.path)
+		switch {
+		case err == nil:
+			cfg, err := Parse(data)
+			if err != nil {
+				return nil, "", fmt.Errorf("%s: %w", l.path, err)
+			}
+			return cfg, "file:" + l.path, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, "", err
+		}
+	}
+
+	return DefaultConfig(), "defaults", nil
+}
+
+// Reload loads the configuration and makes it Current. On error the active
+// configuration is kept.
+func (l *Loader) Reload(ctx context.Context) (*Config, error) {
+	cfg, source, err := l.Load(ctx)
+	if err != nil {
+		return Current(), err
+	}
+
+	l.mu.Lock()
+	l.source = source
+	save := l.snapshots != nil && l.saved != cfg.Version
+	l.mu.Unlock()
+
+	if prev := Current(); prev.Version != cfg.Version {
+		SetCurrent(cfg)
+		log.Printf("Scoring config %s loaded from %s (was %s)", cfg.Version, source, prev.Version)
+	}
+	if save {
+		l.saveSnapshot(ctx, cfg)
+	}
+	return cfg, nil
+}
+
+// saveSnapshot stores cfg. A failure is logged and retried on the next
+// Reload; it never blocks the config from taking effect.
+func (l *Loader) saveSnapshot(ctx context.Context, cfg *Config) {
+	snapshot, err := cfg.Snapshot()
+	if err == nil {
+		err = l.snapshots.Save(ctx, cfg.Version, AlgorithmVersion, snapshot)
+	}
+	if err != nil {
+		log.Printf("Failed to store scoring config %s: %v", cfg.Version, err)
+		return
+	}
+	l.mu.Lock()
+	l.saved = cfg.Version
+	l.mu.Unlock()
+}
+
+// Source reports where the active configuration was last loaded from.
+func (l *Loader) Source() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.source
+}
+
+// Watch reloads the configuration every interval until ctx is cancelled.
+func (l *Loader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastErr := ""
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := l.Reload(ctx)
+			if err == nil {
+				lastErr = ""
+				continue
+			}
+			// Log a broken config once, not on every tick.
+			if err.Error() != lastErr {
+				lastErr = err.Error()
+				log.Printf("Scoring config reload failed, keeping %s: %v", Current().Version, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package scoring
+
+import (
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// Reputation holds learned credibility per domain and per source. Manual
+// tiers in the scoring config always take precedence over it.
+type Reputation struct {
+	Domains map[string]float64
+	Sources map[uuid.UUID]float64
+}
+
+var reputation atomic.Pointer[Reputation]
+
+// CurrentReputation returns the active learned reputation, or nil before
+// one has been loaded.
+func CurrentReputation() *Reputation {
+	return reputation.Load()
+}
+
+// SetReputation makes r the active learned reputation.
+func SetReputation(r *Reputation) {
+	reputation.Store(r)
+}
+
+// resolveCredibility applies, in order: a manual tier from the config, the
+// domain's learned reputation, the source's learned reputation and finally
+// the configured baseline.
+func resolveCredibility(domain string, sourceID uuid.UUID, config CredibilityConfig, rep *Reputation) (float64, string) {
+	val, tier := computeCredibilityScoreWithConfig(domain, config)
+	if tier != "baseline" || rep == nil {
+		return val, tier
+	}
+	if v, ok := rep.Domains[domain]; ok {
+		return v, "domain_reputation"
+	}
+	if v, ok := rep.Sources[sourceID]; ok {
+		return v, "source_reputation"
+	}
+	return val, tier
+}
@@ -0,0 +1,34 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestResolveCredibility(t *testing.T) {
+	source := uuid.New()
+	cfg := DefaultCredibilityConfig()
+	rep := &Reputation{
+		Domains: map[string]float64{"arxiv.org": 0.3, "blog.example": 0.9},
+		Sources: map[uuid.UUID]float64{source: 0.8},
+	}
+
+	tests := []struct {
+		domain    string
+		rep       *Reputation
+		wantValue float64
+		wantTier  string
+	}{
+		{"arxiv.org", rep, 1.2, "tier1"}, // manual tier wins over learned reputation
+		{"blog.example", rep, 0.9, "domain_reputation"},
+		{"other.example", rep, 0.8, "source_reputation"},
+		{"other.example", nil, 0.5, "baseline"},
+	}
+	for _, tt := range tests {
+		value, tier := resolveCredibility(tt.domain, source, cfg, tt.rep)
+		if value != tt.wantValue || tier != tt.wantTier {
+			t.Errorf("resolveCredibility(%q) = %v, %s; want %v, %s", tt.domain, value, tier, tt.wantValue, tt.wantTier)
+		}
+	}
+}
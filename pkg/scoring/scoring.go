@@ -2,17 +2,20 @@ package scoring
 
 import (
 	"math"
+	"sort"
 	"time"
 
 	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 )
 
 type Weights struct {
-	W1 float64 // popularity
-	W2 float64 // relevance/impact
-	W3 float64 // credibility
-	W4 float64 // engineering value
-	W5 float64 // novelty
+	W1 float64 `yaml:"popularity" json:"popularity"`
+	W2 float64 `yaml:"impact" json:"impact"` // relevance/impact
+	W3 float64 `yaml:"credibility" json:"credibility"`
+	W4 float64 `yaml:"engineering_value" json:"engineering_value"`
+	W5 float64 `yaml:"novelty" json:"novelty"`
+	W6 float64 `yaml:"corroboration" json:"corroboration"`
 }
 
 var DefaultWeights = Weights{
@@ -21,37 +24,111 @@ var DefaultWeights = Weights{
 	W3: 0.1, // Credibility
 	W4: 0.4, // Engineering Value
 	W5: 0.2, // Novelty
+	W6: 0.1, // Corroboration by other sources
 }
 
-var (
-	defaultCredibilityConfig = DefaultCredibilityConfig()
-	defaultRelevanceKeywords = DefaultRelevanceKeywords()
-)
-
+// ComputeScore scores item with the active configuration, using weights in
+// place of the configured ones.
 func ComputeScore(item *models.Item, signal *models.Signal, summary *models.Summary, existingScore *models.Score, weights Weights) *models.Score {
-	hot := computeHotScore(signal, item.PublishedAt)
-	credibility := computeCredibilityScore(item.Domain)
+	cfg := Current()
+	if cfg.Weights != weights {
+		override := *cfg
+		override.Weights = weights
+		override.Version = override.computeVersion()
+		cfg = &override
+	}
+	return ComputeScoreWithConfig(item, signal, summary, existingScore, nil, cfg)
+}
 
-	relevance := computeRelevanceScore(item, summary) // Fallback for impact if no LLM
+// ComputeScoreWithConfig scores item with cfg. coverage is the item's story
+// coverage for corroboration (see ComputeCorroboration); nil counts as
+// uncorroborated. Its "self" entry also supplies the item's source type for
+// decay profiles. The result records cfg's version and an explanation of how
+// the final score was built.
+func ComputeScoreWithConfig(item *models.Item, signal *models.Signal, summary *models.Summary, existingScore *models.Score, coverage []models.CoverageItem, cfg *Config) *models.Score {
+	weights := cfg.Weights
+	corroboration := ComputeCorroboration(coverage)
+	decay := cfg.Decay.Resolve(item.Category, selfSourceType(coverage))
+	hot, hotDecay := computeHotScore(signal, item.PublishedAt, decay)
+	credibility, tier := resolveCredibility(item.Domain, item.SourceID, cfg.Credibility, CurrentReputation())
+
+	tax := taxonomy.Current()
+	relevance, keywords := computeRelevanceScoreWithConfig(item, summary, cfg.Keywords, tax) // Fallback for impact if no LLM
 
 	// Default to heuristic novelty
-	novelty := computeNoveltyScore(item.PublishedAt)
+	novelty := computeNoveltyScore(item.PublishedAt, decay)
 	impact := relevance
 	engineeringValue := relevance
 	reasoning := ""
+	llmSource := "heuristic"
 
-	// Use LLM scores if available
-	if existingScore != nil && existingScore.Impact > 0 {
-		// LLM scores are 1-10. Normalize to 0-1 for internal math if needed,
-		// but wait, let's keep them as 0-1 or 1-10.
-		// AnalyzeArticle returns 1-10. Let's normalize them here to 0-1.
-		novelty = existingScore.Novelty / 10.0
-		impact = existingScore.Impact / 10.0
-		engineeringValue = existingScore.EngineeringValue / 10.0
+	// Use LLM scores if available. The raw ratings are kept apart from the
+	// computed components so rescoring never normalizes them twice.
+	var ratings *models.LLMRatings
+	if existingScore != nil {
+		ratings = existingScore.LLMRatings
+	}
+	llmScores := ratings != nil && ratings.Impact > 0
+	if llmScores {
+		// AnalyzeArticle returns 1-10; normalize to 0-1 for the weighted sum.
+		novelty = ratings.Novelty / 10.0
+		impact = ratings.Impact / 10.0
+		engineeringValue = ratings.EngineeringValue / 10.0
 		reasoning = existingScore.Reasoning
+		llmSource = "llm"
 	}
 
-	final := (weights.W2 * impact) + (weights.W3 * credibility) + (weights.W4 * engineeringValue) + (weights.W5 * novelty)
+	hotSource := "signal"
+	if signal == nil {
+		hotSource = "none"
+	}
+	components := []models.ScoreComponent{
+		{Name: "popularity", Value: hot, Weight: weights.W1, Source: hotSource},
+		{Name: "impact", Value: impact, Weight: weights.W2, Source: llmSource},
+		{Name: "credibility", Value: credibility, Weight: weights.W3, Source: "domain"},
+		{Name: "engineering_value", Value: engineeringValue, Weight: weights.W4, Source: llmSource},
+		{Name: "novelty", Value: novelty, Weight: weights.W5, Source: llmSource},
+		{Name: "corroboration", Value: corroboration.Factor, Weight: weights.W6, Source: "coverage"},
+	}
+	final := 0.0
+	for i := range components {
+		components[i].Contribution = components[i].Value * components[i].Weight
+		final += components[i].Contribution
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].Contribution > components[j].Contribution
+	})
+
+	explanation := &models.ScoreExplanation{
+		ConfigVersion:   cfg.Version,
+		TaxonomyVersion: tax.Version(),
+		Final:           final,
+		Components:      components,
+		Relevance:       relevance,
+		Keywords:        keywords,
+		Credibility:     models.CredibilityTier{Domain: item.Domain, Tier: tier, Value: credibility},
+		Decay: models.DecayFactors{
+			AgeHours:           time.Since(item.PublishedAt).Hours(),
+			Profile:            decay.Name,
+			HotHalfLifeHours:   decay.HotHalfLifeHours,
+			HotDecayFactor:     hotDecay,
+			NoveltyWindowHours: decay.NoveltyWindowHours,
+		},
+		Corroboration: corroboration,
+		Provenance: models.ScoreProvenance{
+			LLMScores: llmScores,
+			Reasoning: reasoning,
+		},
+	}
+	if summary != nil {
+		explanation.Provenance.SummaryMethod = summary.Method
+	}
+	if signal != nil {
+		fetchedAt := signal.FetchedAt
+		explanation.Provenance.SignalFetchedAt = &fetchedAt
+		explanation.Provenance.Points = signal.Points
+		explanation.Provenance.Comments = signal.Comments
+	}
 
 	return &models.Score{
 		ItemID:           item.ID,
@@ -63,13 +140,23 @@ func ComputeScore(item *models.Item, signal *models.Signal, summary *models.Summ
 		EngineeringValue: engineeringValue,
 		Reasoning:        reasoning,
 		Final:            final,
+		AlgorithmVersion: AlgorithmVersion,
+		ConfigVersion:    cfg.Version,
 		ComputedAt:       time.Now(),
+		LLMRatings:       ratings,
+		Explanation:      explanation,
 	}
 }
 
-func computeHotScore(signal *models.Signal, publishedAt time.Time) float64 {
+// computeHotScore returns the hot score and the recency decay factor applied
+// to it.
+func computeHotScore(signal *models.Signal, publishedAt time.Time, decay DecayProfile) (float64, float64) {
+	// Recency decay: older items get lower hot score
+	ageHours := time.Since(publishedAt).Hours()
+	decayFactor := math.Exp2(-ageHours / decay.HotHalfLifeHours)
+
 	if signal == nil {
-		return 0.0
+		return 0.0, decayFactor
 	}
 
 	points := 0
@@ -81,10 +168,6 @@ func computeHotScore(signal *models.Signal, publishedAt time.Time) float64 {
 		comments = *signal.Comments
 	}
 
-	// Recency decay: older items get lower hot score
-	ageHours := time.Since(publishedAt).Hours()
-	decayFactor := math.Exp(-ageHours / 48.0) // Half-life of 48 hours
-
 	// Simple scoring: points * 10 + comments * 5
 	rawScore := float64(points*10 + comments*5)
 
@@ -95,55 +178,60 @@ func computeHotScore(signal *models.Signal, publishedAt time.Time) float64 {
 		normalized = 1.0
 	}
 
-	return normalized * decayFactor
-}
-
-func computeRelevanceScore(item *models.Item, summary *models.Summary) float64 {
-	return computeRelevanceScoreWithConfig(item, summary, defaultRelevanceKeywords)
+	return normalized * decayFactor, decayFactor
 }
 
-func computeRelevanceScoreWithConfig(item *models.Item, summary *models.Summary, keywords RelevanceKeywords) float64 {
-	// Check title and excerpt for AI/ML keywords
+func computeRelevanceScoreWithConfig(item *models.Item, summary *models.Summary, keywords RelevanceKeywords, tax *taxonomy.Taxonomy) (float64, []models.KeywordMatch) {
+	// Check title and excerpt for the keywords of weighted taxonomy topics
 	text := item.Title
 	if item.RawExcerpt != nil {
 		text += " " + *item.RawExcerpt
 	}
-	textLower := toLower(text)
 
-	score := 0.0
-	matches := 0
-
-	// LLM keywords
-	for _, kw := range keywords.LLM {
-		if contains(textLower, kw) {
-			score += keywords.Weights["llm"]
-			matches++
+	weights := make(map[string]float64, len(keywords.Weights))
+	for key, w := range keywords.Weights {
+		if key == tagWeightKey {
+			continue
+		}
+		if slug, ok := tax.Resolve(key); ok {
+			weights[slug] = w
 		}
 	}
-
-	// MLOps keywords
-	for _, kw := range keywords.MLOps {
-		if contains(textLower, kw) {
-			score += keywords.Weights["mlops"]
-			matches++
+	// weightedTopic returns the nearest topic at or above slug that has a
+	// weight.
+	weightedTopic := func(slug string) (string, bool) {
+		for _, s := range tax.Ancestors(slug) {
+			if _, ok := weights[s]; ok {
+				return s, true
+			}
 		}
+		return "", false
 	}
 
-	// CV keywords
-	for _, kw := range keywords.CV {
-		if contains(textLower, kw) {
-			score += keywords.Weights["cv"]
-			matches++
+	score := 0.0
+	var matches []models.KeywordMatch
+	seen := make(map[string]bool)
+
+	for _, hit := range tax.Match(text) {
+		group, ok := weightedTopic(hit.Topic)
+		if !ok || seen[group+"\x00"+hit.Keyword] {
+			continue
 		}
+		seen[group+"\x00"+hit.Keyword] = true
+		score += weights[group]
+		matches = append(matches, models.KeywordMatch{Group: group, Topic: hit.Topic, Keyword: hit.Keyword, Weight: weights[group]})
 	}
 
 	// Check tags from summary
 	if summary != nil {
 		for _, tag := range summary.Tags {
-			tagLower := toLower(tag)
-			if contains(tagLower, "llm") || contains(tagLower, "ml") || contains(tagLower, "ai") {
-				score += keywords.Weights["tag"]
-				matches++
+			slug, ok := tax.Resolve(tag)
+			if !ok {
+				continue
+			}
+			if _, ok := weightedTopic(slug); ok {
+				score += keywords.Weights[tagWeightKey]
+				matches = append(matches, models.KeywordMatch{Group: tagWeightKey, Topic: slug, Keyword: tag, Weight: keywords.Weights[tagWeightKey]})
 			}
 		}
 	}
@@ -154,41 +242,39 @@ func computeRelevanceScoreWithConfig(item *models.Item, summary *models.Summary,
 	}
 
 	// If no matches, give baseline 0.1 (might still be relevant)
-	if matches == 0 {
+	if len(matches) == 0 {
 		score = 0.1
 	}
 
-	return score
-}
-
-func computeCredibilityScore(domain string) float64 {
-	return computeCredibilityScoreWithConfig(domain, defaultCredibilityConfig)
+	return score, matches
 }
 
-func computeCredibilityScoreWithConfig(domain string, config CredibilityConfig) float64 {
+// computeCredibilityScoreWithConfig returns the domain's credibility and the
+// tier it was found in.
+func computeCredibilityScoreWithConfig(domain string, config CredibilityConfig) (float64, string) {
 	if val, ok := config.Tier1[domain]; ok {
-		return val
+		return val, "tier1"
 	}
 	if val, ok := config.Tier2[domain]; ok {
-		return val
+		return val, "tier2"
 	}
 	if val, ok := config.Tier3[domain]; ok {
-		return val
+		return val, "tier3"
 	}
-	return 0.5 // Baseline
+	return config.Baseline, "baseline"
 }
 
-func computeNoveltyScore(publishedAt time.Time) float64 {
+func computeNoveltyScore(publishedAt time.Time, decay DecayProfile) float64 {
 	// Newer items get higher novelty
 	ageHours := time.Since(publishedAt).Hours()
 
-	// Decay: items older than 7 days get very low novelty
-	if ageHours > 168 { // 7 days
+	// Decay: items older than the window get very low novelty
+	if ageHours > decay.NoveltyWindowHours {
 		return 0.1
 	}
 
-	// Linear decay from 1.0 to 0.1 over 7 days
-	novelty := 1.0 - (ageHours / 168.0 * 0.9)
+	// Linear decay from 1.0 to 0.1 over the window
+	novelty := 1.0 - (ageHours / decay.NoveltyWindowHours * 0.9)
 	if novelty < 0.1 {
 		novelty = 0.1
 	}
@@ -196,35 +282,6 @@ func computeNoveltyScore(publishedAt time.Time) float64 {
 	return novelty
 }
 
-func toLower(s string) string {
-	// Simple lowercase conversion
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		if s[i] >= 'A' && s[i] <= 'Z' {
-			result[i] = s[i] + 32
-		} else {
-			result[i] = s[i]
-		}
-	}
-	return string(result)
-}
-
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr ||
-		(len(s) > len(substr) && (s[:len(substr)] == substr ||
-			s[len(s)-len(substr):] == substr ||
-			indexOf(s, substr) != -1)))
-}
-
-func indexOf(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
-	}
-	return -1
-}
-
 // ConvertToScale10 converts a 0-1 score to 1-10 scale for better UX
 // 0.0-0.1 -> 1
 // 0.1-0.2 -> 2
@@ -0,0 +1,87 @@
+package scoring
+
+import (
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+type sample struct {
+	at         time.Time
+	engagement float64
+}
+
+// engagement weighs comments at half a point, matching the hot score.
+func engagement(s models.Signal) float64 {
+	e := 0.0
+	if s.Points != nil {
+		e += float64(*s.Points)
+	}
+	if s.Comments != nil {
+		e += float64(*s.Comments) / 2
+	}
+	return e
+}
+
+// ComputeVelocity derives engagement velocity and acceleration from an
+// item's signals, oldest first. With a single signal the item is assumed to
+// have started from zero at publication. With three or more, velocity is the
+// slope over the newer half of the series and acceleration compares it with
+// the older half.
+func ComputeVelocity(signals []models.Signal, publishedAt time.Time) models.ItemVelocity {
+	v := models.ItemVelocity{Samples: len(signals), ComputedAt: time.Now()}
+	if len(signals) == 0 {
+		return v
+	}
+	v.ItemID = signals[0].ItemID
+
+	samples := make([]sample, 0, len(signals)+1)
+	if len(signals) == 1 && publishedAt.Before(signals[0].FetchedAt) {
+		samples = append(samples, sample{at: publishedAt})
+	}
+	for _, s := range signals {
+		samples = append(samples, sample{at: s.FetchedAt, engagement: engagement(s)})
+	}
+	v.Engagement = samples[len(samples)-1].engagement
+
+	n := len(samples)
+	switch {
+	case n < 2:
+		return v
+	case n == 2:
+		v.Velocity, _ = slope(samples[0], samples[1])
+		return v
+	}
+
+	mid := n / 2
+	recent, okRecent := slope(samples[mid], samples[n-1])
+	older, okOlder := slope(samples[0], samples[mid])
+	if !okRecent {
+		v.Velocity, _ = slope(samples[0], samples[n-1])
+		return v
+	}
+	v.Velocity = recent
+	if !okOlder {
+		return v
+	}
+
+	// Time between the midpoints of the two halves.
+	gap := midpoint(samples[mid], samples[n-1]).Sub(midpoint(samples[0], samples[mid])).Hours()
+	if gap > 0 {
+		v.Acceleration = (recent - older) / gap
+	}
+	return v
+}
+
+// slope returns the engagement gained per hour between a and b.
+func slope(a, b sample) (float64, bool) {
+	hours := b.at.Sub(a.at).Hours()
+	if hours <= 0 {
+		return 0, false
+	}
+	return (b.engagement - a.engagement) / hours, true
+}
+
+func midpoint(a, b sample) time.Time {
+	return a.at.Add(b.at.Sub(a.at) / 2)
+}
@@ -0,0 +1,83 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func sig(at time.Time, points, comments int) models.Signal {
+	return models.Signal{Points: &points, Comments: &comments, FetchedAt: at}
+}
+
+func TestComputeVelocity(t *testing.T) {
+	t0 := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	h := func(n float64) time.Time { return t0.Add(time.Duration(n * float64(time.Hour))) }
+
+	tests := []struct {
+		name             string
+		published        time.Time
+		signals          []models.Signal
+		wantVelocity     float64
+		wantAcceleration float64
+	}{
+		{
+			name:      "no signals",
+			published: t0,
+		},
+		{
+			name:         "single signal counts from publication",
+			published:    t0,
+			signals:      []models.Signal{sig(h(2), 100, 40)}, // engagement 120 over 2h
+			wantVelocity: 60,
+		},
+		{
+			name:         "two signals use their slope",
+			published:    h(-10),
+			signals:      []models.Signal{sig(h(0), 100, 0), sig(h(4), 300, 0)},
+			wantVelocity: 50,
+		},
+		{
+			name:      "accelerating",
+			published: h(-10),
+			signals: []models.Signal{
+				sig(h(0), 0, 0), sig(h(2), 20, 0), // older half: 10/h
+				sig(h(4), 120, 0), // newer half: 50/h
+			},
+			wantVelocity:     50,
+			wantAcceleration: 20, // (50-10) over 2h between half midpoints
+		},
+		{
+			name:      "decelerating",
+			published: h(-10),
+			signals: []models.Signal{
+				sig(h(0), 0, 0), sig(h(1), 100, 0), sig(h(2), 150, 0), sig(h(3), 160, 0),
+			},
+			wantVelocity:     10,         // newer half: 150 → 160 over 1h
+			wantAcceleration: -130.0 / 3, // older half 75/h; (10-75) over 1.5h between midpoints
+		},
+		{
+			name:         "duplicate timestamps fall back to the full span",
+			published:    h(-10),
+			signals:      []models.Signal{sig(h(0), 0, 0), sig(h(2), 50, 0), sig(h(2), 60, 0)},
+			wantVelocity: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ComputeVelocity(tt.signals, tt.published)
+			if v.Samples != len(tt.signals) {
+				t.Errorf("Samples = %d, want %d", v.Samples, len(tt.signals))
+			}
+			if math.Abs(v.Velocity-tt.wantVelocity) > 1e-9 {
+				t.Errorf("Velocity = %v, want %v", v.Velocity, tt.wantVelocity)
+			}
+			if math.Abs(v.Acceleration-tt.wantAcceleration) > 1e-9 {
+				t.Errorf("Acceleration = %v, want %v", v.Acceleration, tt.wantAcceleration)
+			}
+		})
+	}
+}
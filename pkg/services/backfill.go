@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/connectors"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/dto"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+const (
+	// backfillTokensPerItem is the rough cost of one LLM call on an item
+	// (prompt with title and excerpt plus the structured answer).
+	backfillTokensPerItem = 500
+	// backfillMaxRange bounds how far back a single backfill may reach.
+	backfillMaxRange = 366 * 24 * time.Hour
+	arxivPageSize    = 100
+	// backfillSchedule is the shared schedule backfill LLM jobs are spaced on.
+	backfillSchedule  = "backfill"
+	defaultArxivQuery = "cat:cs.AI OR cat:cs.LG OR cat:cs.CV OR cat:cs.CL"
+)
+
+// ErrBackfillUnsupported is returned for source types that cannot be paged
+// back in time.
+var ErrBackfillUnsupported = errors.New("source type does not support backfill")
+
+// BackfillRequest selects the source and publication window to backfill.
+type BackfillRequest struct {
+	SourceID uuid.UUID
+	From     time.Time
+	To       time.Time
+	Query    string // optional topic filter (HN full-text search, arXiv search_query)
+	MaxPages int    // 0 uses BACKFILL_MAX_PAGES
+}
+
+// inWindow returns the items published in [From, To).
+func (req BackfillRequest) inWindow(items []dto.ContentItem) []dto.ContentItem {
+	var in []dto.ContentItem
+	for _, ci := range items {
+		if !ci.PublishedAt.Before(req.From) && ci.PublishedAt.Before(req.To) {
+			in = append(in, ci)
+		}
+	}
+	return in
+}
+
+// BackfillResult summarises a finished backfill.
+type BackfillResult struct {
+	CrawlRunID *uuid.UUID `json:"crawl_run_id,omitempty"`
+	Pages      int        `json:"pages"`
+	Fetched    int        `json:"fetched"`
+	Inserted   int        `json:"inserted"`
+	Enriched   int        `json:"enrich_queued"` // items queued for LLM enrichment
+}
+
+// Backfiller pages through a source's history and ingests items with their
+// original publication dates.
+type Backfiller struct {
+	worker *Worker
+	queue  *jobs.Queue
+	cfg    *config.Config
+}
+
+func NewBackfiller(database *db.DB, cfg *config.Config) *Backfiller {
+	return &Backfiller{
+		worker: NewWorker(database, cfg),
+		queue:  jobs.NewQueue(database),
+		cfg:    cfg,
+	}
+}
+
+// Backfill is a validated backfill ready to run.
+type Backfill struct {
+	b      *Backfiller
+	req    BackfillRequest
+	source models.Source
+	run    *ingestionRun
+}
+
+// normalize fills in defaults and checks the window: To defaults to now,
+// From must precede it by at most backfillMaxRange, and MaxPages is capped
+// at maxPages.
+func (req BackfillRequest) normalize(now time.Time, maxPages int) (BackfillRequest, error) {
+	if req.To.IsZero() {
+		req.To = now
+	}
+	if !req.From.Before(req.To) {
+		return req, fmt.Errorf("from must be before to")
+	}
+	if req.To.Sub(req.From) > backfillMaxRange {
+		return req, fmt.Errorf("date range must not exceed %d days", int(backfillMaxRange.Hours()/24))
+	}
+	if req.MaxPages <= 0 || req.MaxPages > maxPages {
+		req.MaxPages = maxPages
+	}
+	return req, nil
+}
+
+// Prepare validates req and records the crawl run the backfill reports into.
+func (b *Backfiller) Prepare(ctx context.Context, req BackfillRequest) (*Backfill, error) {
+	req, err := req.normalize(time.Now(), b.cfg.BackfillMaxPages)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := b.worker.sourceRepo.GetByID(ctx, req.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load source: %w", err)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("source %s not found", req.SourceID)
+	}
+	if _, err := b.pager(*source, req); err != nil {
+		return nil, err
+	}
+
+	run := newIngestionRun(b.worker.db, b.worker.instanceID, "backfill", nil)
+	if run.id(ctx) == nil {
+		return nil, fmt.Errorf("failed to record backfill run: %w", run.createErr)
+	}
+	return &Backfill{b: b, req: req, source: *source, run: run}, nil
+}
+
+// RunID is the crawl run recording this backfill.
+func (bf *Backfill) RunID() uuid.UUID {
+	return bf.run.run.ID
+}
+
+// Run fetches every page in the window, stores new items and queues their
+// enrichment.
+func (bf *Backfill) Run(ctx context.Context) (BackfillResult, error) {
+	res := BackfillResult{CrawlRunID: bf.run.id(ctx)}
+	started := time.Now()
+	log.Printf("Backfilling %s from %s to %s", bf.source.Name, bf.req.From.Format(time.DateOnly), bf.req.To.Format(time.DateOnly))
+
+	fetchRun := &models.FetchRun{SourceID: bf.source.ID, Status: "success", CrawlRunID: res.CrawlRunID}
+
+	next, _ := bf.b.pager(bf.source, bf.req)
+	var created []backfilledItem
+	var counts itemCounts
+	var runErr error
+	for res.Pages < bf.req.MaxPages {
+		if err := ctx.Err(); err != nil {
+			runErr = err
+			break
+		}
+
+		items, done, err := next(ctx)
+		if err != nil {
+			runErr = fmt.Errorf("page %d: %w", res.Pages+1, err)
+			break
+		}
+		res.Pages++
+
+		counts.add(countItems(bf.req.inWindow(items), func(ci dto.ContentItem) (bool, error) {
+			// LLM jobs are queued below, within the backfill budget.
+			item, isNew, err := bf.b.worker.storeItem(ctx, bf.source, ci)
+			if isNew {
+				created = append(created, backfilledItem{item: item, excerpt: ci.Excerpt, points: ci.Points})
+			}
+			return isNew, err
+		}))
+		if done {
+			break
+		}
+	}
+	res.Fetched = counts.Fetched
+	res.Inserted = counts.Inserted
+	res.Enriched = bf.enqueueEnrichment(ctx, created)
+
+	fetchRun.ItemsFetched = res.Fetched
+	fetchRun.ItemsInserted = res.Inserted
+	if runErr != nil {
+		fetchRun.Status = "failed"
+		msg := runErr.Error()
+		fetchRun.Error = &msg
+	}
+	if err := bf.b.worker.fetchRunRepo.Create(context.WithoutCancel(ctx), fetchRun); err != nil {
+		log.Printf("Error creating fetch run: %v", err)
+	}
+
+	bf.run.record(bf.source, started, counts, runErr)
+	bf.run.finish(ctx, runErr)
+
+	log.Printf("Backfill of %s: %d pages, %d items in range, %d new, %d queued for LLM enrichment",
+		bf.source.Name, res.Pages, res.Fetched, res.Inserted, res.Enriched)
+	return res, runErr
+}
+
+type backfilledItem struct {
+	item    *models.Item
+	excerpt string
+	points  *int
+}
+
+// enqueueEnrichment queues scoring for every new item and LLM enrichment
+// (summaries and, when configured, the entity pass) for the most popular
+// ones. LLM jobs join the backfill schedule shared by all backfills through
+// the jobs table, spaced so backfill spends at most BACKFILL_DAILY_TOKENS of
+// the LLM budget per day however many run at once. It returns how many
+// items were queued for LLM enrichment.
+func (bf *Backfill) enqueueEnrichment(ctx context.Context, created []backfilledItem) int {
+	for _, c := range created {
+		p := jobs.ItemPayload{ItemID: c.item.ID, Title: c.item.Title}
+		if err := bf.b.queue.EnqueueItem(ctx, jobs.TypeScore, p); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	llmTypes := []string{jobs.TypeSummarize}
+	if entities.LLMConfigured(bf.b.cfg) {
+		llmTypes = append(llmTypes, jobs.TypeEntities)
+	}
+	// Each type is spaced on its own, so every item costs one call per type.
+	perDay := bf.b.cfg.BackfillDailyTokens / (backfillTokensPerItem * len(llmTypes))
+	if perDay <= 0 || bf.b.cfg.BackfillEnrichMax <= 0 {
+		return 0
+	}
+	spacing := 24 * time.Hour / time.Duration(perDay)
+
+	sort.SliceStable(created, func(i, j int) bool {
+		return itemPoints(created[i].points) > itemPoints(created[j].points)
+	})
+	if len(created) > bf.b.cfg.BackfillEnrichMax {
+		created = created[:bf.b.cfg.BackfillEnrichMax]
+	}
+
+	payloads := make([]jobs.ItemPayload, len(created))
+	for i, c := range created {
+		payloads[i] = jobs.ItemPayload{ItemID: c.item.ID, Title: c.item.Title, Content: c.excerpt, URL: c.item.URL}
+	}
+	queued := 0
+	for _, t := range llmTypes {
+		n, err := bf.b.queue.EnqueueItemsSpaced(ctx, t, backfillSchedule, payloads, spacing)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		queued = max(queued, n)
+	}
+	return queued
+}
+
+func itemPoints(p *int) int {
+	if p == nil {
+		return -1
+	}
+	return *p
+}
+
+// ParseBackfillTime accepts a date (2006-01-02, UTC midnight) or an RFC 3339 timestamp.
+func ParseBackfillTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339", s)
+	}
+	return t, nil
+}
+
+// pageFunc returns the next page of items, newest first, and whether the
+// source has nothing older left in the window.
+type pageFunc func(ctx context.Context) ([]dto.ContentItem, bool, error)
+
+func (b *Backfiller) pager(source models.Source, req BackfillRequest) (pageFunc, error) {
+	switch source.Type {
+	case "arxiv":
+		query := req.Query
+		if query == "" {
+			query = defaultArxivQuery
+		}
+		query = connectors.ArxivDateRange(query, req.From, req.To)
+		return arxivPager(func(ctx context.Context, start int) ([]dto.ContentItem, error) {
+			return connectors.FetchArxivPage(ctx, query, start, arxivPageSize, b.cfg)
+		}), nil
+
+	case "hacker_news", "hackernews":
+		return hnPager(req.To, func(ctx context.Context, to time.Time, page int) (*connectors.HNAlgoliaPage, error) {
+			return connectors.FetchHNAlgoliaPage(ctx, req.Query, req.From, to, page, b.cfg)
+		}), nil
+
+	case "json_api":
+		var mapping map[string]interface{}
+		if source.MappingJSON != nil {
+			if err := json.Unmarshal(source.MappingJSON, &mapping); err != nil {
+				return nil, fmt.Errorf("invalid mapping_json: %w", err)
+			}
+		}
+		if _, ok := mapping["page_param"]; !ok {
+			return nil, fmt.Errorf("%w: json_api source needs page_param in mapping_json", ErrBackfillUnsupported)
+		}
+		return jsonAPIPager(req.From, connectors.JSONAPIFirstPage(mapping), func(ctx context.Context, page int) ([]dto.ContentItem, error) {
+			return connectors.FetchJSONAPIPage(ctx, source.URL, mapping, page, b.cfg)
+		}), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrBackfillUnsupported, source.Type)
+}
+
+// arxivPager pages through an arXiv query by offset. A short page is the
+// last one.
+func arxivPager(fetch func(ctx context.Context, start int) ([]dto.ContentItem, error)) pageFunc {
+	start := 0
+	return func(ctx context.Context) ([]dto.ContentItem, bool, error) {
+		items, err := fetch(ctx, start)
+		start += arxivPageSize
+		return items, err == nil && len(items) < arxivPageSize, err
+	}
+}
+
+// hnPager pages through Algolia's HN search. Algolia stops after 1000 hits
+// per query, so once a window's pages run out, it continues with the window
+// ending at the oldest hit seen.
+func hnPager(to time.Time, fetch func(ctx context.Context, to time.Time, page int) (*connectors.HNAlgoliaPage, error)) pageFunc {
+	page := 0
+	return func(ctx context.Context) ([]dto.ContentItem, bool, error) {
+		res, err := fetch(ctx, to, page)
+		if err != nil {
+			return nil, false, err
+		}
+		page++
+		if page >= res.NbPages {
+			if res.Oldest.IsZero() || !res.Oldest.Before(to) || res.NbPages == 0 {
+				return res.Items, true, nil
+			}
+			to, page = res.Oldest, 0
+		}
+		return res.Items, false, nil
+	}
+}
+
+// jsonAPIPager pages through a json_api source from page first. It stops at
+// an empty page or once a whole page predates from.
+func jsonAPIPager(from time.Time, first int, fetch func(ctx context.Context, page int) ([]dto.ContentItem, error)) pageFunc {
+	page := first
+	return func(ctx context.Context) ([]dto.ContentItem, bool, error) {
+		items, err := fetch(ctx, page)
+		if err != nil {
+			return nil, false, err
+		}
+		page++
+		done := len(items) == 0
+		if !done {
+			done = true
+			for _, it := range items {
+				if !it.PublishedAt.Before(from) {
+					done = false
+					break
+				}
+			}
+		}
+		return items, done, nil
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/connectors"
+	"github.com/hidatara-ds/evolipia-radar/pkg/dto"
+)
+
+func TestParseBackfillTime(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2026-06-01", want: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "2026-06-01T12:30:00Z", want: time.Date(2026, 6, 1, 12, 30, 0, 0, time.UTC)},
+		{in: "01/06/2026", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBackfillTime(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseBackfillTime(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseBackfillTime(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseBackfillTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func day(d int) time.Time {
+	return time.Date(2026, 6, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBackfillRequest_Normalize(t *testing.T) {
+	now := day(30)
+	req, err := BackfillRequest{From: day(1), MaxPages: 500}.normalize(now, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !req.To.Equal(now) || req.MaxPages != 50 {
+		t.Errorf("normalize = to %v, max pages %d; want now and 50", req.To, req.MaxPages)
+	}
+
+	for name, bad := range map[string]BackfillRequest{
+		"empty window":    {From: day(2), To: day(2)},
+		"reversed window": {From: day(3), To: day(2)},
+		"too long":        {From: day(1).AddDate(-2, 0, 0), To: day(1)},
+	} {
+		if _, err := bad.normalize(now, 50); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBackfillRequest_InWindow(t *testing.T) {
+	req := BackfillRequest{From: day(2), To: day(4)}
+	items := []dto.ContentItem{
+		{Title: "before", PublishedAt: day(2).Add(-time.Second)},
+		{Title: "from", PublishedAt: day(2)},
+		{Title: "inside", PublishedAt: day(3)},
+		{Title: "to", PublishedAt: day(4)},
+	}
+	var got []string
+	for _, ci := range req.inWindow(items) {
+		got = append(got, ci.Title)
+	}
+	if want := []string{"from", "inside"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inWindow = %v, want %v", got, want)
+	}
+}
+
+func TestArxivPager(t *testing.T) {
+	var starts []int
+	next := arxivPager(func(_ context.Context, start int) ([]dto.ContentItem, error) {
+		starts = append(starts, start)
+		if start == 0 {
+			return make([]dto.ContentItem, arxivPageSize), nil
+		}
+		return make([]dto.ContentItem, 3), nil
+	})
+
+	if _, done, _ := next(context.Background()); done {
+		t.Error("full page reported as the last")
+	}
+	if items, done, _ := next(context.Background()); !done || len(items) != 3 {
+		t.Errorf("short page: %d items, done %v; want 3 and done", len(items), done)
+	}
+	if want := []int{0, arxivPageSize}; !reflect.DeepEqual(starts, want) {
+		t.Errorf("offsets = %v, want %v", starts, want)
+	}
+}
+
+func TestHNPager_WalksPastAlgoliaLimit(t *testing.T) {
+	type call struct {
+		to   time.Time
+		page int
+	}
+	var calls []call
+	next := hnPager(day(10), func(_ context.Context, to time.Time, page int) (*connectors.HNAlgoliaPage, error) {
+		calls = append(calls, call{to, page})
+		res := &connectors.HNAlgoliaPage{Items: []dto.ContentItem{{Title: "hit"}}, Page: page, NbPages: 2}
+		switch {
+		case to.Equal(day(10)):
+			res.Oldest = day(8) // the 1000-hit cap ends the first window here
+		case to.Equal(day(8)):
+			res.NbPages, res.Oldest = 1, day(8) // nothing older
+		}
+		return res, nil
+	})
+
+	var dones []bool
+	for i := 0; i < 3; i++ {
+		_, done, err := next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		dones = append(dones, done)
+	}
+	wantCalls := []call{{day(10), 0}, {day(10), 1}, {day(8), 0}}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", calls, wantCalls)
+	}
+	if want := []bool{false, false, true}; !reflect.DeepEqual(dones, want) {
+		t.Errorf("done = %v, want %v", dones, want)
+	}
+}
+
+func TestHNPager_Error(t *testing.T) {
+	next := hnPager(day(10), func(context.Context, time.Time, int) (*connectors.HNAlgoliaPage, error) {
+		return nil, errors.New("rate limited")
+	})
+	if _, done, err := next(context.Background()); err == nil || done {
+		t.Errorf("done %v, err %v; want an error", done, err)
+	}
+}
+
+func TestJSONAPIPager(t *testing.T) {
+	pages := map[int][]dto.ContentItem{
+		1: {{PublishedAt: day(5)}, {PublishedAt: day(1)}}, // straddles from
+		2: {{PublishedAt: day(1)}},                        // entirely older
+	}
+	var fetched []int
+	next := jsonAPIPager(day(2), 1, func(_ context.Context, page int) ([]dto.ContentItem, error) {
+		fetched = append(fetched, page)
+		return pages[page], nil
+	})
+
+	if _, done, _ := next(context.Background()); done {
+		t.Error("page reaching into the window reported as the last")
+	}
+	if _, done, _ := next(context.Background()); !done {
+		t.Error("page older than the window not reported as the last")
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("pages = %v, want %v", fetched, want)
+	}
+
+	empty := jsonAPIPager(day(2), 0, func(context.Context, int) ([]dto.ContentItem, error) { return nil, nil })
+	if _, done, _ := empty(context.Background()); !done {
+		t.Error("empty page not reported as the last")
+	}
+}
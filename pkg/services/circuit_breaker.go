@@ -0,0 +1,99 @@
+package services
+
+import (
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// CircuitBreaker decides whether a source may be fetched based on the
+// failure history persisted on its row. It holds no state of its own, so
+// every worker instance makes the same call.
+type CircuitBreaker struct {
+	FailureThreshold int           // consecutive failures before the circuit opens
+	BaseCooldown     time.Duration // first open period; doubles on every further failure
+	MaxCooldown      time.Duration
+	DisableAfter     time.Duration // auto-disable sources failing for this long (0 = never)
+}
+
+// NewCircuitBreaker builds a CircuitBreaker from the SOURCE_* settings.
+func NewCircuitBreaker(cfg *config.Config) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: cfg.SourceFailureThreshold,
+		BaseCooldown:     time.Duration(cfg.SourceCooldownMinutes) * time.Minute,
+		MaxCooldown:      time.Duration(cfg.SourceMaxCooldownHours) * time.Hour,
+		DisableAfter:     time.Duration(cfg.SourceDisableAfterDays) * 24 * time.Hour,
+	}
+}
+
+// Allow reports whether source may be fetched at now. An open circuit whose
+// cool-down has elapsed moves to half-open and lets a single probe through.
+func (b *CircuitBreaker) Allow(source *models.Source, now time.Time) bool {
+	switch source.CircuitState {
+	case CircuitOpen:
+		if source.NextAttemptAt != nil && now.Before(*source.NextAttemptAt) {
+			return false
+		}
+		source.CircuitState = CircuitHalfOpen
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the circuit.
+func (b *CircuitBreaker) RecordSuccess(source *models.Source, now time.Time) {
+	source.CircuitState = CircuitClosed
+	source.ConsecutiveFailures = 0
+	source.NextAttemptAt = nil
+	source.FailingSince = nil
+	source.LastSuccessAt = &now
+}
+
+// RecordFailure counts a failed fetch and opens the circuit once the
+// threshold is reached (or immediately when a half-open probe fails). It
+// reports whether the source has been failing long enough to be disabled.
+func (b *CircuitBreaker) RecordFailure(source *models.Source, now time.Time) (disable bool) {
+	source.ConsecutiveFailures++
+	if source.FailingSince == nil {
+		source.FailingSince = &now
+	}
+
+	threshold := b.FailureThreshold
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	if source.CircuitState == CircuitHalfOpen || source.ConsecutiveFailures >= threshold {
+		next := now.Add(b.cooldown(source.ConsecutiveFailures - threshold))
+		source.CircuitState = CircuitOpen
+		source.NextAttemptAt = &next
+	}
+
+	return b.DisableAfter > 0 && now.Sub(*source.FailingSince) >= b.DisableAfter
+}
+
+// cooldown returns BaseCooldown doubled n times, capped at MaxCooldown.
+func (b *CircuitBreaker) cooldown(n int) time.Duration {
+	d := b.BaseCooldown
+	if d <= 0 {
+		d = time.Minute
+	}
+	for i := 0; i < n; i++ {
+		d *= 2
+		if b.MaxCooldown > 0 && d >= b.MaxCooldown {
+			return b.MaxCooldown
+		}
+	}
+	if b.MaxCooldown > 0 && d > b.MaxCooldown {
+		return b.MaxCooldown
+	}
+	return d
+}
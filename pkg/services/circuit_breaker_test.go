@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestCircuitBreaker_Lifecycle(t *testing.T) {
+	b := &CircuitBreaker{
+		FailureThreshold: 2,
+		BaseCooldown:     10 * time.Minute,
+		MaxCooldown:      time.Hour,
+		DisableAfter:     48 * time.Hour,
+	}
+	src := &models.Source{CircuitState: CircuitClosed}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if b.RecordFailure(src, now); src.CircuitState != CircuitClosed {
+		t.Fatalf("circuit should stay closed below threshold, got %s", src.CircuitState)
+	}
+	b.RecordFailure(src, now)
+	if src.CircuitState != CircuitOpen || !src.NextAttemptAt.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("expected open circuit with 10m cool-down, got %s until %v", src.CircuitState, src.NextAttemptAt)
+	}
+
+	if b.Allow(src, now.Add(5*time.Minute)) {
+		t.Error("open circuit should reject before cool-down elapses")
+	}
+	if !b.Allow(src, now.Add(11*time.Minute)) || src.CircuitState != CircuitHalfOpen {
+		t.Fatalf("expected half-open probe after cool-down, got %s", src.CircuitState)
+	}
+
+	// Failed probe reopens with a doubled cool-down.
+	probe := now.Add(11 * time.Minute)
+	b.RecordFailure(src, probe)
+	if src.CircuitState != CircuitOpen || !src.NextAttemptAt.Equal(probe.Add(20*time.Minute)) {
+		t.Fatalf("expected reopened circuit with 20m cool-down, got %s until %v", src.CircuitState, src.NextAttemptAt)
+	}
+
+	// Cool-down is capped.
+	for i := 0; i < 5; i++ {
+		b.RecordFailure(src, probe)
+	}
+	if got := src.NextAttemptAt.Sub(probe); got != time.Hour {
+		t.Errorf("cool-down should cap at 1h, got %v", got)
+	}
+
+	if !b.RecordFailure(src, now.Add(49*time.Hour)) {
+		t.Error("source failing for longer than DisableAfter should be disabled")
+	}
+
+	b.RecordSuccess(src, now)
+	if src.CircuitState != CircuitClosed || src.ConsecutiveFailures != 0 || src.FailingSince != nil {
+		t.Errorf("success should reset the circuit, got %+v", src)
+	}
+}
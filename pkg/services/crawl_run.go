@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// ingestionRun tracks one RunIngestion pass: its progress and, unless the
+// caller records the run itself, a crawl run record. The record is created
+// when the first source is processed, so ticks where nothing is due leave no
+// history behind.
+type ingestionRun struct {
+	repo       *db.CrawlRunRepository
+	instanceID string
+	trigger    string
+	external   *uuid.UUID // crawl run owned by the caller
+	run        *models.CrawlRun
+	createErr  error
+	progress   *progressTracker
+	inserted   int
+}
+
+func newIngestionRun(database *db.DB, instanceID, trigger string, external *uuid.UUID) *ingestionRun {
+	return &ingestionRun{
+		repo:       db.NewCrawlRunRepository(database),
+		instanceID: instanceID,
+		trigger:    trigger,
+		external:   external,
+		progress:   newProgressTracker(nil, nil, nil),
+	}
+}
+
+// id returns the run ID for tagging fetch_runs, creating the record on first
+// use. It returns nil if the record could not be created.
+func (r *ingestionRun) id(ctx context.Context) *uuid.UUID {
+	if r.external != nil {
+		return r.external
+	}
+	if r.run == nil && r.createErr == nil {
+		run := &models.CrawlRun{TriggerType: r.trigger, InstanceID: &r.instanceID}
+		if err := r.repo.Create(ctx, run); err != nil {
+			log.Printf("Warning: Failed to record crawl run: %v", err)
+			r.createErr = err
+			return nil
+		}
+		r.run = run
+	}
+	if r.run == nil {
+		return nil
+	}
+	return &r.run.ID
+}
+
+// record adds the outcome of one source to the run.
+func (r *ingestionRun) record(source models.Source, started time.Time, counts itemCounts, err error) {
+	r.inserted += counts.Inserted
+	r.progress.sourceFinished(counts, err)
+	if r.run == nil {
+		return
+	}
+
+	entry := models.CrawlRunSource{
+		Name:       source.Name,
+		SourceID:   &source.ID,
+		StartedAt:  started,
+		DurationMs: time.Since(started).Milliseconds(),
+		Discovered: counts.Fetched,
+		Accepted:   counts.Accepted(),
+		Rejected:   counts.Rejected(),
+		Duplicates: counts.Duplicates,
+		Inserted:   counts.Inserted,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	r.run.Sources = append(r.run.Sources, entry)
+	r.run.Discovered += entry.Discovered
+	r.run.Accepted += entry.Accepted
+	r.run.Rejected += entry.Rejected
+	r.run.Duplicates += entry.Duplicates
+	r.run.Inserted += entry.Inserted
+}
+
+// finish reports the end of the pass and stores the final state of the
+// run, if one was started.
+func (r *ingestionRun) finish(ctx context.Context, runErr error) {
+	r.progress.finish(runErr)
+	if r.run == nil {
+		return
+	}
+
+	r.run.Status = "success"
+	if runErr != nil {
+		r.run.Status = "failed"
+		msg := runErr.Error()
+		r.run.Error = &msg
+	}
+	if err := r.repo.Finish(context.WithoutCancel(ctx), r.run); err != nil {
+		log.Printf("Warning: Failed to finish crawl run %s: %v", r.run.ID, err)
+	}
+}
@@ -2,26 +2,38 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
 	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 )
 
 type FeedService struct {
-	itemRepo    *db.ItemRepository
-	signalRepo  *db.SignalRepository
-	scoreRepo   *db.ScoreRepository
-	summaryRepo *db.SummaryRepository
+	cfg          *config.Config // rising baseline and anomaly settings
+	itemRepo     *db.ItemRepository
+	signalRepo   *db.SignalRepository
+	scoreRepo    *db.ScoreRepository
+	summaryRepo  *db.SummaryRepository
+	feedbackRepo *db.FeedbackRepository
+	entityRepo   *db.EntityRepository
+	configRepo   *db.ScoringConfigRepository
 }
 
 func NewFeedService(database *db.DB) *FeedService {
 	return &FeedService{
-		itemRepo:    db.NewItemRepository(database),
-		signalRepo:  db.NewSignalRepository(database),
-		scoreRepo:   db.NewScoreRepository(database),
-		summaryRepo: db.NewSummaryRepository(database),
+		cfg:          config.Load(),
+		itemRepo:     db.NewItemRepository(database),
+		signalRepo:   db.NewSignalRepository(database),
+		scoreRepo:    db.NewScoreRepository(database),
+		summaryRepo:  db.NewSummaryRepository(database),
+		feedbackRepo: db.NewFeedbackRepository(database),
+		entityRepo:   db.NewEntityRepository(database),
+		configRepo:   db.NewScoringConfigRepository(database),
 	}
 }
 
@@ -38,6 +50,7 @@ func (s *FeedService) BuildFeedResponse(ctx context.Context, items []models.Item
 			"title":        item.Title,
 			"url":          item.URL,
 			"domain":       item.Domain,
+			"lang":         item.Lang,
 			"published_at": item.PublishedAt.Format(time.RFC3339),
 			"scores": map[string]float64{
 				"final":       1.0,
@@ -66,12 +79,17 @@ func (s *FeedService) BuildFeedResponse(ctx context.Context, items []models.Item
 		}
 
 		if summary != nil {
-			itemResp["summary"] = map[string]interface{}{
+			summaryResp := map[string]interface{}{
 				"tldr":           summary.TLDR,
 				"why_it_matters": summary.WhyItMatters,
 				"tags":           summary.Tags,
+				"tag_scores":     summary.TagScores,
 				"method":         summary.Method,
 			}
+			if summary.Translation != nil {
+				summaryResp["translation"] = summary.Translation
+			}
+			itemResp["summary"] = summaryResp
 		}
 
 		responseItems = append(responseItems, itemResp)
@@ -103,10 +121,11 @@ func convertToScale10(score float64) float64 {
 	return float64(int(scaled*10)) / 10
 }
 
-func (s *FeedService) BuildRisingResponse(ctx context.Context, items []models.Item, window time.Duration) map[string]interface{} {
+func (s *FeedService) BuildRisingResponse(ctx context.Context, items []models.RisingItem, window time.Duration) map[string]interface{} {
 	responseItems := make([]map[string]interface{}, 0, len(items))
 
-	for rank, item := range items {
+	for rank, ri := range items {
+		item := ri.Item
 		signals, _ := s.signalRepo.GetRisingSignals(ctx, item.ID, window)
 
 		pointsDelta := 0
@@ -124,20 +143,29 @@ func (s *FeedService) BuildRisingResponse(ctx context.Context, items []models.It
 			}
 		}
 
-		risingScore := float64(pointsDelta*10 + commentsDelta*5)
-
 		itemResp := map[string]interface{}{
 			"id":           item.ID,
 			"rank":         rank + 1,
 			"title":        item.Title,
 			"url":          item.URL,
 			"domain":       item.Domain,
+			"lang":         item.Lang,
 			"published_at": item.PublishedAt.Format(time.RFC3339),
-			"rising_score": risingScore,
+			"rising_score": ri.RisingScore,
+			"anomalous":    ri.Anomalous,
 			"signals": map[string]int{
 				"points_delta":   pointsDelta,
 				"comments_delta": commentsDelta,
 			},
+			"velocity": map[string]interface{}{
+				"engagement":     ri.Velocity.Engagement,
+				"per_hour":       ri.Velocity.Velocity,
+				"acceleration":   ri.Velocity.Acceleration,
+				"samples":        ri.Velocity.Samples,
+				"z_velocity":     ri.ZVelocity,
+				"z_acceleration": ri.ZAcceleration,
+				"baseline_size":  ri.BaselineSize,
+			},
 		}
 
 		responseItems = append(responseItems, itemResp)
@@ -149,14 +177,79 @@ func (s *FeedService) BuildRisingResponse(ctx context.Context, items []models.It
 	}
 }
 
-// GetTopDaily retrieves top daily items
-func (s *FeedService) GetTopDaily(ctx context.Context, date time.Time, topic *string, limit int) ([]models.Item, error) {
-	return s.itemRepo.GetTopDaily(ctx, date, topic, limit)
+// AddAlsoCoveredBy lists, under "also_covered_by" on each response item, the
+// other items of its near-duplicate story. response must come from
+// BuildFeedResponse or BuildRisingResponse for the same items.
+func (s *FeedService) AddAlsoCoveredBy(ctx context.Context, response map[string]interface{}, items []models.Item) error {
+	responseItems, ok := response["items"].([]map[string]interface{})
+	if !ok || len(responseItems) != len(items) {
+		return nil
+	}
+
+	storyIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		storyIDs[i] = storyID(item)
+	}
+
+	members, err := s.itemRepo.GetStoryMembers(ctx, storyIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		covered := make([]map[string]interface{}, 0)
+		for _, other := range members[storyIDs[i]] {
+			if other.ID == item.ID {
+				continue
+			}
+			covered = append(covered, map[string]interface{}{
+				"id":           other.ID,
+				"title":        other.Title,
+				"url":          other.URL,
+				"domain":       other.Domain,
+				"published_at": other.PublishedAt.Format(time.RFC3339),
+			})
+		}
+		responseItems[i]["also_covered_by"] = covered
+	}
+	return nil
+}
+
+// storyID is the key shared by an item and its near-duplicates.
+func storyID(item models.Item) uuid.UUID {
+	if item.DuplicateOf != nil {
+		return *item.DuplicateOf
+	}
+	return item.ID
 }
 
-// GetRising retrieves rising items
-func (s *FeedService) GetRising(ctx context.Context, window time.Duration, limit int) ([]models.Item, error) {
-	return s.itemRepo.GetRising(ctx, window, limit)
+// GetTopDaily retrieves top daily items. A topic filter also matches its
+// aliases and sub-topics; with a positive minConfidence the tag must have
+// been assigned with at least that confidence.
+func (s *FeedService) GetTopDaily(ctx context.Context, date time.Time, topic *string, minConfidence float64, lang *string, limit int) ([]models.Item, error) {
+	return s.itemRepo.GetTopDaily(ctx, date, topicTags(topic), minConfidence, lang, limit)
+}
+
+// topicTags expands a topic filter into every summary tag it accepts.
+func topicTags(topic *string) []string {
+	if topic == nil {
+		return nil
+	}
+	return taxonomy.Current().Expand(*topic)
+}
+
+// GetRising retrieves items whose velocity is highest relative to their
+// source's baseline and flags statistically anomalous risers.
+func (s *FeedService) GetRising(ctx context.Context, window time.Duration, lang *string, limit int) ([]models.RisingItem, error) {
+	items, err := s.itemRepo.GetRising(ctx, window, s.cfg.RisingBaseline(), s.cfg.RisingMinBaseline, lang, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Anomalous = items[i].BaselineSize >= s.cfg.RisingMinBaseline &&
+			items[i].ZVelocity >= s.cfg.RisingZThreshold
+	}
+	return items, nil
 }
 
 // GetItemByID retrieves an item by ID
@@ -164,9 +257,9 @@ func (s *FeedService) GetItemByID(ctx context.Context, id uuid.UUID) (*models.It
 	return s.itemRepo.GetByID(ctx, id)
 }
 
-// SearchItems searches for items
-func (s *FeedService) SearchItems(ctx context.Context, query string, topic *string, limit, offset int) ([]models.Item, int, error) {
-	return s.itemRepo.Search(ctx, query, topic, limit, offset)
+// SearchItems searches for items. Topic filters work as in GetTopDaily.
+func (s *FeedService) SearchItems(ctx context.Context, query string, topic *string, minConfidence float64, lang *string, limit, offset int) ([]models.Item, int, error) {
+	return s.itemRepo.Search(ctx, query, topicTags(topic), minConfidence, lang, limit, offset)
 }
 
 // GetItemWithDetails retrieves an item with all related data (signals, scores, summary)
@@ -182,3 +275,170 @@ func (s *FeedService) GetItemWithDetails(ctx context.Context, itemID uuid.UUID)
 
 	return item, signal, score, summary, nil
 }
+
+// ExplainScore returns an item's score with its explanation. Scores stored
+// before explanations were recorded are explained by recomputing them with
+// the active scoring config, reported by recomputed. The score is nil when
+// the item has not been scored yet.
+func (s *FeedService) ExplainScore(ctx context.Context, itemID uuid.UUID) (item *models.Item, score *models.Score, recomputed bool, err error) {
+	item, signal, score, summary, err := s.GetItemWithDetails(ctx, itemID)
+	if err != nil || score == nil || score.Explanation != nil {
+		return item, score, false, err
+	}
+
+	coverage, _ := s.itemRepo.GetCoverage(ctx, itemID)
+	fresh := scoring.ComputeScoreWithConfig(item, signal, summary, score, coverage, scoring.Current())
+	score.Explanation = fresh.Explanation
+	return item, score, true, nil
+}
+
+// GetCorroboration rates how independently the item's story is covered and
+// lists the other items covering it.
+func (s *FeedService) GetCorroboration(ctx context.Context, itemID uuid.UUID) (models.Corroboration, []models.CoverageItem, error) {
+	coverage, err := s.itemRepo.GetCoverage(ctx, itemID)
+	if err != nil {
+		return models.Corroboration{}, nil, err
+	}
+	others := make([]models.CoverageItem, 0, len(coverage))
+	for _, ci := range coverage {
+		if ci.Via != "self" {
+			others = append(others, ci)
+		}
+	}
+	return scoring.ComputeCorroboration(coverage), others, nil
+}
+
+// ScoreHistory returns the item's latest limit score recomputations, oldest
+// first.
+func (s *FeedService) ScoreHistory(ctx context.Context, itemID uuid.UUID, limit int) ([]models.ScoreHistory, error) {
+	return s.scoreRepo.GetHistory(ctx, itemID, limit)
+}
+
+// compareMaxItems bounds how many of a day's items CompareRankings rescores.
+const compareMaxItems = 2000
+
+// CompareRankings compares how the items published on the UTC day of day
+// rank under scoring config versions a and b; an empty b means the active
+// config. When both configs are known, every item is scored under each with
+// the same inputs. Otherwise each item is ranked by the last score recorded
+// for it under each version, which may come from different days.
+func (s *FeedService) CompareRankings(ctx context.Context, day time.Time, a, b string, topN, limit int) (*models.RankingComparison, error) {
+	if b == "" {
+		b = scoring.Current().Version
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	cfgA, err := s.scoringConfig(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	cfgB, err := s.scoringConfig(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var scoresA, scoresB []models.ScoreHistory
+	method := "rescored"
+	if cfgA != nil && cfgB != nil {
+		items, err := s.itemRepo.ListPublished(ctx, day, day.Add(24*time.Hour), compareMaxItems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load items: %w", err)
+		}
+		scoresA, scoresB = s.rescore(ctx, items, cfgA, cfgB)
+	} else {
+		method = "history"
+		if scoresA, err = s.scoreRepo.ListForDay(ctx, a, day); err != nil {
+			return nil, fmt.Errorf("failed to load scores for version %s: %w", a, err)
+		}
+		if scoresB, err = s.scoreRepo.ListForDay(ctx, b, day); err != nil {
+			return nil, fmt.Errorf("failed to load scores for version %s: %w", b, err)
+		}
+	}
+
+	cmp := scoring.CompareRankings(scoresA, scoresB, topN, limit)
+	cmp.Date = day.Format("2006-01-02")
+	cmp.VersionA, cmp.VersionB = a, b
+	cmp.Method = method
+	return &cmp, nil
+}
+
+// scoringConfig returns the scoring config with the given version, or nil
+// if no snapshot of it was stored.
+func (s *FeedService) scoringConfig(ctx context.Context, version string) (*scoring.Config, error) {
+	if cur := scoring.Current(); cur.Version == version {
+		return cur, nil
+	}
+	snapshot, err := s.configRepo.Get(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scoring config %s: %w", version, err)
+	}
+	if snapshot == "" {
+		return nil, nil
+	}
+	cfg, err := scoring.Parse([]byte(snapshot))
+	if err != nil {
+		return nil, fmt.Errorf("stored scoring config %s: %w", version, err)
+	}
+	return cfg, nil
+}
+
+// rescore scores items under cfgA and cfgB from the same inputs.
+func (s *FeedService) rescore(ctx context.Context, items []models.Item, cfgA, cfgB *scoring.Config) (a, b []models.ScoreHistory) {
+	for i := range items {
+		item := &items[i]
+		signal, _ := s.signalRepo.GetLatestByItemID(ctx, item.ID)
+		summary, _ := s.summaryRepo.GetByItemID(ctx, item.ID)
+		existingScore, _ := s.scoreRepo.GetByItemID(ctx, item.ID)
+		coverage, _ := s.itemRepo.GetCoverage(ctx, item.ID)
+
+		scoreA := scoring.ComputeScoreWithConfig(item, signal, summary, existingScore, coverage, cfgA)
+		scoreB := scoring.ComputeScoreWithConfig(item, signal, summary, existingScore, coverage, cfgB)
+		a = append(a, historyEntry(item, scoreA))
+		b = append(b, historyEntry(item, scoreB))
+	}
+	return a, b
+}
+
+func historyEntry(item *models.Item, score *models.Score) models.ScoreHistory {
+	return models.ScoreHistory{
+		ItemID:           item.ID,
+		Title:            item.Title,
+		Hot:              score.Hot,
+		Relevance:        score.Relevance,
+		Credibility:      score.Credibility,
+		Novelty:          score.Novelty,
+		Impact:           score.Impact,
+		EngineeringValue: score.EngineeringValue,
+		Final:            score.Final,
+		AlgorithmVersion: score.AlgorithmVersion,
+		ConfigVersion:    score.ConfigVersion,
+		ComputedAt:       score.ComputedAt,
+	}
+}
+
+// RecordFeedback stores a team vote on an item. Votes feed the item's domain
+// and source reputation.
+func (s *FeedService) RecordFeedback(ctx context.Context, fb *models.ItemFeedback) error {
+	return s.feedbackRepo.Create(ctx, fb)
+}
+
+// ListEntities returns the known entities, optionally of one kind.
+func (s *FeedService) ListEntities(ctx context.Context, kind *string) ([]models.Entity, error) {
+	return s.entityRepo.List(ctx, kind)
+}
+
+// GetEntity returns the entity with the given ID or slug, or nil.
+func (s *FeedService) GetEntity(ctx context.Context, idOrSlug string) (*models.Entity, error) {
+	return s.entityRepo.Get(ctx, idOrSlug)
+}
+
+// EntityTimeline returns the latest limit items mentioning an entity,
+// newest first.
+func (s *FeedService) EntityTimeline(ctx context.Context, entityID uuid.UUID, limit int) ([]models.EntityTimelineItem, error) {
+	return s.entityRepo.Timeline(ctx, entityID, limit)
+}
+
+// ItemEntities returns the entities an item mentions.
+func (s *FeedService) ItemEntities(ctx context.Context, itemID uuid.UUID) ([]models.EntityMention, error) {
+	return s.entityRepo.ListForItem(ctx, itemID)
+}
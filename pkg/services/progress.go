@@ -0,0 +1,213 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// Stages reported through IngestionProgress.
+const (
+	StageStarted        = "started"
+	StageSourceStarted  = "source_started"
+	StageSourceFetched  = "source_fetched"
+	StageEnriching      = "enriching"
+	StageSourceFinished = "source_finished"
+	StageFinished       = "finished"
+)
+
+const (
+	// defaultSourceEstimate is assumed for sources without timing history.
+	defaultSourceEstimate = 10 * time.Second
+	// durationHistoryRuns is how many past crawl runs feed the estimates.
+	durationHistoryRuns = 20
+	// enrichReportEvery throttles enrichment events to one per this many items.
+	enrichReportEvery = 50
+)
+
+// IngestionProgress describes one step of a RunIngestion pass.
+type IngestionProgress struct {
+	Stage          string
+	Source         string
+	SourceID       uuid.UUID
+	SourceIndex    int // 1-based position of Source among the sources started so far
+	TotalSources   int // sources due when the pass began
+	ItemsFetched   int // for Source
+	ItemsInserted  int // for Source
+	ItemsRejected  int // for Source: invalid items and duplicates
+	ItemsDuplicate int // for Source: already stored, included in ItemsRejected
+	TotalFetched   int
+	TotalInserted  int
+	Enriched       int // items scored so far for Source
+	ToEnrich       int
+	Duration       time.Duration // time spent on Source, set on StageSourceFinished
+	Percent        int           // 0-100, weighted by expected source durations
+	ETA            time.Duration
+	Err            error
+}
+
+// ProgressFunc receives ingestion progress. It is called synchronously from
+// the ingestion loop and must not block.
+type ProgressFunc func(IngestionProgress)
+
+// IngestionOptions tunes a RunIngestionWithOptions pass.
+type IngestionOptions struct {
+	Progress ProgressFunc
+	// CrawlRunID tags fetch_runs with a crawl run recorded by the caller
+	// instead of recording a separate worker run.
+	CrawlRunID *uuid.UUID
+}
+
+// progressTracker turns ingestion milestones into IngestionProgress events
+// with a percentage and ETA weighted by each source's historical duration.
+type progressTracker struct {
+	fn       ProgressFunc
+	history  map[uuid.UUID]time.Duration
+	fallback time.Duration
+	now      func() time.Time
+
+	expected    map[uuid.UUID]time.Duration // sources not yet finished
+	totalWeight time.Duration
+	doneWeight  time.Duration
+	total       int
+	started     int
+
+	current      IngestionProgress
+	currentStart time.Time
+	totals       IngestionProgress
+}
+
+func newProgressTracker(fn ProgressFunc, due []models.Source, history map[uuid.UUID]time.Duration) *progressTracker {
+	t := &progressTracker{
+		fn:       fn,
+		history:  history,
+		fallback: defaultSourceEstimate,
+		now:      time.Now,
+		expected: make(map[uuid.UUID]time.Duration, len(due)),
+	}
+
+	if len(history) > 0 {
+		var sum time.Duration
+		for _, d := range history {
+			sum += d
+		}
+		t.fallback = sum / time.Duration(len(history))
+	}
+
+	for _, s := range due {
+		t.expect(s.ID)
+	}
+	t.total = len(due)
+	return t
+}
+
+func (t *progressTracker) expect(id uuid.UUID) time.Duration {
+	if d, ok := t.expected[id]; ok {
+		return d
+	}
+	d, ok := t.history[id]
+	if !ok || d <= 0 {
+		d = t.fallback
+	}
+	t.expected[id] = d
+	t.totalWeight += d
+	return d
+}
+
+// position returns the completion percentage and remaining-time estimate.
+func (t *progressTracker) position() (int, time.Duration) {
+	var remaining, inFlight time.Duration
+	for id, d := range t.expected {
+		if id == t.current.SourceID && !t.currentStart.IsZero() {
+			elapsed := t.now().Sub(t.currentStart)
+			if elapsed > d {
+				elapsed = d
+			}
+			inFlight = elapsed
+			remaining += d - elapsed
+			continue
+		}
+		remaining += d
+	}
+
+	if t.totalWeight == 0 {
+		return 100, 0
+	}
+	// Reserve 5% for setup and 5% for wrap-up.
+	pct := 5 + int(90*(t.doneWeight+inFlight)/t.totalWeight)
+	return pct, remaining
+}
+
+func (t *progressTracker) emit(p IngestionProgress) {
+	if t.fn == nil {
+		return
+	}
+	p.TotalSources = t.total
+	p.TotalFetched = t.totals.TotalFetched
+	p.TotalInserted = t.totals.TotalInserted
+	p.Percent, p.ETA = t.position()
+	if p.Stage == StageFinished {
+		p.Percent, p.ETA = 100, 0
+	}
+	t.fn(p)
+}
+
+func (t *progressTracker) begin() {
+	t.emit(IngestionProgress{Stage: StageStarted})
+}
+
+func (t *progressTracker) sourceStarted(source models.Source) {
+	if _, ok := t.expected[source.ID]; !ok {
+		// Became due after the pass started.
+		t.expect(source.ID)
+		t.total++
+	}
+	t.started++
+	t.current = IngestionProgress{Source: source.Name, SourceID: source.ID, SourceIndex: t.started}
+	t.currentStart = t.now()
+
+	p := t.current
+	p.Stage = StageSourceStarted
+	t.emit(p)
+}
+
+func (t *progressTracker) sourceFetched(fetched int) {
+	t.current.ItemsFetched = fetched
+	t.totals.TotalFetched += fetched
+
+	p := t.current
+	p.Stage = StageSourceFetched
+	t.emit(p)
+}
+
+func (t *progressTracker) enriching(done, total int) {
+	if done != total && done%enrichReportEvery != 0 {
+		return
+	}
+	p := t.current
+	p.Stage = StageEnriching
+	p.Enriched, p.ToEnrich = done, total
+	t.emit(p)
+}
+
+func (t *progressTracker) sourceFinished(counts itemCounts, err error) {
+	t.current.ItemsInserted = counts.Inserted
+	t.current.ItemsRejected = counts.Rejected()
+	t.current.ItemsDuplicate = counts.Duplicates
+	t.totals.TotalInserted += counts.Inserted
+
+	p := t.current
+	p.Stage = StageSourceFinished
+	p.Duration = t.now().Sub(t.currentStart)
+	p.Err = err
+
+	t.doneWeight += t.expected[t.current.SourceID]
+	delete(t.expected, t.current.SourceID)
+	t.currentStart = time.Time{}
+	t.emit(p)
+}
+
+func (t *progressTracker) finish(err error) {
+	t.emit(IngestionProgress{Stage: StageFinished, Err: err})
+}
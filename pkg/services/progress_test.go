@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestProgressTrackerWeightsByHistory(t *testing.T) {
+	slow := models.Source{ID: uuid.New(), Name: "slow"}
+	fast := models.Source{ID: uuid.New(), Name: "fast"}
+	history := map[uuid.UUID]time.Duration{
+		slow.ID: 30 * time.Second,
+		fast.ID: 10 * time.Second,
+	}
+
+	var events []IngestionProgress
+	clock := time.Unix(0, 0)
+	tr := newProgressTracker(func(p IngestionProgress) { events = append(events, p) }, []models.Source{slow, fast}, history)
+	tr.now = func() time.Time { return clock }
+
+	tr.begin()
+	if got := events[0]; got.Percent != 5 || got.ETA != 40*time.Second || got.TotalSources != 2 {
+		t.Fatalf("begin = %d%% eta %v total %d, want 5%% eta 40s total 2", got.Percent, got.ETA, got.TotalSources)
+	}
+
+	tr.sourceStarted(slow)
+	clock = clock.Add(15 * time.Second)
+	tr.sourceFetched(12)
+	if got := events[len(events)-1]; got.ETA != 25*time.Second || got.ItemsFetched != 12 {
+		t.Errorf("mid-source eta = %v fetched %d, want 25s and 12", got.ETA, got.ItemsFetched)
+	}
+
+	clock = clock.Add(20 * time.Second)
+	tr.sourceFinished(itemCounts{Fetched: 12, Inserted: 4}, nil)
+	got := events[len(events)-1]
+	if got.Percent != 5+90*30/40 || got.ETA != 10*time.Second || got.Duration != 35*time.Second {
+		t.Errorf("after slow = %d%% eta %v took %v, want %d%% eta 10s took 35s", got.Percent, got.ETA, got.Duration, 5+90*30/40)
+	}
+
+	tr.sourceStarted(fast)
+	tr.sourceFinished(itemCounts{}, errors.New("boom"))
+	tr.finish(nil)
+
+	last := events[len(events)-1]
+	if last.Stage != StageFinished || last.Percent != 100 || last.TotalInserted != 4 || last.TotalFetched != 12 {
+		t.Errorf("finish = %+v", last)
+	}
+}
+
+func TestProgressTrackerUnknownSourceUsesFallback(t *testing.T) {
+	known := models.Source{ID: uuid.New()}
+	late := models.Source{ID: uuid.New(), Name: "late"}
+
+	var last IngestionProgress
+	tr := newProgressTracker(func(p IngestionProgress) { last = p }, []models.Source{known},
+		map[uuid.UUID]time.Duration{known.ID: 20 * time.Second, uuid.New(): 40 * time.Second})
+	tr.now = func() time.Time { return time.Unix(0, 0) }
+
+	tr.sourceStarted(late)
+	if last.TotalSources != 2 || last.ETA != 50*time.Second {
+		t.Errorf("late source: total %d eta %v, want 2 and 50s", last.TotalSources, last.ETA)
+	}
+}
+
+func TestProgressTrackerEnrichThrottled(t *testing.T) {
+	n := 0
+	tr := newProgressTracker(func(IngestionProgress) { n++ }, nil, nil)
+	for i := 1; i <= 120; i++ {
+		tr.enriching(i, 120)
+	}
+	if n != 3 { // 50, 100, 120
+		t.Errorf("enrichment events = %d, want 3", n)
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	adaptiveMinInterval     = 5 * time.Minute
+	adaptiveMaxInterval     = 24 * time.Hour
+	adaptiveDefaultInterval = time.Hour
+	adaptiveSampleRuns      = 6 // recent fetch runs considered
+	adaptiveTargetPerPoll   = 5 // new items per poll we aim for
+)
+
+// ValidateSchedule checks a source schedule. Empty means "every tick".
+func ValidateSchedule(schedule string) error {
+	if schedule == "" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	return nil
+}
+
+// SourceScheduler plans which sources are due on a worker tick and when they
+// should be polled next.
+type SourceScheduler struct{}
+
+// Due reports whether source should be polled at now.
+func (SourceScheduler) Due(source *models.Source, now time.Time) bool {
+	if source.NextPollAt == nil {
+		return true
+	}
+	return !now.Before(*source.NextPollAt)
+}
+
+// Plan records a poll at now and computes the next one. recentInserted holds
+// items_inserted of the latest fetch runs (newest first) and only matters for
+// adaptive sources.
+func (SourceScheduler) Plan(source *models.Source, now time.Time, recentInserted []int) {
+	source.LastPolledAt = &now
+
+	if source.Adaptive {
+		interval := adaptInterval(baseInterval(source, now), recentInserted)
+		secs := int(interval / time.Second)
+		next := now.Add(interval)
+		source.PollIntervalSeconds = &secs
+		source.NextPollAt = &next
+		return
+	}
+
+	source.PollIntervalSeconds = nil
+	if source.Schedule == "" {
+		source.NextPollAt = nil
+		return
+	}
+	sched, err := cron.ParseStandard(source.Schedule)
+	if err != nil {
+		source.NextPollAt = nil // Invalid schedules fall back to every tick
+		return
+	}
+	next := sched.Next(now)
+	source.NextPollAt = &next
+}
+
+// baseInterval is the adaptive starting point: the current interval, or the
+// gap implied by the source's schedule.
+func baseInterval(source *models.Source, now time.Time) time.Duration {
+	if source.PollIntervalSeconds != nil && *source.PollIntervalSeconds > 0 {
+		return time.Duration(*source.PollIntervalSeconds) * time.Second
+	}
+	if source.Schedule != "" {
+		if sched, err := cron.ParseStandard(source.Schedule); err == nil {
+			next := sched.Next(now)
+			return sched.Next(next).Sub(next)
+		}
+	}
+	return adaptiveDefaultInterval
+}
+
+// adaptInterval widens the interval for feeds that keep coming back empty and
+// narrows it for feeds that publish more than adaptiveTargetPerPoll per poll.
+func adaptInterval(current time.Duration, recentInserted []int) time.Duration {
+	if len(recentInserted) > adaptiveSampleRuns {
+		recentInserted = recentInserted[:adaptiveSampleRuns]
+	}
+
+	if len(recentInserted) > 0 {
+		total := 0
+		for _, n := range recentInserted {
+			total += n
+		}
+		avg := float64(total) / float64(len(recentInserted))
+
+		switch {
+		case avg < 0.5:
+			current = current * 3 / 2
+		case avg > adaptiveTargetPerPoll:
+			// Scale towards the target rate, but never more than halve at once.
+			factor := adaptiveTargetPerPoll / avg
+			if factor < 0.5 {
+				factor = 0.5
+			}
+			current = time.Duration(float64(current) * factor)
+		}
+	}
+
+	if current < adaptiveMinInterval {
+		return adaptiveMinInterval
+	}
+	if current > adaptiveMaxInterval {
+		return adaptiveMaxInterval
+	}
+	return current
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestAdaptInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		current time.Duration
+		recent  []int
+		want    time.Duration
+	}{
+		{name: "no history keeps interval", current: time.Hour, want: time.Hour},
+		{name: "quiet feed widens", current: time.Hour, recent: []int{0, 0, 1, 0}, want: 90 * time.Minute},
+		{name: "steady feed unchanged", current: time.Hour, recent: []int{3, 4, 2}, want: time.Hour},
+		{name: "busy feed narrows towards target", current: time.Hour, recent: []int{8, 8}, want: time.Duration(float64(time.Hour) * 5 / 8)},
+		{name: "narrowing is at most half", current: time.Hour, recent: []int{50}, want: 30 * time.Minute},
+		{name: "clamped to minimum", current: 6 * time.Minute, recent: []int{50}, want: adaptiveMinInterval},
+		{name: "clamped to maximum", current: 20 * time.Hour, recent: []int{0}, want: adaptiveMaxInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adaptInterval(tt.current, tt.recent); got != tt.want {
+				t.Errorf("adaptInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceScheduler_Plan(t *testing.T) {
+	var sched SourceScheduler
+	now := time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC)
+
+	daily := &models.Source{Schedule: "0 6 * * *"}
+	sched.Plan(daily, now, nil)
+	if want := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC); !daily.NextPollAt.Equal(want) {
+		t.Errorf("cron schedule next poll = %v, want %v", daily.NextPollAt, want)
+	}
+	if sched.Due(daily, now.Add(time.Hour)) {
+		t.Error("source should not be due before its next poll")
+	}
+
+	every := &models.Source{Schedule: "@every 30m", Adaptive: true}
+	sched.Plan(every, now, []int{0, 0})
+	if every.PollIntervalSeconds == nil || *every.PollIntervalSeconds != 45*60 {
+		t.Errorf("adaptive interval = %v, want 45m", every.PollIntervalSeconds)
+	}
+
+	unscheduled := &models.Source{}
+	sched.Plan(unscheduled, now, nil)
+	if !sched.Due(unscheduled, now) {
+		t.Error("sources without a schedule should be due every tick")
+	}
+}
@@ -17,14 +17,16 @@ import (
 )
 
 type SourceService struct {
-	db         *db.DB
-	sourceRepo *db.SourceRepository
+	db             *db.DB
+	sourceRepo     *db.SourceRepository
+	reputationRepo *db.ReputationRepository
 }
 
 func NewSourceService(database *db.DB) *SourceService {
 	return &SourceService{
-		db:         database,
-		sourceRepo: db.NewSourceRepository(database),
+		db:             database,
+		sourceRepo:     db.NewSourceRepository(database),
+		reputationRepo: db.NewReputationRepository(database),
 	}
 }
 
@@ -121,22 +123,43 @@ func (s *SourceService) SetEnabled(ctx context.Context, id uuid.UUID, enabled bo
 	status := "pending"
 	if enabled {
 		status = "active"
+		// Re-enabling is an explicit operator decision; give the source a clean slate.
+		if err := s.sourceRepo.ResetCircuit(ctx, id); err != nil {
+			return err
+		}
 	}
 	return s.sourceRepo.SetEnabled(ctx, id, enabled, status)
 }
 
+// SetSchedule validates and stores a source's polling schedule
+func (s *SourceService) SetSchedule(ctx context.Context, id uuid.UUID, schedule string, adaptive bool) error {
+	if err := ValidateSchedule(schedule); err != nil {
+		return err
+	}
+	return s.sourceRepo.SetSchedule(ctx, id, schedule, adaptive)
+}
+
 // ListSources returns all sources
 func (s *SourceService) ListSources(ctx context.Context) ([]models.Source, error) {
 	return s.sourceRepo.List(ctx)
 }
 
 // GetSourceByID returns a source by ID
+// ListReputations returns the learned reputations of kind
+// (models.ReputationDomain or models.ReputationSource), best first.
+func (s *SourceService) ListReputations(ctx context.Context, kind string) ([]models.Reputation, error) {
+	return s.reputationRepo.List(ctx, kind)
+}
+
 func (s *SourceService) GetSourceByID(ctx context.Context, id uuid.UUID) (*models.Source, error) {
 	return s.sourceRepo.GetByID(ctx, id)
 }
 
 // CreateSource creates a new source
 func (s *SourceService) CreateSource(ctx context.Context, source *models.Source) error {
+	if err := ValidateSchedule(source.Schedule); err != nil {
+		return err
+	}
 	return s.sourceRepo.Create(ctx, source)
 }
 
@@ -3,17 +3,22 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/connectors"
 	"github.com/hidatara-ds/evolipia-radar/pkg/db"
 	"github.com/hidatara-ds/evolipia-radar/pkg/dto"
+	"github.com/hidatara-ds/evolipia-radar/pkg/entities"
+	"github.com/hidatara-ds/evolipia-radar/pkg/ingest"
+	"github.com/hidatara-ds/evolipia-radar/pkg/jobs"
 	"github.com/hidatara-ds/evolipia-radar/pkg/models"
-	"github.com/hidatara-ds/evolipia-radar/pkg/normalizer"
 	"github.com/hidatara-ds/evolipia-radar/pkg/scoring"
-	"github.com/hidatara-ds/evolipia-radar/pkg/summarizer"
+	"github.com/hidatara-ds/evolipia-radar/pkg/tagging"
 )
 
 type Worker struct {
@@ -25,8 +30,18 @@ type Worker struct {
 	scoreRepo    *db.ScoreRepository
 	summaryRepo  *db.SummaryRepository
 	fetchRunRepo *db.FetchRunRepository
+	breaker      *CircuitBreaker
+	ingester     *ingest.Ingester
+	queue        *jobs.Queue
+	scheduler    SourceScheduler
+	instanceID   string // lease owner identity
 }
 
+const (
+	sourceLeaseTTL   = 10 * time.Minute
+	sourceLeaseBatch = 5
+)
+
 func NewWorker(database *db.DB, cfg *config.Config) *Worker {
 	return &Worker{
 		db:           database,
@@ -37,13 +52,24 @@ func NewWorker(database *db.DB, cfg *config.Config) *Worker {
 		scoreRepo:    db.NewScoreRepository(database),
 		summaryRepo:  db.NewSummaryRepository(database),
 		fetchRunRepo: db.NewFetchRunRepository(database),
+		breaker:      NewCircuitBreaker(cfg),
+		ingester:     ingest.NewIngester(database, cfg, entities.NewExtractor(database, cfg, nil)),
+		queue:        jobs.NewQueue(database),
+		instanceID:   db.InstanceID(),
 	}
 }
 
 func (w *Worker) RunIngestion(ctx context.Context) error {
+	_, err := w.RunIngestionWithOptions(ctx, IngestionOptions{})
+	return err
+}
+
+// RunIngestionWithOptions fetches every due source once, reporting progress
+// through opts.Progress. It returns the number of items inserted.
+func (w *Worker) RunIngestionWithOptions(ctx context.Context, opts IngestionOptions) (int, error) {
 	sources, err := w.sourceRepo.GetEnabled(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get enabled sources: %w", err)
+		return 0, fmt.Errorf("failed to get enabled sources: %w", err)
 	}
 
 	if len(sources) == 0 {
@@ -53,7 +79,7 @@ func (w *Worker) RunIngestion(ctx context.Context) error {
 		} else {
 			sources, err = w.sourceRepo.GetEnabled(ctx)
 			if err != nil {
-				return fmt.Errorf("failed to get enabled sources after creating default: %w", err)
+				return 0, fmt.Errorf("failed to get enabled sources after creating default: %w", err)
 			}
 		}
 	}
@@ -62,20 +88,98 @@ func (w *Worker) RunIngestion(ctx context.Context) error {
 
 	if len(sources) == 0 {
 		log.Println("No enabled sources to process")
-		return nil
+		return 0, nil
 	}
 
-	for _, source := range sources {
+	if reclaimed, err := w.sourceRepo.ReclaimExpiredLeases(ctx); err != nil {
+		log.Printf("Warning: Failed to reclaim expired source leases: %v", err)
+	} else if len(reclaimed) > 0 {
+		log.Printf("Reclaimed expired leases for %d sources: %v", len(reclaimed), reclaimed)
+	}
+
+	// Claim due sources in small batches so other replicas can pick up the
+	// rest in parallel instead of every replica fetching everything.
+	runStart := time.Now()
+	run := newIngestionRun(w.db, w.instanceID, "worker", opts.CrawlRunID)
+	run.progress = newProgressTracker(opts.Progress, w.dueSources(sources, runStart), w.sourceDurations(ctx))
+	run.progress.begin()
+
+	processed := 0
+	for {
 		if ctx.Err() != nil {
 			log.Printf("Context canceled, stopping ingestion")
-			return ctx.Err()
+			run.finish(ctx, ctx.Err())
+			return run.inserted, ctx.Err()
+		}
+
+		leased, err := w.sourceRepo.LeaseDue(ctx, w.instanceID, sourceLeaseTTL, runStart, sourceLeaseBatch)
+		if err != nil {
+			err = fmt.Errorf("failed to lease due sources: %w", err)
+			run.finish(ctx, err)
+			return run.inserted, err
+		}
+		if len(leased) == 0 {
+			break
 		}
-		if err := w.processSource(ctx, source); err != nil {
-			log.Printf("Error processing source %s: %v", source.Name, err)
+
+		for _, source := range leased {
+			w.runLeased(ctx, source, run)
+			processed++
 		}
 	}
 
-	return nil
+	run.finish(ctx, nil)
+	log.Printf("Processed %d due sources (instance %s)", processed, w.instanceID)
+	return run.inserted, nil
+}
+
+// dueSources returns the sources expected to be fetched in a pass starting
+// at now, for progress estimates.
+func (w *Worker) dueSources(sources []models.Source, now time.Time) []models.Source {
+	var due []models.Source
+	for _, s := range sources {
+		probe := s // Allow may move the copy to half-open
+		if s.Type != "agent" && w.scheduler.Due(&probe, now) && w.breaker.Allow(&probe, now) {
+			due = append(due, s)
+		}
+	}
+	return due
+}
+
+// sourceDurations loads historical per-source durations for the ETA.
+func (w *Worker) sourceDurations(ctx context.Context) map[uuid.UUID]time.Duration {
+	durations, err := db.NewCrawlRunRepository(w.db).AverageSourceDurations(ctx, durationHistoryRuns)
+	if err != nil {
+		log.Printf("Warning: Failed to load source duration history: %v", err)
+		return nil
+	}
+	return durations
+}
+
+// runLeased processes a source this instance holds the lease for and
+// releases the lease afterwards.
+func (w *Worker) runLeased(ctx context.Context, source models.Source, run *ingestionRun) {
+	defer func() {
+		if err := w.sourceRepo.ReleaseLease(context.WithoutCancel(ctx), source.ID, w.instanceID); err != nil {
+			log.Printf("Warning: Failed to release lease on %s: %v", source.Name, err)
+		}
+	}()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := time.Now()
+	if !w.scheduler.Due(&source, now) {
+		return
+	}
+	if !w.breaker.Allow(&source, now) {
+		log.Printf("Skipping source %s: circuit open until %s", source.Name, source.NextAttemptAt.Format(time.RFC3339))
+		return
+	}
+	if err := w.processSource(ctx, source, run); err != nil {
+		log.Printf("Error processing source %s: %v", source.Name, err)
+	}
 }
 
 func (w *Worker) ensureDefaultSource(ctx context.Context) error {
@@ -116,38 +220,91 @@ func (w *Worker) ensureDefaultSource(ctx context.Context) error {
 	return nil
 }
 
-func (w *Worker) processSource(ctx context.Context, source models.Source) error {
+func (w *Worker) processSource(ctx context.Context, source models.Source, run *ingestionRun) error {
 	log.Printf("Processing source: %s (%s)", source.Name, source.Type)
 
+	started := time.Now()
+	run.progress.sourceStarted(source)
 	fetchRun := &models.FetchRun{
-		SourceID: source.ID,
-		Status:   "success",
+		SourceID:   source.ID,
+		Status:     "success",
+		CrawlRunID: run.id(ctx),
 	}
 
 	items, err := w.fetchItems(ctx, source, fetchRun)
+	w.recordFetchOutcome(ctx, &source, err)
 	if err != nil {
+		run.record(source, started, itemCounts{}, err)
+		w.planNextPoll(ctx, &source)
 		return err
 	}
+	run.progress.sourceFetched(len(items))
 
 	fetchRun.ItemsFetched = len(items)
 	log.Printf("Fetched %d items from %s", len(items), source.Name)
 
-	inserted := w.processItems(ctx, source, items)
-	fetchRun.ItemsInserted = inserted
+	counts := w.processItems(ctx, source, items)
+	fetchRun.ItemsInserted = counts.Inserted
 
 	if err := w.fetchRunRepo.Create(ctx, fetchRun); err != nil {
 		log.Printf("Error creating fetch run: %v", err)
 	}
 
-	log.Printf("Inserted %d new items from %s", inserted, source.Name)
+	log.Printf("Inserted %d new items from %s (%d duplicates, %d rejected)", counts.Inserted, source.Name, counts.Duplicates, counts.Invalid)
+	w.planNextPoll(ctx, &source)
 
-	if err := w.computeScores(ctx); err != nil {
+	if err := w.computeScores(ctx, run.progress.enriching); err != nil {
 		log.Printf("Error computing scores: %v", err)
 	}
+	run.record(source, started, counts, nil)
 
 	return nil
 }
 
+// planNextPoll schedules the source's next poll, adapting the interval to
+// its recent publish rate when the source is adaptive.
+func (w *Worker) planNextPoll(ctx context.Context, source *models.Source) {
+	var recent []int
+	if source.Adaptive {
+		var err error
+		recent, err = w.fetchRunRepo.RecentInserted(ctx, source.ID, adaptiveSampleRuns)
+		if err != nil {
+			log.Printf("Warning: Failed to load fetch history for %s: %v", source.Name, err)
+		}
+	}
+
+	w.scheduler.Plan(source, time.Now(), recent)
+	if source.Adaptive && source.PollIntervalSeconds != nil {
+		log.Printf("Adaptive interval for %s: %s", source.Name, time.Duration(*source.PollIntervalSeconds)*time.Second)
+	}
+
+	if err := w.sourceRepo.UpdatePolling(ctx, source); err != nil {
+		log.Printf("Warning: Failed to persist polling schedule for %s: %v", source.Name, err)
+	}
+}
+
+// recordFetchOutcome feeds a fetch result into the source's circuit breaker,
+// persists the new state and disables sources that have been failing too long.
+func (w *Worker) recordFetchOutcome(ctx context.Context, source *models.Source, fetchErr error) {
+	now := time.Now()
+
+	if fetchErr == nil {
+		w.breaker.RecordSuccess(source, now)
+	} else if w.breaker.RecordFailure(source, now) {
+		log.Printf("Disabling source %s: failing since %s", source.Name, source.FailingSince.Format(time.RFC3339))
+		if err := w.sourceRepo.SetEnabled(ctx, source.ID, false, "failed"); err != nil {
+			log.Printf("Warning: Failed to disable source %s: %v", source.Name, err)
+		}
+	} else if source.CircuitState == CircuitOpen {
+		log.Printf("Circuit opened for source %s after %d consecutive failures, next attempt at %s",
+			source.Name, source.ConsecutiveFailures, source.NextAttemptAt.Format(time.RFC3339))
+	}
+
+	if err := w.sourceRepo.UpdateCircuit(ctx, source); err != nil {
+		log.Printf("Warning: Failed to persist circuit state for %s: %v", source.Name, err)
+	}
+}
+
 func (w *Worker) fetchItems(ctx context.Context, source models.Source, fetchRun *models.FetchRun) ([]dto.ContentItem, error) {
 	var items []dto.ContentItem
 	var err error
@@ -196,56 +353,88 @@ func (w *Worker) fetchJSONAPI(ctx context.Context, source models.Source) ([]dto.
 	return connectors.FetchJSONAPI(ctx, source.URL, mapping, w.cfg)
 }
 
-func (w *Worker) processItems(ctx context.Context, source models.Source, items []dto.ContentItem) int {
-	inserted := 0
+func (w *Worker) processItems(ctx context.Context, source models.Source, items []dto.ContentItem) itemCounts {
+	return countItems(items, func(contentItem dto.ContentItem) (bool, error) {
+		_, created, err := w.processItem(ctx, source, contentItem)
+		return created, err
+	})
+}
+
+// itemCounts tallies what became of one source's fetched items.
+type itemCounts struct {
+	Fetched    int
+	Inserted   int // newly stored
+	Duplicates int // already stored, as reported or under their canonical URL
+	Invalid    int // refused by processItem, e.g. a URL that does not parse
+	Failed     int // not stored because of an error on our side
+}
+
+func (c *itemCounts) add(o itemCounts) {
+	c.Fetched += o.Fetched
+	c.Inserted += o.Inserted
+	c.Duplicates += o.Duplicates
+	c.Invalid += o.Invalid
+	c.Failed += o.Failed
+}
+
+// Rejected is how many items were turned away: invalid ones and duplicates.
+func (c itemCounts) Rejected() int {
+	return c.Invalid + c.Duplicates
+}
+
+// Accepted is how many items passed validation and dedup.
+func (c itemCounts) Accepted() int {
+	return c.Fetched - c.Rejected()
+}
+
+// countItems runs process on each item and tallies the outcomes. Only items
+// it newly created count as inserted, so a feed that keeps listing the same
+// stories reads as quiet to the adaptive scheduler.
+func countItems(items []dto.ContentItem, process func(dto.ContentItem) (bool, error)) itemCounts {
+	counts := itemCounts{Fetched: len(items)}
 	for _, contentItem := range items {
-		if err := w.processItem(ctx, source, contentItem); err != nil {
+		created, err := process(contentItem)
+		switch {
+		case errors.Is(err, ingest.ErrInvalid):
+			log.Printf("Rejected item %s: %v", contentItem.Title, err)
+			counts.Invalid++
+		case err != nil:
 			log.Printf("Error processing item %s: %v", contentItem.Title, err)
-			continue
+			counts.Failed++
+		case created:
+			counts.Inserted++
+		default:
+			counts.Duplicates++
 		}
-		inserted++
 	}
-	return inserted
+	return counts
 }
 
-func (w *Worker) processItem(ctx context.Context, source models.Source, contentItem dto.ContentItem) error {
-	normalizedURL, err := normalizer.NormalizeURL(contentItem.URL)
-	if err != nil {
-		return fmt.Errorf("failed to normalize URL: %w", err)
+// processItem stores contentItem like storeItem and queues a new item's
+// LLM jobs. It reports whether the item was newly created.
+func (w *Worker) processItem(ctx context.Context, source models.Source, contentItem dto.ContentItem) (*models.Item, bool, error) {
+	item, created, err := w.storeItem(ctx, source, contentItem)
+	if err == nil && created {
+		w.queueLLMJobs(ctx, item, contentItem.Excerpt)
 	}
+	return item, created, err
+}
 
-	contentHash := normalizer.ContentHash(contentItem.Title, normalizedURL)
-
-	existing, err := w.itemRepo.GetByContentHash(ctx, contentHash)
+// storeItem stores contentItem (or finds the existing copy) and records its
+// signals, leaving LLM jobs to the caller. It reports whether the item was
+// newly created.
+func (w *Worker) storeItem(ctx context.Context, source models.Source, contentItem dto.ContentItem) (*models.Item, bool, error) {
+	item, created, err := w.ingester.Store(ctx, ingest.Candidate{
+		SourceID:    source.ID,
+		URL:         contentItem.URL,
+		Title:       contentItem.Title,
+		Excerpt:     contentItem.Excerpt,
+		Domain:      contentItem.Domain,
+		Category:    source.Category,
+		PublishedAt: contentItem.PublishedAt,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to check duplicate: %w", err)
-	}
-
-	var item *models.Item
-	if existing != nil {
-		item = existing
-	} else {
-		item = &models.Item{
-			SourceID:    source.ID,
-			Title:       contentItem.Title,
-			URL:         normalizedURL,
-			PublishedAt: contentItem.PublishedAt,
-			ContentHash: contentHash,
-			Domain:      contentItem.Domain,
-			Category:    source.Category,
-		}
-		if contentItem.Excerpt != "" {
-			item.RawExcerpt = &contentItem.Excerpt
-		}
-
-		if err := w.itemRepo.Create(ctx, item); err != nil {
-			return fmt.Errorf("failed to create item: %w", err)
-		}
-
-		summary := summarizer.GenerateExtractiveSummary(item)
-		if err := w.summaryRepo.Upsert(ctx, summary); err != nil {
-			log.Printf("Error creating summary: %v", err)
-		}
+		return nil, false, err
 	}
 
 	if contentItem.Points != nil || contentItem.Comments != nil || contentItem.RankPos != nil {
@@ -257,32 +446,84 @@ func (w *Worker) processItem(ctx context.Context, source models.Source, contentI
 		}
 		if err := w.signalRepo.Create(ctx, signal); err != nil {
 			log.Printf("Error creating signal: %v", err)
+		} else if err := w.updateVelocity(ctx, item); err != nil {
+			log.Printf("Error updating velocity for %s: %v", item.URL, err)
 		}
 	}
 
-	return nil
+	return item, created, nil
 }
 
-func (w *Worker) computeScores(ctx context.Context) error {
-	items, err := w.itemRepo.GetItemsNeedingScoring(ctx, 7, 1000)
+// updateVelocity recomputes item's engagement velocity from its recent
+// signals.
+func (w *Worker) updateVelocity(ctx context.Context, item *models.Item) error {
+	signals, err := w.signalRepo.GetRisingSignals(ctx, item.ID, w.cfg.RisingVelocityWindow())
+	if err != nil {
+		return err
+	}
+	if len(signals) == 0 {
+		return nil
+	}
+	return w.signalRepo.UpsertVelocity(ctx, scoring.ComputeVelocity(signals, item.PublishedAt))
+}
+
+// computeScores rescores recent items, calling onScored after each one.
+func (w *Worker) computeScores(ctx context.Context, onScored func(done, total int)) error {
+	// Scoring covers all recent items, so one replica at a time is enough.
+	unlock, acquired, err := w.db.TryLock(ctx, db.LockScoring)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		log.Println("Skipping score computation: another worker holds the scoring lock")
+		return nil
+	}
+	defer unlock()
+
+	// Look back as far as the longest decay profile can still change a score.
+	cfg := scoring.Current()
+	items, err := w.itemRepo.GetItemsNeedingScoring(ctx, cfg.Decay.WindowDays(), 1000, cfg.Version)
 	if err != nil {
 		return fmt.Errorf("failed to get items needing scoring: %w", err)
 	}
 
 	log.Printf("Computing scores for %d items", len(items))
 
-	for _, item := range items {
+	for i, item := range items {
+		onScored(i+1, len(items))
+
 		signal, _ := w.signalRepo.GetLatestByItemID(ctx, item.ID)
 		summary, _ := w.summaryRepo.GetByItemID(ctx, item.ID)
 		existingScore, _ := w.scoreRepo.GetByItemID(ctx, item.ID)
+		coverage, _ := w.itemRepo.GetCoverage(ctx, item.ID)
 
-		score := scoring.ComputeScore(&item, signal, summary, existingScore, scoring.DefaultWeights)
+		score := scoring.ComputeScoreWithConfig(&item, signal, summary, existingScore, coverage, cfg)
 
-		if err := w.scoreRepo.Upsert(ctx, score); err != nil {
-			log.Printf("Error upserting score: %v", err)
+		if err := w.scoreRepo.Record(ctx, score); err != nil {
+			log.Printf("Error recording score: %v", err)
 			continue
 		}
 	}
 
 	return nil
 }
+
+// queueLLMJobs queues a new item for the LLM jobs that are configured: a
+// summarize job, which also tags it against the taxonomy, and the entity
+// pass.
+func (w *Worker) queueLLMJobs(ctx context.Context, item *models.Item, excerpt string) {
+	var types []string
+	if tagging.LLMConfigured(w.cfg) {
+		types = append(types, jobs.TypeSummarize)
+	}
+	if entities.LLMConfigured(w.cfg) {
+		types = append(types, jobs.TypeEntities)
+	}
+
+	p := jobs.ItemPayload{ItemID: item.ID, Title: item.Title, Content: excerpt, URL: item.URL}
+	for _, t := range types {
+		if err := w.queue.EnqueueItem(ctx, t, p); err != nil {
+			log.Printf("Error queueing %s job for %s: %v", t, item.URL, err)
+		}
+	}
+}
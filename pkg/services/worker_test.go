@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/dto"
+	"github.com/hidatara-ds/evolipia-radar/pkg/ingest"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestCountItems(t *testing.T) {
+	items := []dto.ContentItem{{Title: "new"}, {Title: "stored"}, {Title: "stored again"}, {Title: "broken"}, {Title: "db down"}}
+	counts := countItems(items, func(ci dto.ContentItem) (bool, error) {
+		switch ci.Title {
+		case "new":
+			return true, nil
+		case "broken":
+			return false, fmt.Errorf("%w: bad url", ingest.ErrInvalid)
+		case "db down":
+			return false, errors.New("connection refused")
+		}
+		return false, nil
+	})
+	want := itemCounts{Fetched: 5, Inserted: 1, Duplicates: 2, Invalid: 1, Failed: 1}
+	if counts != want {
+		t.Errorf("countItems = %+v, want %+v", counts, want)
+	}
+	if counts.Rejected() != 3 || counts.Accepted() != 2 {
+		t.Errorf("rejected %d accepted %d, want 3 and 2", counts.Rejected(), counts.Accepted())
+	}
+}
+
+func TestCountItems_UnchangedFeedWidensInterval(t *testing.T) {
+	// A busy feed that re-lists the same 30 stories on every poll.
+	feed := make([]dto.ContentItem, 30)
+	for i := range feed {
+		feed[i].Title = fmt.Sprintf("story %d", i)
+	}
+	stored := func(dto.ContentItem) (bool, error) { return false, nil }
+
+	var recent []int
+	for i := 0; i < adaptiveSampleRuns; i++ {
+		recent = append(recent, countItems(feed, stored).Inserted)
+	}
+
+	interval := 3600
+	source := &models.Source{Adaptive: true, PollIntervalSeconds: &interval}
+	SourceScheduler{}.Plan(source, time.Now(), recent)
+	if got := time.Duration(*source.PollIntervalSeconds) * time.Second; got <= time.Hour {
+		t.Errorf("interval = %v, want wider than 1h for a feed with nothing new", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hidatara-ds/evolipia-radar/pkg/config"
 	"github.com/hidatara-ds/evolipia-radar/pkg/llm"
 	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/tagging"
 )
 
 func GenerateExtractiveSummary(item *models.Item) *models.Summary {
@@ -36,13 +37,14 @@ func GenerateExtractiveSummary(item *models.Item) *models.Summary {
 	whyItMatters := generateWhyItMatters(item, text)
 
 	// Extract tags
-	tags := extractTags(item, text)
+	tags := extractTags(text)
 
 	return &models.Summary{
 		ItemID:       item.ID,
 		TLDR:         tldr,
 		WhyItMatters: whyItMatters,
-		Tags:         tags,
+		Tags:         tagging.Names(tags),
+		TagScores:    tags,
 		Method:       "extractive",
 	}
 }
@@ -75,13 +77,14 @@ func GenerateLLMSummary(ctx context.Context, item *models.Item, cfg *config.Conf
 	}
 
 	// Extract tags using existing logic
-	tags := extractTags(item, content)
+	tags := extractTags(item.Title + " " + content)
 
 	return &models.Summary{
 		ItemID:       item.ID,
 		TLDR:         analysis.TLDR,
 		WhyItMatters: analysis.WhyItMatters,
-		Tags:         tags,
+		Tags:         tagging.Names(tags),
+		TagScores:    tags,
 		Method:       "llm",
 	}, nil
 }
@@ -140,29 +143,9 @@ func generateWhyItMatters(item *models.Item, text string) string {
 	return "Staying informed about AI/ML developments helps engineers make better technical decisions, adopt new tools and techniques, and understand the evolving landscape of machine learning."
 }
 
-func extractTags(item *models.Item, text string) []string {
-	return extractTagsWithConfig(item, text, DefaultTopicKeywordsConfig())
-}
-
-func extractTagsWithConfig(item *models.Item, text string, config TopicKeywordsConfig) []string {
-	textLower := strings.ToLower(text)
-	var tags []string
-
-	for topic, keywords := range config.Keywords {
-		for _, kw := range keywords {
-			if strings.Contains(textLower, kw) {
-				tags = append(tags, topic)
-				break // Only add topic once
-			}
-		}
-	}
-
-	// If no tags found, add general_ai
-	if len(tags) == 0 {
-		tags = append(tags, "general_ai")
-	}
-
-	return tags
+// extractTags tags text by keyword with the topic taxonomy.
+func extractTags(text string) []models.TagScore {
+	return tagging.NewAutoTagger().Score(text, "")
 }
 
 func contains(s, substr string) bool {
@@ -1,168 +1,80 @@
 package tagging
 
 import (
-	"regexp"
-	"strings"
+	"math"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
+)
+
+// Tag methods.
+const (
+	MethodKeyword = "keyword"
+	MethodLLM     = "llm"
 )
 
-// AutoTagger automatically assigns tags based on content analysis
+// fallbackConfidence is the confidence of taxonomy.FallbackTag when it is
+// assigned because no topic matched.
+const fallbackConfidence = 0.2
+
+// AutoTagger automatically assigns tags based on content analysis, using the
+// keywords and patterns of the topic taxonomy.
 type AutoTagger struct {
-	rules []TagRule
+	tax *taxonomy.Taxonomy // nil follows taxonomy.Current
 }
 
-// TagRule defines a pattern and corresponding tag
-type TagRule struct {
-	Tag      string
-	Patterns []string // Regex patterns
-	Keywords []string // Simple keyword matching (case-insensitive)
+// NewAutoTagger creates an auto-tagger that uses the active taxonomy, so
+// topic edits apply without rebuilding it.
+func NewAutoTagger() *AutoTagger {
+	return &AutoTagger{}
 }
 
-// NewAutoTagger creates a new auto-tagger with predefined rules
-func NewAutoTagger() *AutoTagger {
-	return &AutoTagger{
-		rules: []TagRule{
-			// LLM & Language Models
-			{
-				Tag: "llm",
-				Keywords: []string{
-					"gpt", "llama", "claude", "gemini", "mistral", "falcon",
-					"language model", "large language model", "transformer",
-					"chatgpt", "bard", "palm", "llm", "generative ai",
-				},
-			},
-			// Computer Vision
-			{
-				Tag: "vision",
-				Keywords: []string{
-					"diffusion", "stable diffusion", "dall-e", "dalle", "midjourney",
-					"computer vision", "image generation", "text-to-image",
-					"vision transformer", "vit", "clip", "image recognition",
-					"object detection", "segmentation",
-				},
-			},
-			// AI Safety & Alignment
-			{
-				Tag: "safety",
-				Keywords: []string{
-					"alignment", "rlhf", "safety", "constitutional",
-					"jailbreak", "adversarial", "robustness", "interpretability",
-					"explainability", "ai safety", "responsible ai",
-				},
-			},
-			// Reinforcement Learning
-			{
-				Tag: "rl",
-				Keywords: []string{
-					"reinforcement learning", "rl", "reward", "policy gradient",
-					"q-learning", "dqn", "ppo", "actor-critic", "markov",
-				},
-			},
-			// Robotics & Embodied AI
-			{
-				Tag: "robotics",
-				Keywords: []string{
-					"robot", "manipulation", "locomotion", "embodied",
-					"robotics", "autonomous", "drone", "self-driving",
-				},
-			},
-			// Data & Datasets
-			{
-				Tag: "data",
-				Keywords: []string{
-					"dataset", "benchmark", "evaluation", "leaderboard",
-					"data", "corpus", "annotation", "labeling",
-				},
-			},
-			// Security & Privacy
-			{
-				Tag: "security",
-				Keywords: []string{
-					"security", "privacy", "encryption", "vulnerability",
-					"attack", "defense", "backdoor", "poisoning",
-				},
-			},
-			// IDE & Developer Tools (NEW!)
-			{
-				Tag: "ide",
-				Keywords: []string{
-					"kiro", "cursor", "windsurf", "codeium", "copilot",
-					"github copilot", "tabnine", "replit", "ghostwriter",
-					"warp", "fig", "zed", "fleet", "nova",
-					"ai ide", "code editor", "code assistant",
-					"intellij", "vscode", "visual studio code",
-					"jetbrains", "sublime", "atom",
-				},
-			},
-			// Free Credits & Student Programs (NEW!)
-			{
-				Tag: "free-credits",
-				Keywords: []string{
-					"free credit", "free token", "free api", "student program",
-					"education program", "academic program", "free tier",
-					"free access", "student discount", "github student",
-					"anthropic student", "openai credit", "azure credit",
-					"gcp credit", "aws educate", "free trial",
-				},
-			},
-			// Research & Papers
-			{
-				Tag: "research",
-				Keywords: []string{
-					"arxiv", "paper", "research", "study", "conference",
-					"neurips", "icml", "iclr", "cvpr", "acl", "emnlp",
-				},
-			},
-			// Tools & Frameworks
-			{
-				Tag: "tools",
-				Keywords: []string{
-					"framework", "library", "tool", "sdk", "api",
-					"pytorch", "tensorflow", "jax", "huggingface",
-					"langchain", "llamaindex",
-				},
-			},
-		},
+// NewAutoTaggerWith creates an auto-tagger bound to tax.
+func NewAutoTaggerWith(tax *taxonomy.Taxonomy) *AutoTagger {
+	return &AutoTagger{tax: tax}
+}
+
+func (at *AutoTagger) active() *taxonomy.Taxonomy {
+	if at.tax != nil {
+		return at.tax
 	}
+	return taxonomy.Current()
 }
 
-// AssignTags analyzes title and content to assign relevant tags
+// AssignTags analyzes title and content to assign relevant topic slugs, or
+// taxonomy.FallbackTag when no topic matches.
 func (at *AutoTagger) AssignTags(title, content string) []string {
-	// Combine title and content for analysis (title weighted more)
-	text := strings.ToLower(title + " " + title + " " + content)
-
-	tagSet := make(map[string]bool)
-
-	for _, rule := range at.rules {
-		// Check keywords
-		for _, keyword := range rule.Keywords {
-			if strings.Contains(text, strings.ToLower(keyword)) {
-				tagSet[rule.Tag] = true
-				break
-			}
-		}
+	return at.active().Tag(title + " " + content)
+}
 
-		// Check regex patterns
-		for _, pattern := range rule.Patterns {
-			matched, err := regexp.MatchString(pattern, text)
-			if err == nil && matched {
-				tagSet[rule.Tag] = true
-				break
-			}
+// Score tags title and content like AssignTags, with a confidence for each
+// tag that grows with the number of the topic's keywords and patterns found:
+// 0.5 for one, 0.75 for two, 0.88 for three.
+func (at *AutoTagger) Score(title, content string) []models.TagScore {
+	var scores []models.TagScore
+	hits := at.active().Match(title + " " + content)
+	for i := 0; i < len(hits); {
+		n := 1
+		for i+n < len(hits) && hits[i+n].Topic == hits[i].Topic {
+			n++
 		}
+		confidence := math.Round((1-math.Pow(0.5, float64(n)))*100) / 100
+		scores = append(scores, models.TagScore{Tag: hits[i].Topic, Confidence: confidence, Method: MethodKeyword})
+		i += n
 	}
-
-	// Convert set to slice
-	tags := make([]string, 0, len(tagSet))
-	for tag := range tagSet {
-		tags = append(tags, tag)
+	if len(scores) == 0 {
+		return []models.TagScore{{Tag: taxonomy.FallbackTag, Confidence: fallbackConfidence, Method: MethodKeyword}}
 	}
+	return scores
+}
 
-	// If no tags matched, assign "general_ai"
-	if len(tags) == 0 {
-		tags = append(tags, "general_ai")
+// Names returns the tags of scores, in order.
+func Names(scores []models.TagScore) []string {
+	names := make([]string, len(scores))
+	for i, s := range scores {
+		names[i] = s.Tag
 	}
-
-	return tags
+	return names
 }
 
 // MergeTags combines existing tags with auto-generated tags (deduplicates)
@@ -1,7 +1,11 @@
 package tagging
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
 )
 
 func TestAutoTagger_AssignTags(t *testing.T) {
@@ -72,6 +76,22 @@ func TestAutoTagger_AssignTags(t *testing.T) {
 	}
 }
 
+func TestAutoTagger_Score(t *testing.T) {
+	tagger := NewAutoTaggerWith(taxonomy.Default())
+
+	scores := tagger.Score("LLM prompt tricks", "")
+	want := []models.TagScore{{Tag: "llm", Confidence: 0.75, Method: MethodKeyword}}
+	if !reflect.DeepEqual(scores, want) {
+		t.Errorf("Score = %+v, want %+v", scores, want)
+	}
+
+	scores = tagger.Score("Random tech news", "")
+	want = []models.TagScore{{Tag: taxonomy.FallbackTag, Confidence: fallbackConfidence, Method: MethodKeyword}}
+	if !reflect.DeepEqual(scores, want) {
+		t.Errorf("Score = %+v, want %+v", scores, want)
+	}
+}
+
 func TestMergeTags(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,117 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/ai"
+	"github.com/hidatara-ds/evolipia-radar/pkg/config"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
+)
+
+// minLLMConfidence drops topics the LLM itself considers unlikely.
+const minLLMConfidence = 0.3
+
+// LLMTagger classifies items against the topic taxonomy with an LLM, zero
+// shot: the prompt lists the topics and the LLM rates each that applies.
+type LLMTagger struct {
+	ai      *ai.Service
+	keyword *AutoTagger
+}
+
+func NewLLMTagger(aiSvc *ai.Service) *LLMTagger {
+	return &LLMTagger{ai: aiSvc, keyword: NewAutoTagger()}
+}
+
+// LLMConfigured reports whether items get LLM tags: TAG_LLM_ENABLED is set
+// and the AI service has a key, so job runners can process summarize jobs.
+// Ingestion queues a summarize job for every new item only then.
+func LLMConfigured(cfg *config.Config) bool {
+	return cfg.TagLLMEnabled && config.LoadAIConfig().APIKey != ""
+}
+
+// Tag returns the item's tags with the LLM's confidence in each. If the LLM
+// call fails, e.g. because the token budget is exhausted, the keyword tags
+// are returned together with the error.
+func (t *LLMTagger) Tag(ctx context.Context, title, content string) ([]models.TagScore, error) {
+	tax := taxonomy.Current()
+	content = ai.ArticleContent(content)
+
+	temperature := float32(0)
+	resp, err := t.ai.Chat(ctx, ai.ChatRequest{
+		Messages: []ai.ChatMessage{
+			{Role: ai.RoleSystem, Content: tagPrompt(tax)},
+			{Role: ai.RoleUser, Content: "Title: " + title + "\n\n" + content},
+		},
+		Temperature: &temperature,
+	})
+	if err != nil {
+		return t.keyword.Score(title, content), fmt.Errorf("topic classification failed: %w", err)
+	}
+
+	scores, err := parseTagScores(tax, resp.Content)
+	if err != nil {
+		return t.keyword.Score(title, content), err
+	}
+	if len(scores) == 0 {
+		return []models.TagScore{{Tag: taxonomy.FallbackTag, Confidence: fallbackConfidence, Method: MethodLLM}}, nil
+	}
+	return scores, nil
+}
+
+func tagPrompt(tax *taxonomy.Taxonomy) string {
+	var b strings.Builder
+	b.WriteString("Classify the AI/ML news article into these topics:\n")
+	for _, topic := range tax.Topics() {
+		fmt.Fprintf(&b, "- %s: %s", topic.Slug, topic.Name)
+		if topic.Parent != "" {
+			fmt.Fprintf(&b, " (part of %s)", topic.Parent)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("Pick every topic the article is substantially about, with your confidence from 0 to 1. " +
+		"Reply with only a JSON object: {\"tags\": [{\"topic\": \"<topic>\", \"confidence\": <0-1>}]}.")
+	return b.String()
+}
+
+// parseTagScores reads the LLM's reply. Topics not in tax are dropped, as
+// are those below minLLMConfidence; the rest are sorted by confidence.
+func parseTagScores(tax *taxonomy.Taxonomy, content string) ([]models.TagScore, error) {
+	var out struct {
+		Tags []struct {
+			Topic      string  `json:"topic"`
+			Confidence float64 `json:"confidence"`
+		} `json:"tags"`
+	}
+	if err := ai.DecodeJSONReply(content, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse topic classification: %w", err)
+	}
+
+	best := make(map[string]float64)
+	for _, t := range out.Tags {
+		slug, ok := tax.Resolve(t.Topic)
+		if !ok {
+			continue
+		}
+		confidence := math.Round(math.Min(math.Max(t.Confidence, 0), 1)*100) / 100
+		if confidence >= minLLMConfidence && confidence > best[slug] {
+			best[slug] = confidence
+		}
+	}
+
+	scores := make([]models.TagScore, 0, len(best))
+	for slug, confidence := range best {
+		scores = append(scores, models.TagScore{Tag: slug, Confidence: confidence, Method: MethodLLM})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Confidence != scores[j].Confidence {
+			return scores[i].Confidence > scores[j].Confidence
+		}
+		return scores[i].Tag < scores[j].Tag
+	})
+	return scores, nil
+}
@@ -0,0 +1,35 @@
+package tagging
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+	"github.com/hidatara-ds/evolipia-radar/pkg/taxonomy"
+)
+
+func TestParseTagScores(t *testing.T) {
+	reply := "Here you go:\n" + `{"tags": [
+		{"topic": "vision", "confidence": 0.62},
+		{"topic": "LLM", "confidence": 1.4},
+		{"topic": "infra", "confidence": 0.5},
+		{"topic": "cooking", "confidence": 0.9},
+		{"topic": "robotics", "confidence": 0.1}
+	]}`
+	got, err := parseTagScores(taxonomy.Default(), reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []models.TagScore{
+		{Tag: "llm", Confidence: 1, Method: MethodLLM},
+		{Tag: "vision", Confidence: 0.62, Method: MethodLLM},
+		{Tag: "mlops", Confidence: 0.5, Method: MethodLLM}, // via its alias
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTagScores = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseTagScores(taxonomy.Default(), "no json here"); err == nil {
+		t.Error("expected an error for a reply without JSON")
+	}
+}
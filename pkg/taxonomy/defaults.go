@@ -0,0 +1,133 @@
+package taxonomy
+
+import "github.com/hidatara-ds/evolipia-radar/pkg/models"
+
+// DefaultTopics returns the compiled-in taxonomy, seeded into an empty
+// topics table. Short or ambiguous terms are patterns with word boundaries
+// so they do not match inside longer words.
+func DefaultTopics() []models.Topic {
+	return []models.Topic{
+		{
+			Slug: "general_ai", Name: "General AI",
+			Aliases:  []string{"ai", "artificial intelligence", "machine learning"},
+			Keywords: []string{"artificial intelligence", "machine learning", "deep learning"},
+			Patterns: []string{`\bai\b`},
+		},
+		{
+			Slug: "llm", Name: "Large Language Models",
+			Aliases: []string{"large language models", "language models", "gpt", "claude", "llama"},
+			Keywords: []string{
+				"llm", "large language model", "language model", "transformer", "generative ai",
+				"gpt", "chatgpt", "claude", "gemini", "llama", "mistral", "falcon",
+				"prompt", "inference", "fine-tune",
+			},
+			Patterns: []string{`\brag\b`, `\bbard\b`, `\bpalm\b`},
+		},
+		{
+			Slug: "agents", Parent: "llm", Name: "AI Agents",
+			Aliases:  []string{"autonomous agents", "autonomous", "autogpt", "babyagi"},
+			Keywords: []string{"ai agent", "agentic", "multi-agent", "autogpt", "babyagi"},
+		},
+		{
+			Slug: "nlp", Name: "Natural Language Processing",
+			Aliases:  []string{"natural language processing"},
+			Keywords: []string{"nlp", "natural language", "text processing", "sentiment"},
+		},
+		{
+			Slug: "vision", Name: "Computer Vision",
+			Aliases: []string{"computer_vision", "computer vision", "cv", "image generation", "midjourney", "dalle"},
+			Keywords: []string{
+				"computer vision", "diffusion", "stable diffusion", "dall-e", "dalle", "midjourney",
+				"image generation", "text-to-image", "vision transformer", "image recognition",
+				"object detection", "segmentation", "detection", "yolo", "opencv",
+			},
+			Patterns: []string{`\bvit\b`, `\bclip\b`},
+		},
+		{
+			Slug: "mlops", Name: "MLOps & Infrastructure",
+			Aliases:  []string{"infra", "infrastructure", "deployment", "kubernetes"},
+			Keywords: []string{"mlops", "deployment", "monitoring", "drift", "kubernetes", "kubeflow", "airflow", "feature store"},
+		},
+		{
+			Slug: "cloud", Name: "Cloud",
+			Keywords: []string{"gcp", "azure", "cloud", "lambda"},
+			Patterns: []string{`\baws\b`, `\bs3\b`},
+		},
+		{
+			Slug: "security", Name: "Security & Privacy",
+			Keywords: []string{"security", "privacy", "encryption", "vulnerability", "attack", "defense", "backdoor", "poisoning"},
+		},
+		{
+			Slug: "safety", Parent: "security", Name: "AI Safety & Alignment",
+			Aliases: []string{"alignment", "jailbreak", "ai safety"},
+			Keywords: []string{
+				"alignment", "rlhf", "safety", "constitutional", "jailbreak", "adversarial", "robustness",
+				"interpretability", "explainability", "ai safety", "responsible ai",
+			},
+		},
+		{
+			Slug: "rl", Name: "Reinforcement Learning",
+			Aliases:  []string{"reinforcement learning"},
+			Keywords: []string{"reinforcement learning", "reward", "policy gradient", "q-learning", "dqn", "actor-critic", "markov"},
+			Patterns: []string{`\brl\b`, `\bppo\b`},
+		},
+		{
+			Slug: "robotics", Name: "Robotics & Embodied AI",
+			Aliases:  []string{"embodied ai", "control"},
+			Keywords: []string{"robot", "robotics", "manipulation", "locomotion", "embodied", "autonomous", "drone", "self-driving"},
+		},
+		{
+			Slug: "data", Name: "Data & Datasets",
+			Aliases:  []string{"datasets"},
+			Keywords: []string{"dataset", "benchmark", "evaluation", "leaderboard", "data", "corpus", "annotation", "labeling"},
+			Patterns: []string{`\betl\b`},
+		},
+		{
+			Slug: "research", Name: "Research & Papers",
+			Aliases:  []string{"papers"},
+			Keywords: []string{"arxiv", "paper", "research", "study", "conference", "neurips", "icml", "iclr", "cvpr", "emnlp"},
+			Patterns: []string{`\bacl\b`},
+		},
+		{
+			Slug: "tools", Name: "Tools & Frameworks",
+			Aliases: []string{"frameworks"},
+			Keywords: []string{
+				"framework", "library", "tool", "sdk", "pytorch", "tensorflow", "jax", "huggingface",
+				"langchain", "llamaindex",
+			},
+			Patterns: []string{`\bapi\b`},
+		},
+		{
+			Slug: "ide", Parent: "tools", Name: "AI IDEs & Code Assistants",
+			Aliases: []string{"code assistants"},
+			Keywords: []string{
+				"kiro", "cursor", "windsurf", "codeium", "copilot", "github copilot", "tabnine", "replit",
+				"ghostwriter", "ai ide", "code editor", "code assistant",
+				"intellij", "vscode", "visual studio code", "jetbrains", "sublime",
+			},
+			Patterns: []string{`\bwarp\b`, `\bfig\b`, `\bzed\b`, `\bfleet\b`, `\bnova\b`, `\batom\b`},
+		},
+		{
+			Slug: "open-source", Name: "Open Source",
+			Aliases:  []string{"open source", "open_source", "oss", "huggingface"},
+			Keywords: []string{"open source", "open-source", "open weights", "open-weight"},
+		},
+		{
+			Slug: "free-credits", Name: "Free Credits & Student Programs",
+			Keywords: []string{
+				"free credit", "free token", "free api", "student program", "education program",
+				"academic program", "free tier", "free access", "student discount", "github student",
+				"anthropic student", "openai credit", "azure credit", "gcp credit", "aws educate", "free trial",
+			},
+		},
+	}
+}
+
+// Default returns the compiled-in taxonomy.
+func Default() *Taxonomy {
+	t, err := New(DefaultTopics())
+	if err != nil {
+		panic("taxonomy: invalid default topics: " + err.Error())
+	}
+	return t
+}
@@ -0,0 +1,120 @@
+package taxonomy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/db"
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+var (
+	// ErrInvalid wraps edits that would leave the taxonomy invalid.
+	ErrInvalid = errors.New("invalid taxonomy")
+	// ErrNotFound is returned when deleting a topic that does not exist.
+	ErrNotFound = errors.New("topic not found")
+	// ErrHasChildren is returned when deleting a topic other topics sit under.
+	ErrHasChildren = errors.New("topic has children")
+)
+
+// Service keeps the stored taxonomy and Current in step.
+type Service struct {
+	repo *db.TopicRepository
+}
+
+func NewService(database *db.DB) *Service {
+	return &Service{repo: db.NewTopicRepository(database)}
+}
+
+// Load seeds an empty topics table with DefaultTopics and installs the
+// stored taxonomy as Current. An invalid stored taxonomy is reported and the
+// active one kept.
+func (s *Service) Load(ctx context.Context) (*Taxonomy, error) {
+	seeded, err := s.repo.SeedIfEmpty(ctx, DefaultTopics())
+	if err != nil {
+		return Current(), fmt.Errorf("failed to seed topics: %w", err)
+	}
+	if seeded {
+		log.Printf("Seeded topic taxonomy with %d default topics", len(DefaultTopics()))
+	}
+
+	topics, err := s.repo.List(ctx)
+	if err != nil {
+		return Current(), fmt.Errorf("failed to list topics: %w", err)
+	}
+	t, err := New(topics)
+	if err != nil {
+		return Current(), fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if prev := Current(); prev.Version() != t.Version() {
+		log.Printf("Topic taxonomy %s loaded (%d topics)", t.Version(), len(topics))
+	}
+	SetCurrent(t)
+	return t, nil
+}
+
+// Watch reloads the taxonomy every interval until ctx is cancelled, so edits
+// made through another process reach this one.
+func (s *Service) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Load(ctx); err != nil {
+				log.Printf("Topic taxonomy reload failed: %v", err)
+			}
+		}
+	}
+}
+
+// Put creates or replaces a topic. The edit is validated against the rest of
+// the taxonomy first and rejected with ErrInvalid if it would break it.
+func (s *Service) Put(ctx context.Context, topic *models.Topic) (*Taxonomy, error) {
+	topics, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidate := make([]models.Topic, 0, len(topics)+1)
+	for _, t := range topics {
+		if t.Slug != topic.Slug {
+			candidate = append(candidate, t)
+		}
+	}
+	if _, err := New(append(candidate, *topic)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	if err := s.repo.Upsert(ctx, topic); err != nil {
+		return nil, err
+	}
+	return s.Load(ctx)
+}
+
+// Delete removes a topic. Topics with children must have them moved or
+// deleted first.
+func (s *Service) Delete(ctx context.Context, slug string) (*Taxonomy, error) {
+	if _, err := s.Load(ctx); err != nil {
+		return nil, err
+	}
+	if len(Current().Children(slug)) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrHasChildren, slug)
+	}
+	found, err := s.repo.Delete(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, slug)
+	}
+	return s.Load(ctx)
+}
@@ -0,0 +1,273 @@
+// Package taxonomy holds the topic tree that tagging, feed topic filters and
+// relevance scoring share. Topics have an optional parent, aliases (other
+// names a tag or filter may use), keywords and regular expressions. The tree
+// is stored in the topics table and installed process-wide as Current.
+package taxonomy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+// FallbackTag is assigned to items no topic matches.
+const FallbackTag = "general_ai"
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Taxonomy is a validated, immutable topic tree.
+type Taxonomy struct {
+	topics   map[string]*topic
+	slugs    []string            // sorted
+	names    map[string]string   // lowercased slug or alias → slug
+	children map[string][]string // sorted
+	version  string
+}
+
+type topic struct {
+	models.Topic
+	keywords []string // lowercased
+	patterns []*regexp.Regexp
+}
+
+// Hit is a keyword or pattern of a topic found in a text.
+type Hit struct {
+	Topic   string
+	Keyword string // the keyword, or the pattern that matched
+}
+
+// New validates topics and builds a Taxonomy. Every problem is reported at
+// once.
+func New(topics []models.Topic) (*Taxonomy, error) {
+	t := &Taxonomy{
+		topics:   make(map[string]*topic, len(topics)),
+		names:    make(map[string]string),
+		children: make(map[string][]string),
+	}
+
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for _, tp := range topics {
+		if !slugPattern.MatchString(tp.Slug) {
+			fail("topic %q: slug must be lowercase letters, digits, '-' or '_'", tp.Slug)
+			continue
+		}
+		if _, dup := t.topics[tp.Slug]; dup {
+			fail("topic %s: defined twice", tp.Slug)
+			continue
+		}
+		if tp.Name == "" {
+			tp.Name = tp.Slug
+		}
+		n := &topic{Topic: tp}
+		for _, kw := range tp.Keywords {
+			if kw = strings.ToLower(strings.TrimSpace(kw)); kw != "" {
+				n.keywords = append(n.keywords, kw)
+			}
+		}
+		for _, p := range tp.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				fail("topic %s: pattern %q: %v", tp.Slug, p, err)
+				continue
+			}
+			n.patterns = append(n.patterns, re)
+		}
+		t.topics[tp.Slug] = n
+		t.slugs = append(t.slugs, tp.Slug)
+		t.names[tp.Slug] = tp.Slug
+	}
+	sort.Strings(t.slugs)
+
+	for _, slug := range t.slugs {
+		n := t.topics[slug]
+		for _, alias := range n.Aliases {
+			key := strings.ToLower(strings.TrimSpace(alias))
+			if key == "" {
+				continue
+			}
+			if other, taken := t.names[key]; taken && other != slug {
+				fail("topic %s: alias %q already names %s", slug, alias, other)
+				continue
+			}
+			t.names[key] = slug
+		}
+
+		if n.Parent == "" {
+			continue
+		}
+		if _, ok := t.topics[n.Parent]; !ok {
+			fail("topic %s: unknown parent %s", slug, n.Parent)
+			continue
+		}
+		t.children[n.Parent] = append(t.children[n.Parent], slug)
+	}
+
+	for _, slug := range t.slugs {
+		seen := map[string]bool{slug: true}
+		for p := t.topics[slug].Parent; p != ""; p = t.topics[p].Parent {
+			if _, ok := t.topics[p]; !ok {
+				break
+			}
+			if seen[p] {
+				fail("topic %s: parent chain loops through %s", slug, p)
+				break
+			}
+			seen[p] = true
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+		return nil, errors.Join(errs...)
+	}
+
+	content := t.Topics()
+	for i := range content {
+		content[i].UpdatedAt = time.Time{}
+	}
+	data, _ := json.Marshal(content)
+	sum := sha256.Sum256(data)
+	t.version = hex.EncodeToString(sum[:])[:12]
+	return t, nil
+}
+
+// Version is a hash of the taxonomy's content, edit times excluded.
+func (t *Taxonomy) Version() string {
+	return t.version
+}
+
+// Topics returns every topic ordered by slug.
+func (t *Taxonomy) Topics() []models.Topic {
+	out := make([]models.Topic, len(t.slugs))
+	for i, slug := range t.slugs {
+		tp := t.topics[slug].Topic
+		tp.UpdatedAt = tp.UpdatedAt.UTC()
+		out[i] = tp
+	}
+	return out
+}
+
+// Get returns the topic with slug.
+func (t *Taxonomy) Get(slug string) (models.Topic, bool) {
+	n, ok := t.topics[slug]
+	if !ok {
+		return models.Topic{}, false
+	}
+	return n.Topic, true
+}
+
+// Children returns the slugs of slug's direct children.
+func (t *Taxonomy) Children(slug string) []string {
+	return t.children[slug]
+}
+
+// Resolve maps a slug or alias, in any case, to its topic slug.
+func (t *Taxonomy) Resolve(name string) (string, bool) {
+	slug, ok := t.names[strings.ToLower(strings.TrimSpace(name))]
+	return slug, ok
+}
+
+// Ancestors returns slug followed by its parent, grandparent and so on.
+func (t *Taxonomy) Ancestors(slug string) []string {
+	var chain []string
+	for n, ok := t.topics[slug]; ok; n, ok = t.topics[n.Parent] {
+		chain = append(chain, n.Slug)
+	}
+	return chain
+}
+
+// Expand returns the lowercased tag values a filter on name should accept:
+// the topic's slug and aliases and those of all its descendants. An unknown
+// name matches only itself.
+func (t *Taxonomy) Expand(name string) []string {
+	slug, ok := t.Resolve(name)
+	if !ok {
+		return []string{strings.ToLower(strings.TrimSpace(name))}
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	queue := []string{slug}
+	for len(queue) > 0 {
+		n := t.topics[queue[0]]
+		queue = append(queue[1:], t.children[n.Slug]...)
+		add(n.Slug)
+		for _, alias := range n.Aliases {
+			add(alias)
+		}
+	}
+	return tags
+}
+
+// Match returns every keyword and pattern hit in text, by topic slug. text
+// is lowercased before matching.
+func (t *Taxonomy) Match(text string) []Hit {
+	text = strings.ToLower(text)
+	var hits []Hit
+	for _, slug := range t.slugs {
+		n := t.topics[slug]
+		for _, kw := range n.keywords {
+			if strings.Contains(text, kw) {
+				hits = append(hits, Hit{Topic: slug, Keyword: kw})
+			}
+		}
+		for _, re := range n.patterns {
+			if re.MatchString(text) {
+				hits = append(hits, Hit{Topic: slug, Keyword: re.String()})
+			}
+		}
+	}
+	return hits
+}
+
+// Tag returns the sorted slugs of the topics matching text, or FallbackTag
+// when none do.
+func (t *Taxonomy) Tag(text string) []string {
+	var tags []string
+	for _, h := range t.Match(text) {
+		if len(tags) == 0 || tags[len(tags)-1] != h.Topic {
+			tags = append(tags, h.Topic)
+		}
+	}
+	if len(tags) == 0 {
+		return []string{FallbackTag}
+	}
+	return tags
+}
+
+var current atomic.Pointer[Taxonomy]
+
+// Current returns the active taxonomy, the compiled-in defaults until one is
+// loaded.
+func Current() *Taxonomy {
+	if t := current.Load(); t != nil {
+		return t
+	}
+	current.CompareAndSwap(nil, Default())
+	return current.Load()
+}
+
+// SetCurrent installs t as the active taxonomy.
+func SetCurrent(t *Taxonomy) {
+	current.Store(t)
+}
@@ -0,0 +1,109 @@
+package taxonomy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hidatara-ds/evolipia-radar/pkg/models"
+)
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		topics []models.Topic
+		want   string
+	}{
+		{"bad slug", []models.Topic{{Slug: "Computer Vision"}}, "slug must be"},
+		{"duplicate", []models.Topic{{Slug: "llm"}, {Slug: "llm"}}, "defined twice"},
+		{"unknown parent", []models.Topic{{Slug: "agents", Parent: "llm"}}, "unknown parent llm"},
+		{"cycle", []models.Topic{{Slug: "a", Parent: "b"}, {Slug: "b", Parent: "a"}}, "loops through"},
+		{"alias clash", []models.Topic{{Slug: "cv", Aliases: []string{"vision"}}, {Slug: "vision"}}, `alias "vision" already names vision`},
+		{"bad pattern", []models.Topic{{Slug: "llm", Patterns: []string{`\bgpt(`}}}, "pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.topics)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefault_Expand(t *testing.T) {
+	tax := Default()
+
+	// A filter on a parent topic also accepts its sub-topics' tags.
+	llm := tax.Expand("LLM")
+	for _, want := range []string{"llm", "gpt", "agents", "autogpt"} {
+		if !contains(llm, want) {
+			t.Errorf("Expand(LLM) = %v, missing %q", llm, want)
+		}
+	}
+	if agents := tax.Expand("agents"); contains(agents, "llm") {
+		t.Errorf("Expand(agents) = %v, should not include its parent", agents)
+	}
+
+	// Aliases resolve to their topic.
+	if got := tax.Expand("infra"); !contains(got, "mlops") || !contains(got, "kubernetes") {
+		t.Errorf("Expand(infra) = %v, want mlops and kubernetes", got)
+	}
+	if got := tax.Expand("Cooking"); !reflect.DeepEqual(got, []string{"cooking"}) {
+		t.Errorf("Expand(Cooking) = %v, want only itself", got)
+	}
+}
+
+func TestDefault_Tag(t *testing.T) {
+	tax := Default()
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Building a multi-agent planner", []string{"agents"}},
+		{"RAG pipelines for LLM apps", []string{"llm"}},
+		{"Storage prices fall", []string{FallbackTag}}, // "rag" inside a word is not a match
+		{"New AI chip announced", []string{"general_ai"}},
+	}
+	for _, tt := range tests {
+		if got := tax.Tag(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tag(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestVersion_IgnoresEditTimes(t *testing.T) {
+	a, err := New(DefaultTopics())
+	if err != nil {
+		t.Fatal(err)
+	}
+	topics := a.Topics()
+	for i := range topics {
+		topics[i].UpdatedAt = topics[i].UpdatedAt.AddDate(1, 0, 0)
+	}
+	b, err := New(topics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Version() != b.Version() {
+		t.Error("edit times changed the version")
+	}
+
+	topics[0].Keywords = append(topics[0].Keywords, "new keyword")
+	c, err := New(topics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Version() == a.Version() {
+		t.Error("keyword change kept the version")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 )
 
 // HashString generates a SHA-256 hex hash of the given string.
@@ -27,6 +28,18 @@ func NormalizeTitle(title string) string {
 	return strings.Join(strings.Fields(sb.String()), " ")
 }
 
+// Truncate shortens s to at most maxBytes bytes without splitting a UTF-8
+// character.
+func Truncate(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
+
 // IsValidURL verifies if the string is a valid HTTP or HTTPS URL.
 func IsValidURL(rawURL string) bool {
 	u, err := url.ParseRequestURI(rawURL)
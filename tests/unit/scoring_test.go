@@ -72,6 +72,25 @@ func TestComputeScore_Relevance(t *testing.T) {
 	assert.Greater(t, relevant.Final, irrelevant.Final, "ML-related content should have higher score")
 }
 
+func TestComputeScore_RelevanceFromSubTopic(t *testing.T) {
+	item := &models.Item{
+		ID:          uuid.New(),
+		Title:       "Agentic workflows in production",
+		PublishedAt: time.Now(),
+	}
+
+	score := scoring.ComputeScore(item, nil, &models.Summary{Tags: []string{"agents"}}, nil, scoring.DefaultWeights)
+	require.NotNil(t, score.Explanation)
+
+	// agents has no weight of its own and counts for its parent, llm.
+	var keywords []string
+	for _, m := range score.Explanation.Keywords {
+		keywords = append(keywords, m.Group+":"+m.Topic+":"+m.Keyword)
+	}
+	assert.ElementsMatch(t, []string{"llm:agents:agentic", "tag:agents:agents"}, keywords)
+	assert.NotEmpty(t, score.Explanation.TaxonomyVersion)
+}
+
 func TestComputeScore_Credibility(t *testing.T) {
 	now := time.Now()
 
@@ -144,6 +163,76 @@ func TestComputeScore_Novelty(t *testing.T) {
 	assert.Greater(t, scoreDayOld.Final, scoreWeekOld.Final)
 }
 
+func TestComputeScore_Explanation(t *testing.T) {
+	item := &models.Item{
+		ID:          uuid.New(),
+		Title:       "LLM inference on Kubernetes",
+		Domain:      "arxiv.org",
+		PublishedAt: time.Now().Add(-48 * time.Hour),
+	}
+
+	heuristic := scoring.ComputeScore(item, nil, nil, nil, scoring.DefaultWeights)
+	exp := heuristic.Explanation
+	require.NotNil(t, exp)
+
+	total := 0.0
+	for i, c := range exp.Components {
+		assert.InDelta(t, c.Value*c.Weight, c.Contribution, 1e-9, c.Name)
+		if i > 0 {
+			assert.GreaterOrEqual(t, exp.Components[i-1].Contribution, c.Contribution, "components sorted by contribution")
+		}
+		if c.Name == "impact" {
+			assert.Equal(t, "heuristic", c.Source)
+		}
+		total += c.Contribution
+	}
+	assert.InDelta(t, heuristic.Final, total, 1e-9)
+	assert.Equal(t, heuristic.ConfigVersion, exp.ConfigVersion)
+
+	var keywords []string
+	for _, m := range exp.Keywords {
+		keywords = append(keywords, m.Group+":"+m.Keyword)
+	}
+	assert.ElementsMatch(t, []string{"llm:llm", "llm:inference", "mlops:kubernetes"}, keywords)
+	assert.Equal(t, "tier1", exp.Credibility.Tier)
+	assert.InDelta(t, 0.5, exp.Decay.HotDecayFactor, 0.01, "48h old item at a 48h half-life")
+	assert.False(t, exp.Provenance.LLMScores)
+
+	ratings := &models.LLMRatings{Impact: 9, EngineeringValue: 8, Novelty: 7}
+	llm := scoring.ComputeScore(item, nil, nil, &models.Score{LLMRatings: ratings, Reasoning: "new method"}, scoring.DefaultWeights)
+	assert.True(t, llm.Explanation.Provenance.LLMScores)
+	assert.Equal(t, "new method", llm.Explanation.Provenance.Reasoning)
+	for _, c := range llm.Explanation.Components {
+		if c.Name == "impact" {
+			assert.Equal(t, "llm", c.Source)
+			assert.InDelta(t, 0.9, c.Value, 1e-9)
+		}
+	}
+}
+
+func TestComputeScore_RescoreIsStable(t *testing.T) {
+	item := &models.Item{
+		ID:          uuid.New(),
+		Title:       "LLM inference on Kubernetes",
+		Domain:      "arxiv.org",
+		PublishedAt: time.Now().Add(-6 * time.Hour),
+	}
+	analyzed := &models.Score{
+		ItemID:     item.ID,
+		LLMRatings: &models.LLMRatings{Impact: 9, EngineeringValue: 8, Novelty: 7},
+		Reasoning:  "new method",
+	}
+
+	first := scoring.ComputeScoreWithConfig(item, nil, nil, analyzed, nil, scoring.DefaultConfig())
+	second := scoring.ComputeScoreWithConfig(item, nil, nil, first, nil, scoring.DefaultConfig())
+	third := scoring.ComputeScoreWithConfig(item, nil, nil, second, nil, scoring.DefaultConfig())
+
+	assert.InDelta(t, first.Final, second.Final, 1e-6)
+	assert.InDelta(t, first.Final, third.Final, 1e-6)
+	assert.InDelta(t, 0.9, third.Impact, 1e-9)
+	assert.Equal(t, analyzed.LLMRatings, third.LLMRatings)
+}
+
 func BenchmarkComputeScore(b *testing.B) {
 	item := &models.Item{
 		ID:          uuid.New(),